@@ -1,29 +1,293 @@
 package app
 
-import "product-app/common/postgresql"
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"product-app/common/postgresql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigFileEnvironmentVariable, konfigürasyon dosyasının yolunu belirten ortam değişkenidir.
+const ConfigFileEnvironmentVariable = "PRODUCTAPP_CONFIG_FILE"
+
+// defaultConfigFile, ortam değişkeni verilmediğinde aranan konfigürasyon dosyasıdır.
+// Bu dosya yoksa varsayılan ayarlar kullanılır.
+const defaultConfigFile = "config/application.yaml"
 
 // ConfigurationManager, uygulama ayarlarını yöneten bir yapı tanımıdır.
 type ConfigurationManager struct {
-	PostgreSqlConfig postgresql.Config // PostgreSQL bağlantı ayarlarını tutar.
+	PostgreSqlConfig   postgresql.Config  `yaml:"postgresql"`    // PostgreSQL bağlantı ayarlarını tutar.
+	ServerConfig       ServerConfig       `yaml:"server"`        // HTTP sunucusu ayarlarını tutar.
+	ExchangeRateConfig ExchangeRateConfig `yaml:"exchangeRates"` // Döviz kuru kaynağı ayarlarını tutar.
+	TrashConfig        TrashConfig        `yaml:"trash"`         // Silinen ürünlerin çöp kutusunda tutulma ayarlarını tutar.
+	IdempotencyConfig  IdempotencyConfig  `yaml:"idempotency"`   // Idempotency anahtarlarının saklanma ayarlarını tutar.
 }
 
-// NewConfigurationManager, yeni bir ConfigurationManager nesnesi oluşturur ve döndürür.
-func NewConfigurationManager() *ConfigurationManager {
-	postgreSqlConfig := getPostgreSqlConfig() // PostgreSQL ayarlarını alır.
-	return &ConfigurationManager{
-		PostgreSqlConfig: postgreSqlConfig, // ConfigurationManager içinde PostgreSQL ayarlarını saklar.
+// ServerConfig, HTTP sunucusunun dinleyeceği adresi ve zaman aşımı sürelerini tutar.
+type ServerConfig struct {
+	Address         string        `yaml:"address"`         // Sunucunun dinleyeceği adres.
+	ReadTimeout     time.Duration `yaml:"readTimeout"`     // İsteğin tamamının okunması için azami süre.
+	WriteTimeout    time.Duration `yaml:"writeTimeout"`    // Yanıtın yazılması için azami süre.
+	IdleTimeout     time.Duration `yaml:"idleTimeout"`     // Keep-alive bağlantılarının boşta kalabileceği azami süre.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"` // Kapanışta devam eden isteklerin tamamlanması için azami süre.
+}
+
+// ExchangeRateConfig, fiyat dönüştürmede kullanılacak döviz kuru kaynağını belirler.
+type ExchangeRateConfig struct {
+	File string `yaml:"file"` // Kurların okunacağı YAML/JSON dosyası; boşsa exchange_rates tablosu kullanılır.
+}
+
+// TrashConfig, silinen ürünlerin çöp kutusunda ne kadar tutulacağını ve ne sıklıkla temizleneceğini belirler.
+type TrashConfig struct {
+	Retention     time.Duration `yaml:"retention"`     // Silinen ürünün kalıcı olarak silinmeden önce geri yüklenebileceği süre.
+	PurgeInterval time.Duration `yaml:"purgeInterval"` // Süresi dolan ürünleri kalıcı olarak silen işin çalışma aralığı.
+}
+
+// IdempotencyConfig, Idempotency-Key başlığıyla saklanan yanıtların ne kadar tutulacağını belirler.
+type IdempotencyConfig struct {
+	TTL           time.Duration `yaml:"ttl"`           // Bir anahtarın ve yanıtının saklandığı süre; süre dolunca anahtar yeniden kullanılabilir.
+	PurgeInterval time.Duration `yaml:"purgeInterval"` // Süresi dolan anahtarları silen işin çalışma aralığı.
+	Lease         time.Duration `yaml:"lease"`         // İşlenmekte olan bir isteğin anahtarı kilitli tuttuğu süre; süre dolunca anahtar yeniden ayrılabilir.
+	MaxBodySize   int           `yaml:"maxBodySize"`   // Özeti alınmak için belleğe okunan istek gövdesinin bayt cinsinden en büyük boyutu.
+}
+
+// NewConfigurationManager, ayarları sırasıyla varsayılan değerlerden, konfigürasyon dosyasından
+// ve PRODUCTAPP_* ortam değişkenlerinden yükler, doğrular ve döndürür.
+// Dosya YAML veya JSON formatında olabilir.
+func NewConfigurationManager() (*ConfigurationManager, error) {
+	configurationManager := &ConfigurationManager{
+		PostgreSqlConfig:  getPostgreSqlConfig(),  // PostgreSQL varsayılan ayarlarını alır.
+		ServerConfig:      getServerConfig(),      // Sunucu varsayılan ayarlarını alır.
+		TrashConfig:       getTrashConfig(),       // Çöp kutusu varsayılan ayarlarını alır.
+		IdempotencyConfig: getIdempotencyConfig(), // Idempotency varsayılan ayarlarını alır.
+	}
+
+	configFile, explicit := os.LookupEnv(ConfigFileEnvironmentVariable)
+	if !explicit {
+		configFile = defaultConfigFile
+	}
+	content, readErr := os.ReadFile(configFile)
+	if readErr != nil && (explicit || !errors.Is(readErr, os.ErrNotExist)) {
+		return nil, fmt.Errorf("Konfigürasyon dosyası okunamadı: %w", readErr)
+	}
+	if readErr == nil {
+		// JSON, YAML'ın bir alt kümesi olduğundan iki format da aynı şekilde çözülür.
+		if err := yaml.Unmarshal(content, configurationManager); err != nil {
+			return nil, fmt.Errorf("%s konfigürasyon dosyası çözümlenemedi: %w", configFile, err)
+		}
+	}
+
+	if err := configurationManager.applyEnvironment(); err != nil {
+		return nil, err
+	}
+	if err := configurationManager.validate(); err != nil {
+		return nil, err
 	}
+	return configurationManager, nil
 }
 
-// getPostgreSqlConfig, PostgreSQL bağlantı ayarlarını döndürür.
+// getPostgreSqlConfig, PostgreSQL bağlantı ayarlarının varsayılan değerlerini döndürür.
 func getPostgreSqlConfig() postgresql.Config {
 	return postgresql.Config{
-		Host:                  "localhost",  // Veritabanı sunucusunun adresi.
-		Port:                  "6432",       // Veritabanı bağlantı portu.
-		UserName:              "postgres",   // Veritabanı kullanıcı adı.
-		Password:              "postgres",   // Veritabanı kullanıcı şifresi.
-		DbName:                "productapp", // Bağlanılacak veritabanı adı.
-		MaxConnections:        "10",         // Maksimum bağlantı sayısı.
-		MaxConnectionIdleTime: "30s",        // Maksimum bağlantı boşta kalma süresi.
+		Host:                  "localhost",              // Veritabanı sunucusunun adresi.
+		Port:                  6432,                     // Veritabanı bağlantı portu.
+		UserName:              "postgres",               // Veritabanı kullanıcı adı.
+		Password:              "postgres",               // Veritabanı kullanıcı şifresi.
+		DbName:                "productapp",             // Bağlanılacak veritabanı adı.
+		MaxConnections:        10,                       // Maksimum bağlantı sayısı.
+		MaxConnectionIdleTime: 30 * time.Second,         // Maksimum bağlantı boşta kalma süresi.
+		ConnectRetries:        5,                        // İlk bağlantı için ek deneme sayısı.
+		ConnectRetryBackoff:   time.Second,              // İlk yeniden denemeden önceki bekleme.
+		ConnectRetryMaxWait:   30 * time.Second,         // İki deneme arasındaki en uzun bekleme.
+		QueryTimeout:          5 * time.Second,          // Tek bir sorgu için azami süre.
+		ExportTimeout:         5 * time.Minute,          // Bir dışa aktarmanın tamamı için azami süre.
+		TxIsolationLevel:      postgresql.ReadCommitted, // Unit of work transaction'larının yalıtım düzeyi.
+		TxMaxRetries:          3,                        // Serileştirme hatasında transaction yeniden deneme sayısı.
+	}
+}
+
+// getServerConfig, HTTP sunucusu ayarlarının varsayılan değerlerini döndürür.
+func getServerConfig() ServerConfig {
+	return ServerConfig{
+		Address:         "localhost:8080",  // Sunucunun dinleyeceği adres.
+		ReadTimeout:     10 * time.Second,  // İstek okuma zaman aşımı.
+		WriteTimeout:    30 * time.Second,  // Yanıt yazma zaman aşımı.
+		IdleTimeout:     120 * time.Second, // Boştaki bağlantı zaman aşımı.
+		ShutdownTimeout: 15 * time.Second,  // Kapanış için bekleme süresi.
+	}
+}
+
+// getTrashConfig, çöp kutusu ayarlarının varsayılan değerlerini döndürür.
+func getTrashConfig() TrashConfig {
+	return TrashConfig{
+		Retention:     30 * 24 * time.Hour, // Silinen ürünler 30 gün boyunca geri yüklenebilir.
+		PurgeInterval: time.Hour,           // Süresi dolan ürünler saatte bir temizlenir.
+	}
+}
+
+// getIdempotencyConfig, idempotency ayarlarının varsayılan değerlerini döndürür.
+func getIdempotencyConfig() IdempotencyConfig {
+	return IdempotencyConfig{
+		TTL:           24 * time.Hour,  // Tekrarlanan istekler bir gün boyunca ayıklanır.
+		PurgeInterval: time.Hour,       // Süresi dolan anahtarlar saatte bir silinir.
+		Lease:         5 * time.Minute, // Yanıtı kaydedilmeyen bir istek anahtarı en fazla beş dakika kilitler.
+		MaxBodySize:   1 << 20,         // Idempotency-Key ile gelen istek gövdeleri en fazla 1 MiB olabilir.
+	}
+}
+
+// applyEnvironment, tanımlı PRODUCTAPP_* ortam değişkenlerini dosyadan gelen değerlerin üzerine yazar.
+func (configurationManager *ConfigurationManager) applyEnvironment() error {
+	postgreSqlConfig := &configurationManager.PostgreSqlConfig
+	serverConfig := &configurationManager.ServerConfig
+	trashConfig := &configurationManager.TrashConfig
+	idempotencyConfig := &configurationManager.IdempotencyConfig
+
+	bindings := map[string]func(value string) error{
+		"PRODUCTAPP_POSTGRESQL_HOST":                     stringSetter(&postgreSqlConfig.Host),
+		"PRODUCTAPP_POSTGRESQL_PORT":                     intSetter(&postgreSqlConfig.Port),
+		"PRODUCTAPP_POSTGRESQL_USERNAME":                 stringSetter(&postgreSqlConfig.UserName),
+		"PRODUCTAPP_POSTGRESQL_PASSWORD":                 stringSetter(&postgreSqlConfig.Password),
+		"PRODUCTAPP_POSTGRESQL_DBNAME":                   stringSetter(&postgreSqlConfig.DbName),
+		"PRODUCTAPP_POSTGRESQL_MAX_CONNECTIONS":          intSetter(&postgreSqlConfig.MaxConnections),
+		"PRODUCTAPP_POSTGRESQL_MAX_CONNECTION_IDLE_TIME": durationSetter(&postgreSqlConfig.MaxConnectionIdleTime),
+		"PRODUCTAPP_POSTGRESQL_CONNECT_RETRIES":          intSetter(&postgreSqlConfig.ConnectRetries),
+		"PRODUCTAPP_POSTGRESQL_CONNECT_RETRY_BACKOFF":    durationSetter(&postgreSqlConfig.ConnectRetryBackoff),
+		"PRODUCTAPP_POSTGRESQL_CONNECT_RETRY_MAX_WAIT":   durationSetter(&postgreSqlConfig.ConnectRetryMaxWait),
+		"PRODUCTAPP_POSTGRESQL_QUERY_TIMEOUT":            durationSetter(&postgreSqlConfig.QueryTimeout),
+		"PRODUCTAPP_POSTGRESQL_EXPORT_TIMEOUT":           durationSetter(&postgreSqlConfig.ExportTimeout),
+		"PRODUCTAPP_POSTGRESQL_TX_ISOLATION_LEVEL":       stringSetter((*string)(&postgreSqlConfig.TxIsolationLevel)),
+		"PRODUCTAPP_POSTGRESQL_TX_MAX_RETRIES":           intSetter(&postgreSqlConfig.TxMaxRetries),
+		"PRODUCTAPP_SERVER_ADDRESS":                      stringSetter(&serverConfig.Address),
+		"PRODUCTAPP_SERVER_READ_TIMEOUT":                 durationSetter(&serverConfig.ReadTimeout),
+		"PRODUCTAPP_SERVER_WRITE_TIMEOUT":                durationSetter(&serverConfig.WriteTimeout),
+		"PRODUCTAPP_SERVER_IDLE_TIMEOUT":                 durationSetter(&serverConfig.IdleTimeout),
+		"PRODUCTAPP_SERVER_SHUTDOWN_TIMEOUT":             durationSetter(&serverConfig.ShutdownTimeout),
+		"PRODUCTAPP_EXCHANGE_RATES_FILE":                 stringSetter(&configurationManager.ExchangeRateConfig.File),
+		"PRODUCTAPP_TRASH_RETENTION":                     durationSetter(&trashConfig.Retention),
+		"PRODUCTAPP_TRASH_PURGE_INTERVAL":                durationSetter(&trashConfig.PurgeInterval),
+		"PRODUCTAPP_IDEMPOTENCY_TTL":                     durationSetter(&idempotencyConfig.TTL),
+		"PRODUCTAPP_IDEMPOTENCY_PURGE_INTERVAL":          durationSetter(&idempotencyConfig.PurgeInterval),
+		"PRODUCTAPP_IDEMPOTENCY_LEASE":                   durationSetter(&idempotencyConfig.Lease),
+		"PRODUCTAPP_IDEMPOTENCY_MAX_BODY_SIZE":           intSetter(&idempotencyConfig.MaxBodySize),
+	}
+	for name, set := range bindings {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := set(strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("%s ortam değişkeni geçersiz: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validate, yüklenen ayarları kontrol eder ve bulunan tüm hataları tek bir hata olarak döner.
+func (configurationManager *ConfigurationManager) validate() error {
+	postgreSqlConfig := configurationManager.PostgreSqlConfig
+	serverConfig := configurationManager.ServerConfig
+	trashConfig := configurationManager.TrashConfig
+	idempotencyConfig := configurationManager.IdempotencyConfig
+
+	var problems []string
+	if len(postgreSqlConfig.Host) == 0 {
+		problems = append(problems, "postgresql.host boş olamaz")
+	}
+	if postgreSqlConfig.Port < 1 || postgreSqlConfig.Port > 65535 {
+		problems = append(problems, "postgresql.port 1 ile 65535 arasında olmalıdır")
+	}
+	if len(postgreSqlConfig.UserName) == 0 {
+		problems = append(problems, "postgresql.userName boş olamaz")
+	}
+	if len(postgreSqlConfig.DbName) == 0 {
+		problems = append(problems, "postgresql.dbName boş olamaz")
+	}
+	if postgreSqlConfig.MaxConnections < 1 {
+		problems = append(problems, "postgresql.maxConnections en az 1 olmalıdır")
+	}
+	if postgreSqlConfig.MaxConnectionIdleTime <= 0 {
+		problems = append(problems, "postgresql.maxConnectionIdleTime pozitif olmalıdır")
+	}
+	if postgreSqlConfig.ConnectRetries < 0 {
+		problems = append(problems, "postgresql.connectRetries negatif olamaz")
+	}
+	if postgreSqlConfig.ConnectRetries > 0 && postgreSqlConfig.ConnectRetryBackoff <= 0 {
+		problems = append(problems, "postgresql.connectRetryBackoff pozitif olmalıdır")
+	}
+	if postgreSqlConfig.QueryTimeout < 0 {
+		problems = append(problems, "postgresql.queryTimeout negatif olamaz")
+	}
+	if postgreSqlConfig.ExportTimeout <= 0 {
+		problems = append(problems, "postgresql.exportTimeout pozitif olmalıdır")
+	}
+	if !postgreSqlConfig.TxIsolationLevel.IsValid() {
+		problems = append(problems, "postgresql.txIsolationLevel readCommitted, repeatableRead veya serializable olmalıdır")
+	}
+	if postgreSqlConfig.TxMaxRetries < 0 {
+		problems = append(problems, "postgresql.txMaxRetries negatif olamaz")
+	}
+	if len(serverConfig.Address) == 0 {
+		problems = append(problems, "server.address boş olamaz")
+	}
+	if serverConfig.ReadTimeout < 0 || serverConfig.WriteTimeout < 0 || serverConfig.IdleTimeout < 0 {
+		problems = append(problems, "server zaman aşımı süreleri negatif olamaz")
+	}
+	if serverConfig.ShutdownTimeout <= 0 {
+		problems = append(problems, "server.shutdownTimeout pozitif olmalıdır")
+	}
+	if trashConfig.Retention <= 0 {
+		problems = append(problems, "trash.retention pozitif olmalıdır")
+	}
+	if trashConfig.PurgeInterval <= 0 {
+		problems = append(problems, "trash.purgeInterval pozitif olmalıdır")
+	}
+	if idempotencyConfig.TTL <= 0 {
+		problems = append(problems, "idempotency.ttl pozitif olmalıdır")
+	}
+	if idempotencyConfig.PurgeInterval <= 0 {
+		problems = append(problems, "idempotency.purgeInterval pozitif olmalıdır")
+	}
+	if idempotencyConfig.Lease <= 0 {
+		problems = append(problems, "idempotency.lease pozitif olmalıdır")
+	}
+	if idempotencyConfig.MaxBodySize <= 0 {
+		problems = append(problems, "idempotency.maxBodySize pozitif olmalıdır")
+	}
+	if len(problems) > 0 {
+		return errors.New("Geçersiz konfigürasyon: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func stringSetter(target *string) func(value string) error {
+	return func(value string) error {
+		*target = value
+		return nil
+	}
+}
+
+func intSetter(target *int) func(value string) error {
+	return func(value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*target = parsed
+		return nil
+	}
+}
+
+func durationSetter(target *time.Duration) func(value string) error {
+	return func(value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*target = parsed
+		return nil
 	}
 }
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"net/http"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// shutdownHook, sunucu durdurulduktan sonra kapatılacak bir kaynağı temsil eder.
+type shutdownHook struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// Lifecycle, uygulamanın başlatılmasını ve SIGINT/SIGTERM sinyalleriyle düzgün şekilde
+// kapatılmasını yönetir. Kapanışta önce sunucudaki istekler tamamlanır, ardından kayıtlı
+// kaynaklar kayıt sırasının tersiyle kapatılır. Periyodik işler kaynaklar kapatılmadan önce durdurulur.
+type Lifecycle struct {
+	ctx             context.Context
+	stop            context.CancelFunc
+	shutdownTimeout time.Duration
+	hooks           []shutdownHook
+	jobs            sync.WaitGroup // Every ile başlatılan periyodik işlerin bitmesini beklemek için kullanılır.
+}
+
+// NewLifecycle, verilen bağlamdan türeyen ve SIGINT/SIGTERM alındığında iptal edilen
+// yeni bir Lifecycle oluşturur. shutdownTimeout, kapanışın tamamlanması için verilen süredir.
+func NewLifecycle(parent context.Context, shutdownTimeout time.Duration) *Lifecycle {
+	ctx, stop := signal.NotifyContext(parent, syscall.SIGINT, syscall.SIGTERM)
+	return &Lifecycle{
+		ctx:             ctx,
+		stop:            stop,
+		shutdownTimeout: shutdownTimeout,
+	}
+}
+
+// Context, kapanış sinyali alındığında iptal edilen uygulama bağlamını döner.
+// Başlangıç işlemleri (ör. veritabanı bağlantısı) bu bağlamla yapılmalıdır.
+func (lifecycle *Lifecycle) Context() context.Context {
+	return lifecycle.ctx
+}
+
+// OnShutdown, sunucu durdurulduktan sonra kapatılacak bir kaynağı kaydeder.
+func (lifecycle *Lifecycle) OnShutdown(name string, close func(ctx context.Context) error) {
+	lifecycle.hooks = append(lifecycle.hooks, shutdownHook{name: name, close: close})
+}
+
+// Every, job fonksiyonunu hemen ve ardından her interval süresinde bir arka planda çalıştırır.
+// İş, uygulama bağlamıyla çağrılır ve kapanış başladığında durdurulur; hataları loglanır.
+// Çalışmakta olan iş, kayıtlı kaynaklar kapatılmadan önce beklenir.
+func (lifecycle *Lifecycle) Every(name string, interval time.Duration, job func(ctx context.Context) error) {
+	lifecycle.jobs.Add(1)
+	go func() {
+		defer lifecycle.jobs.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// Bağlam, tetikleyici ile aynı anda hazır olabileceğinden her çalıştırmadan önce yeniden kontrol edilir.
+		for lifecycle.ctx.Err() == nil {
+			if err := job(lifecycle.ctx); err != nil && lifecycle.ctx.Err() == nil {
+				log.Errorf("%s çalışırken hata oluştu: %v", name, err)
+			}
+			select {
+			case <-lifecycle.ctx.Done():
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Serve, sunucuyu verilen adreste başlatır ve kapanış sinyali gelene ya da sunucu hata
+// verene kadar bekler. Ardından devam eden isteklerin tamamlanmasını shutdownTimeout süresi
+// kadar bekler, periyodik işleri durdurur ve kayıtlı kaynakları kapatır.
+func (lifecycle *Lifecycle) Serve(e *echo.Echo, address string) error {
+	defer lifecycle.stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- e.Start(address)
+	}()
+
+	var startErr error
+	select {
+	case <-lifecycle.ctx.Done():
+		log.Info("Kapanış sinyali alındı, sunucu durduruluyor")
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			startErr = fmt.Errorf("Sunucu çalışırken hata oluştu: %w", err)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), lifecycle.shutdownTimeout)
+	defer cancel()
+
+	shutdownErrs := []error{startErr}
+	// Yeni bağlantılar reddedilir ve devam eden istekler süre dolana kadar beklenir.
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		shutdownErrs = append(shutdownErrs, fmt.Errorf("Sunucu süresi içinde durdurulamadı: %w", err))
+	}
+	// Sunucu hata verip durduysa bağlam henüz iptal edilmemiştir; periyodik işler burada durdurulur.
+	lifecycle.stop()
+	lifecycle.jobs.Wait()
+	for i := len(lifecycle.hooks) - 1; i >= 0; i-- {
+		hook := lifecycle.hooks[i]
+		if err := hook.close(shutdownCtx); err != nil {
+			shutdownErrs = append(shutdownErrs, fmt.Errorf("%s kapatılamadı: %w", hook.name, err))
+			continue
+		}
+		log.Infof("%s kapatıldı", hook.name)
+	}
+	return errors.Join(shutdownErrs...)
+}
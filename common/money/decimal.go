@@ -0,0 +1,299 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// maxDigits, bir Decimal değerinin int64 sınırları içinde taşıyabileceği en fazla basamak sayısıdır.
+const maxDigits = 18
+
+// Decimal ayrıştırma hataları.
+var (
+	ErrInvalidDecimal = errors.New("invalid decimal value")
+	ErrOutOfRange     = errors.New("decimal value out of range")
+)
+
+// Decimal, ondalık bir sayıyı kayan nokta hatası olmadan ölçeklenmiş tam sayı olarak tutar:
+// değer = units / 10^scale. Değerler sondaki sıfırları atılmış en sade hâlde tutulur; böylece
+// eşit sayılar == ile de karşılaştırılabilir. Sıfır değeri 0'dır.
+type Decimal struct {
+	units int64
+	scale int32
+}
+
+// NewFromInt, tam sayı bir değerden Decimal oluşturur.
+func NewFromInt(value int64) Decimal {
+	return Decimal{units: value}
+}
+
+// Parse, "1500", "-19.99" veya "15e-1" biçimindeki metni Decimal'e çevirir.
+// En fazla 18 anlamlı basamak desteklenir.
+func Parse(value string) (Decimal, error) {
+	text := strings.TrimSpace(value)
+	var exponent int64
+	if index := strings.IndexAny(text, "eE"); index >= 0 {
+		parsedExponent, err := strconv.ParseInt(text[index+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, value)
+		}
+		exponent = parsedExponent
+		text = text[:index]
+	}
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	integerPart, fractionPart, _ := strings.Cut(text, ".")
+	if len(integerPart)+len(fractionPart) == 0 || !isDigits(integerPart) || !isDigits(fractionPart) {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, value)
+	}
+
+	digits := strings.TrimLeft(integerPart+fractionPart, "0")
+	scale := int64(len(fractionPart)) - exponent
+	for len(digits) > 0 && scale > 0 && digits[len(digits)-1] == '0' {
+		digits = digits[:len(digits)-1]
+		scale--
+	}
+	if len(digits) == 0 {
+		return Decimal{}, nil
+	}
+	if scale < 0 {
+		if int64(len(digits))-scale > maxDigits {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrOutOfRange, value)
+		}
+		digits += strings.Repeat("0", int(-scale))
+		scale = 0
+	}
+	if len(digits) > maxDigits || scale > maxDigits {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrOutOfRange, value)
+	}
+	units, _ := strconv.ParseInt(digits, 10, 64)
+	if negative {
+		units = -units
+	}
+	return Decimal{units: units, scale: int32(scale)}, nil
+}
+
+// MustParse, Parse gibi çalışır ancak hata durumunda panic oluşturur.
+// Yalnızca sabit değerler ve testler için kullanılmalıdır.
+func MustParse(value string) Decimal {
+	decimal, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return decimal
+}
+
+func isDigits(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// IsZero, değerin sıfır olup olmadığını döner.
+func (decimal Decimal) IsZero() bool {
+	return decimal.units == 0
+}
+
+// Sign, değer negatifse -1, sıfırsa 0, pozitifse 1 döner.
+func (decimal Decimal) Sign() int {
+	switch {
+	case decimal.units < 0:
+		return -1
+	case decimal.units > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DecimalPlaces, değerin en sade hâlindeki ondalık basamak sayısını döner.
+// Örneğin 19.99 için 2, 100 için 0 döner.
+func (decimal Decimal) DecimalPlaces() int {
+	return int(decimal.scale)
+}
+
+// Cmp, değer other'dan küçükse -1, eşitse 0, büyükse 1 döner.
+func (decimal Decimal) Cmp(other Decimal) int {
+	scale := max(decimal.scale, other.scale)
+	return decimal.rescaled(scale).Cmp(other.rescaled(scale))
+}
+
+// Add, iki değerin toplamını döner.
+func (decimal Decimal) Add(other Decimal) Decimal {
+	scale := max(decimal.scale, other.scale)
+	return fromBig(new(big.Int).Add(decimal.rescaled(scale), other.rescaled(scale)), scale)
+}
+
+// Sub, değerden other'ın çıkarılmasıyla elde edilen farkı döner.
+func (decimal Decimal) Sub(other Decimal) Decimal {
+	scale := max(decimal.scale, other.scale)
+	return fromBig(new(big.Int).Sub(decimal.rescaled(scale), other.rescaled(scale)), scale)
+}
+
+// Mul, iki değerin çarpımını places ondalık basamağa yuvarlayarak döner.
+// Çarpım yuvarlanmadan önce tam hassasiyetle hesaplanır.
+func (decimal Decimal) Mul(other Decimal, places int32) Decimal {
+	product := new(big.Int).Mul(big.NewInt(decimal.units), big.NewInt(other.units))
+	return roundBig(product, decimal.scale+other.scale, places)
+}
+
+// Percent, değerin yüzde rate kadarını places ondalık basamağa yuvarlayarak döner.
+// Örneğin 19.99'un %15'i 2 basamakla 3.00 olur (2.9985 yukarı yuvarlanır).
+func (decimal Decimal) Percent(rate Decimal, places int32) Decimal {
+	product := new(big.Int).Mul(big.NewInt(decimal.units), big.NewInt(rate.units))
+	return roundBig(product, decimal.scale+rate.scale+2, places) // Yüzdeye çevirmek için 100'e bölünür.
+}
+
+// Round, değeri places ondalık basamağa yuvarlar. Yarımlar sıfırdan uzağa yuvarlanır
+// (2.345 → 2.35, -2.345 → -2.35); bu, PostgreSQL'in numeric round() davranışıyla aynıdır.
+func (decimal Decimal) Round(places int32) Decimal {
+	if decimal.scale <= places {
+		return decimal
+	}
+	return roundBig(big.NewInt(decimal.units), decimal.scale, places)
+}
+
+// String, değeri en sade ondalık gösterimiyle döner, örneğin "1500" veya "19.9".
+func (decimal Decimal) String() string {
+	return decimal.StringFixed(decimal.scale)
+}
+
+// StringFixed, değeri places basamağa yuvarlayıp tam olarak places ondalık basamakla döner,
+// örneğin places 2 için "1500.00".
+func (decimal Decimal) StringFixed(places int32) string {
+	rounded := decimal.Round(places)
+	digits := new(big.Int).Abs(big.NewInt(rounded.units)).String()
+	if len(digits) <= int(rounded.scale) {
+		digits = strings.Repeat("0", int(rounded.scale)-len(digits)+1) + digits
+	}
+	integerPart := digits[:len(digits)-int(rounded.scale)]
+	fractionPart := digits[len(digits)-int(rounded.scale):] + strings.Repeat("0", int(places-rounded.scale))
+
+	var builder strings.Builder
+	if rounded.units < 0 {
+		builder.WriteByte('-')
+	}
+	builder.WriteString(integerPart)
+	if places > 0 {
+		builder.WriteByte('.')
+		builder.WriteString(fractionPart)
+	}
+	return builder.String()
+}
+
+// MarshalJSON, değeri hassasiyet kaybı olmaması için JSON metni olarak yazar.
+func (decimal Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(decimal.String())
+}
+
+// UnmarshalJSON, değeri JSON metninden ("19.99") veya JSON sayısından (19.99) okur.
+// Sayılar float'a çevrilmeden doğrudan metin olarak ayrıştırılır.
+func (decimal *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if strings.HasPrefix(text, `"`) {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*decimal = parsed
+	return nil
+}
+
+// Scan, veritabanından okunan NUMERIC değerini Decimal'e çevirir (sql.Scanner).
+func (decimal *Decimal) Scan(src interface{}) error {
+	var parsed Decimal
+	var err error
+	switch value := src.(type) {
+	case string:
+		parsed, err = Parse(value)
+	case []byte:
+		parsed, err = Parse(string(value))
+	case int64:
+		parsed = NewFromInt(value)
+	case float64:
+		parsed, err = Parse(strconv.FormatFloat(value, 'f', -1, 64))
+	default:
+		return fmt.Errorf("%T tipindeki değer Decimal'e dönüştürülemez", src)
+	}
+	if err != nil {
+		return err
+	}
+	*decimal = parsed
+	return nil
+}
+
+// Value, değeri veritabanına NUMERIC olarak yazılabilecek metin şeklinde döner (driver.Valuer).
+func (decimal Decimal) Value() (driver.Value, error) {
+	return decimal.String(), nil
+}
+
+// rescaled, değerin verilen ölçekteki ölçeklenmemiş tam sayı karşılığını döner.
+// scale, değerin kendi ölçeğinden küçük olmamalıdır.
+func (decimal Decimal) rescaled(scale int32) *big.Int {
+	return new(big.Int).Mul(big.NewInt(decimal.units), pow10(scale-decimal.scale))
+}
+
+// fromBig, ölçeklenmemiş tam sayıdan sondaki sıfırları atılmış bir Decimal oluşturur.
+// Sonuç int64 sınırlarını aşarsa panic oluşur.
+func fromBig(units *big.Int, scale int32) Decimal {
+	ten := big.NewInt(10)
+	remainder := new(big.Int)
+	for scale > 0 && units.Sign() != 0 {
+		quotient, _ := new(big.Int).QuoRem(units, ten, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		units = quotient
+		scale--
+	}
+	if units.Sign() == 0 {
+		return Decimal{}
+	}
+	if !units.IsInt64() {
+		panic(ErrOutOfRange)
+	}
+	return Decimal{units: units.Int64(), scale: scale}
+}
+
+// roundBig, scale ölçeğindeki ölçeklenmemiş tam sayıyı places basamağa yuvarlayarak Decimal'e çevirir.
+func roundBig(units *big.Int, scale int32, places int32) Decimal {
+	if scale <= places {
+		return fromBig(units, scale)
+	}
+	return fromBig(divRoundHalfUp(units, pow10(scale-places)), places)
+}
+
+// divRoundHalfUp, dividend/divisor bölümünü yarımları sıfırdan uzağa yuvarlayarak döner.
+// divisor pozitif olmalıdır.
+func divRoundHalfUp(dividend *big.Int, divisor *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(dividend, divisor, new(big.Int))
+	doubledRemainder := new(big.Int).Lsh(new(big.Int).Abs(remainder), 1)
+	if doubledRemainder.Cmp(divisor) >= 0 {
+		if dividend.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return quotient
+}
+
+func pow10(exponent int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
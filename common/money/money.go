@@ -0,0 +1,94 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Places, para miktarlarında kullanılan ondalık basamak sayısıdır (kuruş, cent).
+const Places = 2
+
+// Currency, ISO 4217 para birimi kodudur.
+type Currency string
+
+// Desteklenen para birimleri.
+const (
+	TRY Currency = "TRY"
+	EUR Currency = "EUR"
+	USD Currency = "USD"
+)
+
+// DefaultCurrency, para birimi belirtilmemiş fiyatlar için kullanılan para birimidir.
+const DefaultCurrency = TRY
+
+// SupportedCurrencies, ürün fiyatlarında kullanılabilecek para birimlerini listeler.
+var SupportedCurrencies = []Currency{TRY, EUR, USD}
+
+// ErrUnsupportedCurrency, desteklenmeyen bir para birimi kodu ayrıştırıldığında döner.
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// ParseCurrency, büyük/küçük harf duyarsız bir para birimi kodunu ayrıştırır.
+// Kod desteklenen para birimlerinden biri değilse ErrUnsupportedCurrency döner.
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currency.IsSupported() {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedCurrency, code)
+	}
+	return currency, nil
+}
+
+// IsSupported, para biriminin SupportedCurrencies içinde olup olmadığını döner.
+func (currency Currency) IsSupported() bool {
+	for _, supported := range SupportedCurrencies {
+		if currency == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportedCurrencyCodes, desteklenen para birimi kodlarını virgülle ayrılmış olarak döner,
+// örneğin "TRY, EUR, USD".
+func SupportedCurrencyCodes() string {
+	codes := make([]string, 0, len(SupportedCurrencies))
+	for _, currency := range SupportedCurrencies {
+		codes = append(codes, string(currency))
+	}
+	return strings.Join(codes, ", ")
+}
+
+// Money, bir para miktarını para birimiyle birlikte tutar.
+type Money struct {
+	Amount   Decimal
+	Currency Currency
+}
+
+// New, verilen miktar ve para biriminden Money oluşturur.
+func New(amount Decimal, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Percent, miktarın yüzde rate kadarını kuruşa yuvarlayarak döner.
+func (money Money) Percent(rate Decimal) Money {
+	return Money{Amount: money.Amount.Percent(rate, Places), Currency: money.Currency}
+}
+
+// ApplyDiscount, yüzde rate indirim uygulanmış miktarı döner. İndirim tutarı kuruşa
+// yuvarlandıktan sonra düşüldüğü için indirim tutarı ile sonuç toplamı her zaman
+// kuruşa yuvarlanmış fiyata eşittir.
+func (money Money) ApplyDiscount(rate Decimal) Money {
+	price := money.Amount.Round(Places)
+	return Money{Amount: price.Sub(price.Percent(rate, Places)), Currency: money.Currency}
+}
+
+// Convert, miktarı verilen kurla hedef para birimine çevirir ve kuruşa yuvarlar.
+// rate, kaynak para biriminin bir biriminin hedef para birimindeki karşılığıdır.
+func (money Money) Convert(rate Decimal, target Currency) Money {
+	return Money{Amount: money.Amount.Mul(rate, Places), Currency: target}
+}
+
+// String, miktarı para birimiyle birlikte döner, örneğin "1500.00 TRY".
+func (money Money) String() string {
+	return money.Amount.StringFixed(Places) + " " + string(money.Currency)
+}
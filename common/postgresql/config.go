@@ -1,11 +1,21 @@
 package postgresql
 
+import "time"
+
+// Config, PostgreSQL bağlantı ve bağlantı havuzu ayarlarını tutar.
 type Config struct {
-	Host                  string
-	Port                  string
-	UserName              string
-	Password              string
-	DbName                string
-	MaxConnections        string
-	MaxConnectionIdleTime string
+	Host                  string         `yaml:"host"`
+	Port                  int            `yaml:"port"`
+	UserName              string         `yaml:"userName"`
+	Password              string         `yaml:"password"`
+	DbName                string         `yaml:"dbName"`
+	MaxConnections        int            `yaml:"maxConnections"`
+	MaxConnectionIdleTime time.Duration  `yaml:"maxConnectionIdleTime"`
+	ConnectRetries        int            `yaml:"connectRetries"`      // İlk bağlantı başarısız olursa yapılacak ek deneme sayısı.
+	ConnectRetryBackoff   time.Duration  `yaml:"connectRetryBackoff"` // İlk yeniden denemeden önceki bekleme, her denemede iki katına çıkar.
+	ConnectRetryMaxWait   time.Duration  `yaml:"connectRetryMaxWait"` // İki deneme arasındaki en uzun bekleme süresi.
+	QueryTimeout          time.Duration  `yaml:"queryTimeout"`        // Tek bir sorgunun çalışabileceği azami süre.
+	ExportTimeout         time.Duration  `yaml:"exportTimeout"`       // Dışa aktarma transaction'ının ve imlecinin açık kalabileceği azami süre.
+	TxIsolationLevel      IsolationLevel `yaml:"txIsolationLevel"`    // Unit of work transaction'larının varsayılan yalıtım düzeyi.
+	TxMaxRetries          int            `yaml:"txMaxRetries"`        // Serileştirme hatası veya kilitlenmede transaction'ın en fazla yeniden deneme sayısı.
 }
@@ -5,32 +5,60 @@ import (
 	"fmt"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/labstack/gommon/log"
+	"time"
 )
 
-// GetConnectionPool, PostgreSQL veritabanına bağlantı havuzu oluşturur.
-func GetConnectionPool(context context.Context, config Config) *pgxpool.Pool {
-	// Sağlanan yapılandırma parametreleriyle bağlantı dizesini oluşturur.
-	connString := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable statement_cache_mode=describe pool_max_conns=%s pool_max_conn_idle_time=%s",
-		config.Host,
-		config.Port,
-		config.UserName,
-		config.Password,
-		config.DbName,
-		config.MaxConnections,
-		config.MaxConnectionIdleTime)
-
-	// Bağlantı dizesini pgxpool için yapılandırmaya çevirir.
-	connConfig, parseConfigErr := pgxpool.ParseConfig(connString)
+// NewPoolConfig, ayarlardan pgxpool yapılandırmasını oluşturur. Bağlantı bilgileri bağlantı
+// dizesine yazılmadan alanlara doğrudan atanır; böylece boşluk, ' veya \ içeren şifreler de
+// olduğu gibi kullanılır.
+func NewPoolConfig(config Config) (*pgxpool.Config, error) {
+	// Bağlantı dizesi yalnızca kullanıcıdan gelmeyen sabit seçenekleri taşır.
+	connConfig, parseConfigErr := pgxpool.ParseConfig("sslmode=disable statement_cache_mode=describe")
 	if parseConfigErr != nil {
-		panic(parseConfigErr) // Hata durumunda programı durdurur.
+		return nil, fmt.Errorf("Bağlantı ayarları çözümlenemedi: %w", parseConfigErr)
 	}
+	connConfig.ConnConfig.Host = config.Host
+	connConfig.ConnConfig.Port = uint16(config.Port)
+	connConfig.ConnConfig.User = config.UserName
+	connConfig.ConnConfig.Password = config.Password
+	connConfig.ConnConfig.Database = config.DbName
+	// Ortam değişkenlerinden gelebilecek yedek sunucular kullanılmaz; yalnızca ayarlardaki sunucuya bağlanılır.
+	connConfig.ConnConfig.Fallbacks = nil
+	// Havuz ayarları tipli alanlardan doğrudan atanır.
+	connConfig.MaxConns = int32(config.MaxConnections)
+	connConfig.MaxConnIdleTime = config.MaxConnectionIdleTime
+	return connConfig, nil
+}
 
-	// Bağlantı havuzunu yapılandırmaya göre oluşturur.
-	conn, err := pgxpool.ConnectConfig(context, connConfig)
-	if err != nil {
-		log.Error("Veritabanına bağlanılamıyor: %v\n", err)
-		panic(err) // Hata durumunda programı durdurur.
+// GetConnectionPool, PostgreSQL veritabanına bağlantı havuzu oluşturur.
+// İlk bağlantı kurulamazsa, yapılandırmadaki deneme sayısı kadar üstel artan
+// beklemelerle yeniden dener. Bağlam iptal edilirse beklemeden vazgeçer.
+func GetConnectionPool(ctx context.Context, config Config) (*pgxpool.Pool, error) {
+	connConfig, configErr := NewPoolConfig(config)
+	if configErr != nil {
+		return nil, configErr
 	}
 
-	return conn // Başarılı bağlantı havuzunu döner.
+	backoff := config.ConnectRetryBackoff
+	for attempt := 0; ; attempt++ {
+		// Bağlantı havuzunu yapılandırmaya göre oluşturur.
+		conn, err := pgxpool.ConnectConfig(ctx, connConfig)
+		if err == nil {
+			return conn, nil // Başarılı bağlantı havuzunu döner.
+		}
+		if attempt >= config.ConnectRetries {
+			return nil, fmt.Errorf("Veritabanına %d denemede bağlanılamadı: %w", attempt+1, err)
+		}
+		log.Warnf("Veritabanına bağlanılamıyor, %v sonra yeniden denenecek (%d/%d): %v", backoff, attempt+1, config.ConnectRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("Veritabanı bağlantısı beklenirken vazgeçildi: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if config.ConnectRetryMaxWait > 0 && backoff > config.ConnectRetryMaxWait {
+			backoff = config.ConnectRetryMaxWait
+		}
+	}
 }
@@ -0,0 +1,33 @@
+package postgresql
+
+import "github.com/jackc/pgx/v4"
+
+// IsolationLevel, unit of work transaction'larının yalıtım düzeyidir.
+type IsolationLevel string
+
+// Desteklenen transaction yalıtım düzeyleri.
+const (
+	ReadCommitted  IsolationLevel = "readCommitted"  // PostgreSQL varsayılanı; her sorgu en son onaylanmış veriyi görür.
+	RepeatableRead IsolationLevel = "repeatableRead" // Transaction boyunca aynı anlık görüntü okunur.
+	Serializable   IsolationLevel = "serializable"   // Transaction'lar sırayla çalışmış gibi sonuçlanır; çakışmada serileştirme hatası döner.
+)
+
+// IsValid, yalıtım düzeyinin desteklenen değerlerden biri olup olmadığını kontrol eder.
+func (isolationLevel IsolationLevel) IsValid() bool {
+	switch isolationLevel {
+	case ReadCommitted, RepeatableRead, Serializable:
+		return true
+	}
+	return false
+}
+
+// TxIsoLevel, yalıtım düzeyini pgx karşılığına çevirir; boş veya geçersiz değerlerde ReadCommitted kullanılır.
+func (isolationLevel IsolationLevel) TxIsoLevel() pgx.TxIsoLevel {
+	switch isolationLevel {
+	case RepeatableRead:
+		return pgx.RepeatableRead
+	case Serializable:
+		return pgx.Serializable
+	}
+	return pgx.ReadCommitted
+}
@@ -0,0 +1,20 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize, metni Türkçe büyük/küçük harf kurallarına göre küçük harfe çevirir ve
+// baştaki/sondaki boşlukları temizler. Böylece "IŞIK" "ışık", "İSTANBUL" "istanbul" olur.
+func Normalize(value string) string {
+	return strings.TrimSpace(strings.ToLowerSpecial(unicode.TurkishCase, value))
+}
+
+// Tokenize, metni normalize ederek harf ve rakamlardan oluşan kelimelere ayırır.
+// Noktalama işaretleri ve diğer karakterler ayraç olarak kabul edilir.
+func Tokenize(value string) []string {
+	return strings.FieldsFunc(Normalize(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"product-app/domain"
+	"strings"
+)
+
+// ActorHeader, isteği yapan kişiyi belirten başlıktır; fiyat geçmişi gibi denetim kayıtlarına yazılır.
+const ActorHeader = "X-Actor"
+
+// maxActorLength, aktör adının veritabanında saklanabilecek en fazla karakter sayısıdır.
+const maxActorLength = 255
+
+// ActorMiddleware, X-Actor başlığındaki aktörü istek bağlamına ekler.
+// Başlık yoksa aktör domain.AnonymousActor olur.
+func ActorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		actor := strings.TrimSpace(c.Request().Header.Get(ActorHeader))
+		if len([]rune(actor)) > maxActorLength {
+			return echo.NewHTTPError(http.StatusBadRequest, "Header X-Actor can not be longer than 255 characters")
+		}
+		if len(actor) > 0 {
+			c.SetRequest(c.Request().WithContext(domain.WithActor(c.Request().Context(), actor)))
+		}
+		return next(c)
+	}
+}
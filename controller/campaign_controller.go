@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"product-app/controller/request"
+	"product-app/controller/response"
+	"product-app/domain"
+	"product-app/service"
+	"strconv"
+)
+
+// CampaignController, indirim kampanyalarıyla ilgili işlemleri yöneten bir kontrolcü yapısıdır.
+type CampaignController struct {
+	campaignService service.ICampaignService
+}
+
+// NewCampaignController, yeni bir CampaignController nesnesi oluşturur ve döndürür.
+func NewCampaignController(campaignService service.ICampaignService) *CampaignController {
+	return &CampaignController{
+		campaignService: campaignService,
+	}
+}
+
+// RegisterRoutes, kampanyayla ilgili API uç noktalarını Echo framework'e kaydeder.
+func (campaignController *CampaignController) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/v1/campaigns", campaignController.GetAllCampaigns)           // Kampanyaları listeler; active=true ile yalnızca geçerli olanlar.
+	e.GET("/api/v1/campaigns/:id", campaignController.GetCampaignById)       // Belirli bir kampanyayı ID ile getirir.
+	e.POST("/api/v1/campaigns", campaignController.AddCampaign)              // Yeni bir kampanya ekler.
+	e.PUT("/api/v1/campaigns/:id", campaignController.UpdateCampaign)        // Kampanyanın tüm alanlarını ve hedeflerini değiştirir.
+	e.DELETE("/api/v1/campaigns/:id", campaignController.DeleteCampaignById) // Kampanyayı siler.
+}
+
+// GetAllCampaigns, tüm kampanyaları veya active=true verilmişse yalnızca şu anda geçerli olanları getirir.
+func (campaignController *CampaignController) GetAllCampaigns(c echo.Context) error {
+	activeOnly := false
+	if activeParam := c.QueryParam("active"); len(activeParam) > 0 {
+		parsedActive, parseErr := strconv.ParseBool(activeParam)
+		if parseErr != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Parameter active must be true or false")
+		}
+		activeOnly = parsedActive
+	}
+	var campaigns []domain.Campaign
+	var err error
+	if activeOnly {
+		campaigns, err = campaignController.campaignService.GetActiveCampaigns(c.Request().Context())
+	} else {
+		campaigns, err = campaignController.campaignService.GetAllCampaigns(c.Request().Context())
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToCampaignResponseList(campaigns))
+}
+
+// GetCampaignById, ID'ye göre bir kampanyayı getirir.
+func (campaignController *CampaignController) GetCampaignById(c echo.Context) error {
+	campaignId, err := parseCampaignId(c)
+	if err != nil {
+		return err
+	}
+	// Kampanya bulunamazsa hata işleyici 404 döner.
+	campaign, err := campaignController.campaignService.GetById(c.Request().Context(), campaignId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToCampaignResponse(campaign))
+}
+
+// AddCampaign, yeni bir kampanya ekler ve eklenen kampanyayı döner.
+func (campaignController *CampaignController) AddCampaign(c echo.Context) error {
+	var addCampaignRequest request.AddCampaignRequest
+	if err := c.Bind(&addCampaignRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	// Doğrulama hatasında veya hedef ürün ya da mağaza yoksa 422 döner.
+	campaign, err := campaignController.campaignService.Add(c.Request().Context(), addCampaignRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response.ToCampaignResponse(campaign))
+}
+
+// UpdateCampaign, bir kampanyanın tüm alanlarını ve hedeflerini değiştirir.
+func (campaignController *CampaignController) UpdateCampaign(c echo.Context) error {
+	campaignId, err := parseCampaignId(c)
+	if err != nil {
+		return err
+	}
+	var updateCampaignRequest request.UpdateCampaignRequest
+	if err := c.Bind(&updateCampaignRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	err = campaignController.campaignService.Update(c.Request().Context(), campaignId, updateCampaignRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
+}
+
+// DeleteCampaignById, ID'ye göre bir kampanyayı siler.
+func (campaignController *CampaignController) DeleteCampaignById(c echo.Context) error {
+	campaignId, err := parseCampaignId(c)
+	if err != nil {
+		return err
+	}
+	err = campaignController.campaignService.DeleteById(c.Request().Context(), campaignId)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı silme durumunda 200 döner.
+}
+
+// parseCampaignId, yol parametresindeki kampanya ID'sini ayrıştırır; geçersizse 400 hatası döner.
+func parseCampaignId(c echo.Context) (int64, error) {
+	campaignId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || campaignId < 1 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Campaign id must be a positive integer")
+	}
+	return campaignId, nil
+}
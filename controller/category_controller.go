@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"product-app/controller/request"
+	"product-app/controller/response"
+	"product-app/service"
+	"strconv"
+)
+
+// CategoryController, kategori ağacı ve ürün kategorileriyle ilgili işlemleri yöneten bir kontrolcü yapısıdır.
+type CategoryController struct {
+	categoryService service.ICategoryService
+}
+
+// NewCategoryController, yeni bir CategoryController nesnesi oluşturur ve döndürür.
+func NewCategoryController(categoryService service.ICategoryService) *CategoryController {
+	return &CategoryController{
+		categoryService: categoryService,
+	}
+}
+
+// RegisterRoutes, kategoriyle ilgili API uç noktalarını Echo framework'e kaydeder.
+func (categoryController *CategoryController) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/v1/categories", categoryController.GetCategoryTree)                     // Kategori ağacını getirir.
+	e.GET("/api/v1/categories/:id", categoryController.GetCategoryById)                 // Belirli bir kategoriyi ID ile getirir.
+	e.POST("/api/v1/categories", categoryController.AddCategory)                        // Yeni bir kategori ekler.
+	e.PUT("/api/v1/categories/:id", categoryController.UpdateCategory)                  // Kategorinin adını ve üst kategorisini değiştirir.
+	e.DELETE("/api/v1/categories/:id", categoryController.DeleteCategoryById)           // Boş bir kategoriyi siler.
+	e.GET("/api/v1/products/:id/categories", categoryController.GetCategoriesOfProduct) // Ürünün kategorilerini listeler.
+	e.PUT("/api/v1/products/:id/categories", categoryController.SetCategoriesOfProduct) // Ürünün kategorilerini değiştirir.
+}
+
+// GetCategoryTree, tüm kategorileri ağaç olarak getirir.
+func (categoryController *CategoryController) GetCategoryTree(c echo.Context) error {
+	categoryTree, err := categoryController.categoryService.GetCategoryTree(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToCategoryTreeResponse(categoryTree))
+}
+
+// GetCategoryById, ID'ye göre bir kategoriyi getirir.
+func (categoryController *CategoryController) GetCategoryById(c echo.Context) error {
+	categoryId, err := parseCategoryId(c)
+	if err != nil {
+		return err
+	}
+	// Kategori bulunamazsa hata işleyici 404 döner.
+	category, err := categoryController.categoryService.GetById(c.Request().Context(), categoryId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToCategoryResponse(category))
+}
+
+// AddCategory, yeni bir kategori ekler ve eklenen kategoriyi döner.
+func (categoryController *CategoryController) AddCategory(c echo.Context) error {
+	var addCategoryRequest request.AddCategoryRequest
+	if err := c.Bind(&addCategoryRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	// Doğrulama hatasında 422, aynı üst kategoride aynı adda kategori varsa 409 döner.
+	category, err := categoryController.categoryService.Add(c.Request().Context(), addCategoryRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response.ToCategoryResponse(category))
+}
+
+// UpdateCategory, bir kategorinin adını ve üst kategorisini değiştirir.
+func (categoryController *CategoryController) UpdateCategory(c echo.Context) error {
+	categoryId, err := parseCategoryId(c)
+	if err != nil {
+		return err
+	}
+	var updateCategoryRequest request.UpdateCategoryRequest
+	if err := c.Bind(&updateCategoryRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	err = categoryController.categoryService.Update(c.Request().Context(), categoryId, updateCategoryRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
+}
+
+// DeleteCategoryById, ID'ye göre bir kategoriyi siler. Alt kategori veya ürün varsa 409 döner.
+func (categoryController *CategoryController) DeleteCategoryById(c echo.Context) error {
+	categoryId, err := parseCategoryId(c)
+	if err != nil {
+		return err
+	}
+	err = categoryController.categoryService.DeleteById(c.Request().Context(), categoryId)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı silme durumunda 200 döner.
+}
+
+// GetCategoriesOfProduct, bir ürünün doğrudan bağlı olduğu kategorileri getirir.
+func (categoryController *CategoryController) GetCategoriesOfProduct(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	categories, err := categoryController.categoryService.GetCategoriesOfProduct(c.Request().Context(), productId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToCategoryResponseList(categories))
+}
+
+// SetCategoriesOfProduct, bir ürünün kategorilerini istekte gönderilen kategorilerle değiştirir.
+func (categoryController *CategoryController) SetCategoriesOfProduct(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	var productCategoriesRequest request.ProductCategoriesRequest
+	if err := c.Bind(&productCategoriesRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	err = categoryController.categoryService.SetCategoriesOfProduct(c.Request().Context(), productId, productCategoriesRequest.CategoryIds)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
+}
+
+// parseCategoryId, yol parametresindeki kategori ID'sini ayrıştırır; geçersizse 400 hatası döner.
+func parseCategoryId(c echo.Context) (int64, error) {
+	categoryId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || categoryId < 1 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Category id must be a positive integer")
+	}
+	return categoryId, nil
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"net/http"
+	"product-app/controller/response"
+	"product-app/domain"
+)
+
+// StatusClientClosedRequest, istemci yanıtı beklemeden bağlantıyı kestiğinde kullanılan standart dışı
+// durum kodudur (nginx'in 499 kuralı). Sunucu hatası sayılmaz ve loglanmaz.
+const StatusClientClosedRequest = 499
+
+// HTTPErrorHandler, kontrolcülerin döndürdüğü hataları tek noktadan HTTP yanıtına çevirir.
+// Domain hata türleri uygun durum kodlarına eşlenir, Echo'nun kendi hataları (ör. bağlama
+// veya bulunamayan rota) olduğu gibi korunur, iptal edilen istekler 499, tanınmayan hatalar loglanarak 500 döner.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	status, errorResponse := toErrorResponse(err)
+	if status >= http.StatusInternalServerError {
+		log.Errorf("%s %s isteği işlenirken hata oluştu: %v", c.Request().Method, c.Request().URL.Path, err)
+	}
+
+	var writeErr error
+	if c.Request().Method == http.MethodHead {
+		writeErr = c.NoContent(status)
+	} else {
+		writeErr = c.JSON(status, errorResponse)
+	}
+	if writeErr != nil {
+		log.Errorf("Hata yanıtı yazılamadı: %v", writeErr)
+	}
+}
+
+// toErrorResponse, hatanın durum kodunu ve istemciye dönecek gövdesini belirler.
+func toErrorResponse(err error) (int, response.ErrorResponse) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code, response.ErrorResponse{
+			ErrorCode:        errorCodeForStatus(httpErr.Code),
+			ErrorDescription: httpErrorMessage(httpErr),
+		}
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		// Tüm alan hataları tek yanıtta, alan adlarıyla birlikte döner.
+		errorResponse := newErrorResponse(response.ErrorCodeValidationFailed, validationErr)
+		errorResponse.FieldErrors = response.ToFieldErrorResponseList(validationErr.FieldErrors)
+		return http.StatusUnprocessableEntity, errorResponse
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return StatusClientClosedRequest, response.ErrorResponse{
+			ErrorCode:        response.ErrorCodeClientClosedRequest,
+			ErrorDescription: "Client closed request",
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound, newErrorResponse(response.ErrorCodeNotFound, err)
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusUnprocessableEntity, newErrorResponse(response.ErrorCodeValidationFailed, err)
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict, newErrorResponse(response.ErrorCodeConflict, err)
+	case errors.Is(err, domain.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed, newErrorResponse(response.ErrorCodePreconditionFailed, err)
+	case errors.Is(err, domain.ErrUnavailable):
+		return http.StatusServiceUnavailable, newErrorResponse(response.ErrorCodeServiceUnavailable, err)
+	}
+	// İç hata ayrıntıları istemciye gösterilmez.
+	return http.StatusInternalServerError, response.ErrorResponse{
+		ErrorCode:        response.ErrorCodeInternalError,
+		ErrorDescription: http.StatusText(http.StatusInternalServerError),
+	}
+}
+
+func newErrorResponse(errorCode string, err error) response.ErrorResponse {
+	return response.ErrorResponse{
+		ErrorCode:        errorCode,
+		ErrorDescription: err.Error(),
+	}
+}
+
+// errorCodeForStatus, Echo'nun ürettiği HTTP hatalarına durum koduna göre hata kodu atar.
+func errorCodeForStatus(status int) string {
+	switch {
+	case status == http.StatusNotFound:
+		return response.ErrorCodeNotFound
+	case status == http.StatusConflict:
+		return response.ErrorCodeConflict
+	case status == http.StatusPreconditionFailed:
+		return response.ErrorCodePreconditionFailed
+	case status == http.StatusPreconditionRequired:
+		return response.ErrorCodePreconditionRequired
+	case status == http.StatusServiceUnavailable:
+		return response.ErrorCodeServiceUnavailable
+	case status >= http.StatusInternalServerError:
+		return response.ErrorCodeInternalError
+	default:
+		return response.ErrorCodeBadRequest
+	}
+}
+
+func httpErrorMessage(httpErr *echo.HTTPError) string {
+	if message, ok := httpErr.Message.(string); ok {
+		return message
+	}
+	return http.StatusText(httpErr.Code)
+}
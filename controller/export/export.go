@@ -0,0 +1,161 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"product-app/controller/response"
+	"strconv"
+	"strings"
+)
+
+// ProductWriter, dışa aktarılan ürünleri seçilen biçimde bir akışa yazar.
+// Ürünler ara belleğe alınarak yazılır; Close çağrılmadan çıktı tamamlanmış sayılmaz.
+type ProductWriter interface {
+	Write(product response.ProductResponse) error
+	Close() error
+}
+
+// Format, desteklenen bir dışa aktarma biçimini ve HTTP yanıtında kullanılacak bilgilerini tanımlar.
+type Format struct {
+	Name          string // format parametresinde kullanılan ad.
+	ContentType   string // Yanıtın içerik tipi.
+	FileExtension string // İndirilen dosyanın uzantısı.
+	newWriter     func(output io.Writer) ProductWriter
+}
+
+// NewWriter, biçime uygun yeni bir ProductWriter oluşturur.
+func (format Format) NewWriter(output io.Writer) ProductWriter {
+	return format.newWriter(output)
+}
+
+// Desteklenen dışa aktarma biçimleri.
+var (
+	FormatCsv = Format{Name: "csv", ContentType: "text/csv; charset=utf-8", FileExtension: ".csv",
+		newWriter: newCsvWriter}
+	FormatNdjson = Format{Name: "ndjson", ContentType: "application/x-ndjson", FileExtension: ".ndjson",
+		newWriter: newNdjsonWriter}
+	FormatXlsx = Format{Name: "xlsx", ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", FileExtension: ".xlsx",
+		newWriter: newXlsxWriter}
+)
+
+// ParseFormat, format parametresini desteklenen bir biçime çevirir; boşsa CSV kullanılır.
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "", FormatCsv.Name:
+		return FormatCsv, nil
+	case FormatNdjson.Name:
+		return FormatNdjson, nil
+	case FormatXlsx.Name:
+		return FormatXlsx, nil
+	}
+	return Format{}, fmt.Errorf("Parameter format must be one of %s, %s, %s", FormatCsv.Name, FormatNdjson.Name, FormatXlsx.Name)
+}
+
+// productColumns, CSV ve Excel çıktılarındaki kolon başlıklarıdır; adlar JSON alan adlarıyla aynıdır.
+var productColumns = []string{"id", "name", "price", "currency", "discount", "discountAmount", "finalPrice", "storeId", "store", "campaignId"}
+
+// productCell, tablo biçimlerinde tek bir hücrenin metin değerini ve sayı olup olmadığını taşır.
+type productCell struct {
+	value   string
+	numeric bool
+}
+
+// productCells, ürünü productColumns sırasıyla hücrelere çevirir. Kampanyası olmayan ürünlerde campaignId boş kalır.
+func productCells(product response.ProductResponse) []productCell {
+	campaignId := productCell{}
+	if product.CampaignId != nil {
+		campaignId = productCell{value: strconv.FormatInt(*product.CampaignId, 10), numeric: true}
+	}
+	return []productCell{
+		{value: strconv.FormatInt(product.Id, 10), numeric: true},
+		{value: product.Name},
+		{value: product.Price, numeric: true},
+		{value: product.Currency},
+		{value: product.Discount.String(), numeric: true},
+		{value: product.DiscountAmount, numeric: true},
+		{value: product.FinalPrice, numeric: true},
+		{value: strconv.FormatInt(product.StoreId, 10), numeric: true},
+		{value: product.Store},
+		campaignId,
+	}
+}
+
+// formulaPrefixes, tablo programlarının hücreyi formül olarak çalıştırmasına yol açan ilk karakterlerdir.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula, formül gibi başlayan metnin başına ' ekler; böylece CSV dosyası Excel veya
+// LibreOffice ile açıldığında metin formül olarak çalıştırılmaz (OWASP CSV injection önerisi).
+func escapeFormula(value string) string {
+	if len(value) > 0 && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvWriter, ürünleri başlık satırı olan bir CSV dosyası olarak yazar.
+// Metin hücreleri formül enjeksiyonuna karşı escapeFormula ile yazılır.
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newCsvWriter(output io.Writer) ProductWriter {
+	return &csvWriter{writer: csv.NewWriter(output)}
+}
+
+// Write, ilk üründen önce başlık satırını yazar.
+func (csvWriter *csvWriter) Write(product response.ProductResponse) error {
+	if err := csvWriter.writeHeader(); err != nil {
+		return err
+	}
+	cells := productCells(product)
+	record := make([]string, len(cells))
+	for index, cell := range cells {
+		record[index] = cell.value
+		if !cell.numeric {
+			record[index] = escapeFormula(cell.value)
+		}
+	}
+	return csvWriter.writer.Write(record)
+}
+
+// Close, hiç ürün yazılmadıysa yalnızca başlık satırını yazar ve ara belleği boşaltır.
+func (csvWriter *csvWriter) Close() error {
+	if err := csvWriter.writeHeader(); err != nil {
+		return err
+	}
+	csvWriter.writer.Flush()
+	return csvWriter.writer.Error()
+}
+
+func (csvWriter *csvWriter) writeHeader() error {
+	if csvWriter.headerWritten {
+		return nil
+	}
+	csvWriter.headerWritten = true
+	return csvWriter.writer.Write(productColumns)
+}
+
+// ndjsonWriter, her ürünü API yanıtıyla aynı alanlara sahip bir JSON satırı olarak yazar.
+type ndjsonWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNdjsonWriter(output io.Writer) ProductWriter {
+	buffer := bufio.NewWriter(output)
+	return &ndjsonWriter{buffer: buffer, encoder: json.NewEncoder(buffer)}
+}
+
+// Write, ürünü tek satırlık bir JSON nesnesi olarak yazar.
+func (ndjsonWriter *ndjsonWriter) Write(product response.ProductResponse) error {
+	return ndjsonWriter.encoder.Encode(product)
+}
+
+// Close, ara bellekte kalan satırları yazar.
+func (ndjsonWriter *ndjsonWriter) Close() error {
+	return ndjsonWriter.buffer.Flush()
+}
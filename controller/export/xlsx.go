@@ -0,0 +1,132 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"product-app/controller/response"
+	"strconv"
+)
+
+// xlsxStaticParts, tek sayfalık bir Excel (Office Open XML) çalışma kitabının sayfa dışındaki sabit parçalarıdır.
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Products" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// xlsxWriter, ürünleri bir Excel çalışma kitabının tek sayfasına satır satır yazar.
+// Sayfa, zip arşivine akış olarak yazıldığı için tüm satırların bellekte tutulması gerekmez.
+// Metinler paylaşılan metin tablosu yerine hücre içinde (inlineStr) saklanır.
+type xlsxWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	rowNo   int
+	err     error
+}
+
+func newXlsxWriter(output io.Writer) ProductWriter {
+	return &xlsxWriter{archive: zip.NewWriter(output)}
+}
+
+// Write, ilk üründen önce sabit parçaları ve başlık satırını yazar, ardından ürünü bir satır olarak ekler.
+func (xlsxWriter *xlsxWriter) Write(product response.ProductResponse) error {
+	if err := xlsxWriter.start(); err != nil {
+		return err
+	}
+	xlsxWriter.writeRow(productCells(product))
+	return xlsxWriter.err
+}
+
+// Close, sayfayı ve zip arşivini kapatır.
+func (xlsxWriter *xlsxWriter) Close() error {
+	if err := xlsxWriter.start(); err != nil {
+		return err
+	}
+	xlsxWriter.writeString(`</sheetData></worksheet>`)
+	if xlsxWriter.err == nil {
+		xlsxWriter.err = xlsxWriter.sheet.Flush()
+	}
+	if xlsxWriter.err != nil {
+		return xlsxWriter.err
+	}
+	return xlsxWriter.archive.Close()
+}
+
+// start, çalışma kitabının sabit parçalarını ve sayfanın başlık satırını bir kez yazar.
+func (xlsxWriter *xlsxWriter) start() error {
+	if xlsxWriter.sheet != nil || xlsxWriter.err != nil {
+		return xlsxWriter.err
+	}
+	for _, part := range xlsxStaticParts {
+		partWriter, err := xlsxWriter.archive.Create(part.name)
+		if err != nil {
+			xlsxWriter.err = err
+			return err
+		}
+		if _, err := io.WriteString(partWriter, part.content); err != nil {
+			xlsxWriter.err = err
+			return err
+		}
+	}
+	sheetWriter, err := xlsxWriter.archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		xlsxWriter.err = err
+		return err
+	}
+	xlsxWriter.sheet = bufio.NewWriter(sheetWriter)
+	xlsxWriter.writeString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header := make([]productCell, len(productColumns))
+	for index, column := range productColumns {
+		header[index] = productCell{value: column}
+	}
+	xlsxWriter.writeRow(header)
+	return xlsxWriter.err
+}
+
+// writeRow, hücreleri sayı veya satır içi metin olarak yeni bir satıra yazar; boş hücreler atlanır.
+func (xlsxWriter *xlsxWriter) writeRow(cells []productCell) {
+	xlsxWriter.rowNo++
+	rowNo := strconv.Itoa(xlsxWriter.rowNo)
+	xlsxWriter.writeString(`<row r="` + rowNo + `">`)
+	for index, cell := range cells {
+		if len(cell.value) == 0 {
+			continue
+		}
+		reference := string(rune('A'+index)) + rowNo
+		if cell.numeric {
+			xlsxWriter.writeString(`<c r="` + reference + `"><v>` + cell.value + `</v></c>`)
+			continue
+		}
+		xlsxWriter.writeString(`<c r="` + reference + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if xlsxWriter.err == nil {
+			xlsxWriter.err = xml.EscapeText(xlsxWriter.sheet, []byte(cell.value))
+		}
+		xlsxWriter.writeString(`</t></is></c>`)
+	}
+	xlsxWriter.writeString(`</row>`)
+}
+
+// writeString, önceki bir yazma hatası yoksa metni sayfaya yazar; ilk hata saklanır.
+func (xlsxWriter *xlsxWriter) writeString(value string) {
+	if xlsxWriter.err != nil {
+		return
+	}
+	_, xlsxWriter.err = xlsxWriter.sheet.WriteString(value)
+}
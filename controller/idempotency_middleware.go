@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"io"
+	"net/http"
+	"product-app/domain"
+	"product-app/service"
+	"strings"
+)
+
+// IdempotencyKeyHeader, istemcinin aynı isteği tekrar gönderdiğini belirtmek için kullandığı başlıktır.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader, yanıtın yeniden işlenmeden saklanan yanıttan döndüğünü belirten başlıktır.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength, idempotency anahtarının veritabanında saklanabilecek en fazla karakter sayısıdır.
+const maxIdempotencyKeyLength = 255
+
+// replayedHeaders, saklanan yanıtla birlikte tekrar gönderilen başlıklardır.
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, headerETag}
+
+// NewIdempotencyMiddleware, Idempotency-Key başlığı verilen istekleri bir kez işleyen bir ara katman oluşturur.
+// İlk isteğin yanıtı saklanır ve aynı anahtar ile aynı gövdeyle gelen tekrarlara aynen döndürülür.
+// Aynı anahtar farklı bir istekle kullanılırsa 422, ilk istek hâlâ işleniyorsa 409 döner.
+// 5xx ve 499 yanıtlarında veya kontrolcü panik ile çıkarsa anahtar bırakılır; istemci aynı anahtarla yeniden
+// deneyebilir. Başarılı bir yanıt kaydedilemezse istek yeniden işlenmesin diye anahtar bırakılmaz, kilidinin
+// dolmasını bekler. Gövdesi maxBodySize bayttan büyük istekler 413 alır. Başlık yoksa istek olduğu gibi işlenir.
+func NewIdempotencyMiddleware(idempotencyService service.IIdempotencyService, maxBodySize int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := strings.TrimSpace(c.Request().Header.Get(IdempotencyKeyHeader))
+			if len(key) == 0 {
+				return next(c)
+			}
+			if len([]rune(key)) > maxIdempotencyKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Header Idempotency-Key can not be longer than 255 characters")
+			}
+			// Gövde, özeti hesaplandıktan sonra kontrolcünün okuyabilmesi için yeniden yerine konur.
+			body, readErr := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxBodySize))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(readErr, &maxBytesErr) {
+				return echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body can not be larger than %d bytes", maxBodySize))
+			}
+			if readErr != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Request body could not be read")
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			savedResponse, err := idempotencyService.Begin(c.Request().Context(), key, requestHash(c.Request(), body))
+			if err != nil {
+				return err
+			}
+			if savedResponse != nil {
+				return replayResponse(c, *savedResponse)
+			}
+
+			// İstemci bağlantıyı kesmiş olsa bile anahtarın durumu kaydedilmelidir.
+			ctx := context.WithoutCancel(c.Request().Context())
+			// Yanıt kaydedilmediyse anahtar bırakılır; kontrolcü panik ile çıktığında da bu çalışır
+			// ve panik yukarıdaki katmanlara aynen iletilir.
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if abandonErr := idempotencyService.Abandon(ctx, key); abandonErr != nil {
+					log.Errorf("%s idempotency anahtarı bırakılamadı: %v", key, abandonErr)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			if handlerErr := next(c); handlerErr != nil {
+				// Hata yanıtının da saklanabilmesi için hata işleyici burada çağrılır.
+				c.Error(handlerErr)
+			}
+
+			status := c.Response().Status
+			if !c.Response().Committed || status >= http.StatusInternalServerError || status == StatusClientClosedRequest {
+				return nil
+			}
+			header := map[string][]string{}
+			for _, name := range replayedHeaders {
+				if values := c.Response().Header().Values(name); len(values) > 0 {
+					header[name] = values
+				}
+			}
+			savedResponse = &domain.IdempotentResponse{StatusCode: status, Header: header, Body: recorder.body.Bytes()}
+			// Yanıt istemciye gitti; kaydedilemese bile anahtar bırakılmaz, yoksa tekrar aynı ürünü yeniden oluşturur.
+			completed = true
+			if completeErr := idempotencyService.Complete(ctx, key, *savedResponse); completeErr != nil {
+				log.Errorf("%s idempotency anahtarının yanıtı kaydedilemedi, anahtar kilidi dolana kadar kilitli kalacak: %v", key, completeErr)
+			}
+			return nil
+		}
+	}
+}
+
+// requestHash, isteğin yöntemini, yolunu ve gövdesini SHA-256 ile özetler.
+// Aynı anahtarın farklı bir istekle kullanılıp kullanılmadığı bu özetle anlaşılır.
+func requestHash(request *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + " " + request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replayResponse, saklanan yanıtı başlıklarıyla birlikte yeniden yazar.
+func replayResponse(c echo.Context, savedResponse domain.IdempotentResponse) error {
+	for name, values := range savedResponse.Header {
+		for _, value := range values {
+			c.Response().Header().Add(name, value)
+		}
+	}
+	c.Response().Header().Set(IdempotentReplayedHeader, "true")
+	c.Response().WriteHeader(savedResponse.StatusCode)
+	_, err := c.Response().Write(savedResponse.Body)
+	return err
+}
+
+// responseRecorder, yanıt gövdesini istemciye yazarken bir kopyasını da saklar.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (recorder *responseRecorder) Write(data []byte) (int, error) {
+	recorder.body.Write(data)
+	return recorder.ResponseWriter.Write(data)
+}
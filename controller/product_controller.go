@@ -1,125 +1,493 @@
 package controller
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"io"
+	"mime"
 	"net/http"
+	"product-app/common/money"
+	"product-app/controller/export"
 	"product-app/controller/request"
 	"product-app/controller/response"
+	"product-app/domain"
 	"product-app/service"
 	"strconv"
+	"strings"
+)
+
+// mergePatchContentType, JSON merge-patch istekleri için kullanılan içerik tipidir.
+const mergePatchContentType = "application/merge-patch+json"
+
+// Koşullu istekler için kullanılan HTTP başlıkları.
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
 )
 
 // ProductController, ürünlerle ilgili işlemleri yöneten bir kontrolcü yapısıdır.
 type ProductController struct {
-	productService service.IProductService
+	productService     service.IProductService
+	idempotencyService service.IIdempotencyService // Ürün ekleme isteklerinin tekrarlarını ayıklamak için kullanılır.
+	maxIdempotentBody  int64                       // Idempotency-Key ile gelen istek gövdesinin en büyük boyutu.
 }
 
 // NewProductController, yeni bir ProductController nesnesi oluşturur ve döndürür.
-func NewProductController(productService service.IProductService) *ProductController {
+func NewProductController(productService service.IProductService, idempotencyService service.IIdempotencyService,
+	maxIdempotentBody int64) *ProductController {
 	return &ProductController{
-		productService: productService,
+		productService:     productService,
+		idempotencyService: idempotencyService,
+		maxIdempotentBody:  maxIdempotentBody,
 	}
 }
 
 // RegisterRoutes, ürünle ilgili API uç noktalarını Echo framework'e kaydeder.
 func (productController *ProductController) RegisterRoutes(e *echo.Echo) {
-	e.GET("/api/v1/products/:id", productController.GetProductById)       // Belirli bir ürünü ID ile getirir.
-	e.GET("/api/v1/products", productController.GetAllProducts)           // Tüm ürünleri listeler.
-	e.POST("/api/v1/products", productController.AddProduct)              // Yeni bir ürün ekler.
-	e.PUT("/api/v1/products/:id", productController.UpdatePrice)          // Belirli bir ürünün fiyatını günceller.
-	e.DELETE("/api/v1/products/:id", productController.DeleteProductById) // Belirli bir ürünü siler.
+	idempotent := NewIdempotencyMiddleware(productController.idempotencyService, productController.maxIdempotentBody)
+
+	e.GET("/api/v1/products/search", productController.SearchProducts)             // Ürün adlarında tam metin araması yapar.
+	e.GET("/api/v1/products/export", productController.ExportProducts)             // Ürün kataloğunu CSV, NDJSON veya Excel olarak indirir.
+	e.GET("/api/v1/products/trash", productController.GetTrash)                    // Çöp kutusundaki ürünleri listeler.
+	e.GET("/api/v1/products/:id/price-history", productController.GetPriceHistory) // Belirli bir ürünün fiyat geçmişini listeler.
+	e.GET("/api/v1/products/:id", productController.GetProductById)                // Belirli bir ürünü ID ile getirir.
+	e.GET("/api/v1/products", productController.GetAllProducts)                    // Tüm ürünleri listeler.
+	e.POST("/api/v1/products", productController.AddProduct, idempotent)           // Yeni bir ürün ekler; Idempotency-Key ile tekrarlar ayıklanır.
+	e.POST("/api/v1/products/import", productController.ImportProducts)            // CSV veya NDJSON dosyasından toplu ürün ekler.
+	e.POST("/api/v1/products/batch", productController.ExecuteBatch)               // Ekleme, fiyat güncelleme ve silme işlemlerini tek transaction içinde uygular.
+	e.PUT("/api/v1/products/:id", productController.UpdateProduct)                 // Belirli bir ürünü tamamen değiştirir.
+	e.PATCH("/api/v1/products/:id", productController.PatchProduct)                // Belirli bir ürüne kısmi güncelleme uygular.
+	e.PUT("/api/v1/products/:id/price", productController.UpdatePrice)             // Belirli bir ürünün fiyatını günceller.
+	e.DELETE("/api/v1/products/:id", productController.DeleteProductById)          // Belirli bir ürünü çöp kutusuna taşır.
+	e.POST("/api/v1/products/:id/restore", productController.RestoreProduct)       // Çöp kutusundaki bir ürünü geri yükler.
+	e.GET("/api/v1/stores/:id/products", productController.GetProductsByStore)     // Belirli bir mağazanın ürünlerini listeler.
 }
 
 // GetProductById, ID'ye göre bir ürünü getirir.
 func (productController *ProductController) GetProductById(c echo.Context) error {
-	param := c.Param("id")
-	productId, _ := strconv.Atoi(param) // ID'yi string'den int'e çevirir.
-
-	// Ürünü servis katmanından alır.
-	product, err := productController.productService.GetById(int64(productId))
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	currency, parseErr := request.ParseOptionalCurrency(c.QueryParam("currency"))
+	if parseErr != nil {
+		// Desteklenmeyen bir para birimi istenirse, 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, parseErr.Error())
+	}
+	// Ürünü servis katmanından alır; bulunamazsa hata işleyici 404 döner.
+	// Para birimi verilmişse fiyat o para birimine çevrilir.
+	var product domain.Product
+	if len(currency) > 0 {
+		product, err = productController.productService.GetByIdInCurrency(c.Request().Context(), productId, currency)
+	} else {
+		product, err = productController.productService.GetById(c.Request().Context(), productId)
+	}
 	if err != nil {
-		// Eğer ürün bulunamazsa, 404 döner.
-		return c.JSON(http.StatusNotFound, response.ErrorResponse{
-			ErrorDescription: err.Error(),
-		})
+		return err
 	}
-	// Ürün bulunduysa, 200 ile ürün detaylarını döner.
+	// Ürün bulunduysa, 200 ile ürün detaylarını ve koşullu güncellemelerde kullanılacak ETag'i döner.
+	c.Response().Header().Set(headerETag, productETag(product.Version))
 	return c.JSON(http.StatusOK, response.ToResponse(product))
 }
 
-// GetAllProducts, tüm ürünleri veya belirli bir mağazaya ait ürünleri getirir.
+// GetAllProducts, ürünleri filtreleyerek, sıralayarak ve sayfalayarak getirir.
 func (productController *ProductController) GetAllProducts(c echo.Context) error {
-	store := c.QueryParam("store") // Mağaza sorgu parametresini alır.
-	if len(store) == 0 {
-		// Mağaza belirtilmemişse tüm ürünleri getirir.
-		allProducts := productController.productService.GetAllProducts()
-		return c.JSON(http.StatusOK, response.ToResponseList(allProducts))
+	var productListRequest request.ProductListRequest
+	if err := c.Bind(&productListRequest); err != nil { // Sorgu parametrelerini modele bağlar.
+		return err
+	}
+	query, parseErr := productListRequest.ToQuery()
+	if parseErr != nil {
+		// Sorgu parametreleri ayrıştırılamazsa, 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, parseErr.Error())
+	}
+	page, err := productController.productService.GetProducts(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToListResponse(page))
+}
+
+// ExportProducts, listeleme filtrelerine uyan tüm ürünleri istenen biçimde dosya olarak akıtır.
+// Ürünler veritabanından gruplar hâlinde okunup yazıldığı için katalog belleğe alınmaz.
+// Yanıt başlıkları ilk ürün yazılırken ayarlanır; o ana kadar oluşan hatalar normal hata yanıtı olarak döner.
+func (productController *ProductController) ExportProducts(c echo.Context) error {
+	var productExportRequest request.ProductExportRequest
+	if err := c.Bind(&productExportRequest); err != nil { // Sorgu parametrelerini modele bağlar.
+		return err
+	}
+	format, formatErr := export.ParseFormat(productExportRequest.Format)
+	if formatErr != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, formatErr.Error())
+	}
+	query, parseErr := productExportRequest.ToQuery()
+	if parseErr != nil {
+		// Sorgu parametreleri ayrıştırılamazsa, 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, parseErr.Error())
+	}
+	productWriter := format.NewWriter(c.Response())
+	started := false
+	startResponse := func() {
+		started = true
+		c.Response().Header().Set(echo.HeaderContentType, format.ContentType)
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="products`+format.FileExtension+`"`)
+	}
+	err := productController.productService.Export(c.Request().Context(), query, func(product domain.Product) error {
+		if !started {
+			startResponse()
+		}
+		return productWriter.Write(response.ToResponse(product))
+	})
+	if err != nil && c.Response().Committed {
+		// Yanıt gönderilmeye başladıktan sonra durum kodu değiştirilemez; dosya yarım kalır.
+		log.Errorf("Ürün dışa aktarımı yarıda kaldı: %v", err)
+		return nil
+	}
+	if err != nil {
+		// Dosya başlıkları kaldırılır; hata işleyici yerine JSON hata yanıtı yazar.
+		c.Response().Header().Del(echo.HeaderContentType)
+		c.Response().Header().Del(echo.HeaderContentDisposition)
+		return err
+	}
+	if !started {
+		startResponse()
+	}
+	return productWriter.Close()
+}
+
+// GetProductsByStore, bir mağazanın ürünlerini listeleme ile aynı parametrelerle getirir.
+func (productController *ProductController) GetProductsByStore(c echo.Context) error {
+	storeId, err := parseStoreId(c)
+	if err != nil {
+		return err
+	}
+	var productListRequest request.ProductListRequest
+	if err := c.Bind(&productListRequest); err != nil { // Sorgu parametrelerini modele bağlar.
+		return err
+	}
+	query, parseErr := productListRequest.ToQuery()
+	if parseErr != nil {
+		// Sorgu parametreleri ayrıştırılamazsa, 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, parseErr.Error())
+	}
+	// Mağaza bulunamazsa hata işleyici 404 döner.
+	page, err := productController.productService.GetAllProductsByStore(c.Request().Context(), storeId, query)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToListResponse(page))
+}
+
+// SearchProducts, ürün adlarında "q" parametresiyle tam metin araması yapar.
+func (productController *ProductController) SearchProducts(c echo.Context) error {
+	searchText := c.QueryParam("q") // Arama metnini alır.
+	limit := 0
+	if limitParam := c.QueryParam("limit"); len(limitParam) > 0 {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Parameter limit must be a positive integer")
+		}
+		limit = parsedLimit
+	}
+	products, err := productController.productService.Search(c.Request().Context(), searchText, limit)
+	if err != nil {
+		return err
 	}
-	// Belirli bir mağazanın ürünlerini getirir.
-	productsWithGivenStore := productController.productService.GetAllProductsByStore(store)
-	return c.JSON(http.StatusOK, response.ToResponseList(productsWithGivenStore))
+	return c.JSON(http.StatusOK, response.ToResponseList(products))
 }
 
 // AddProduct, yeni bir ürün ekler.
 func (productController *ProductController) AddProduct(c echo.Context) error {
 	var addProductRequest request.AddProductRequest
-	bindErr := c.Bind(&addProductRequest) // Gelen isteği modele bağlar.
-	if bindErr != nil {
-		// Eğer bağlama sırasında hata olursa, 400 döner.
-		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-			ErrorDescription: bindErr.Error(),
-		})
+	if err := c.Bind(&addProductRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
 	}
-	// Ürünü servis katmanına ekler.
-	err := productController.productService.Add(addProductRequest.ToModel())
+	// Ürünü servis katmanına ekler; doğrulama hatasında hata işleyici 422 döner.
+	product, err := productController.productService.Add(c.Request().Context(), addProductRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	// Başarılı ekleme durumunda eklenen ürün, adresi ve sürümüyle birlikte 201 döner.
+	c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/api/v1/products/%d", product.Id))
+	c.Response().Header().Set(headerETag, productETag(product.Version))
+	return c.JSON(http.StatusCreated, response.ToResponse(product))
+}
 
+// ImportProducts, CSV veya NDJSON dosyasındaki ürünleri doğrular ve hepsi geçerliyse tek seferde ekler.
+// Dosya istek gövdesinde ya da multipart formun "file" alanında gönderilebilir.
+// dryRun=true ise yalnızca doğrulama yapılır ve 200 döner; hatalı satır varsa hiçbir ürün eklenmez ve 422 döner.
+func (productController *ProductController) ImportProducts(c echo.Context) error {
+	dryRun := false
+	if dryRunParam := c.QueryParam("dryRun"); len(dryRunParam) > 0 {
+		parsedDryRun, parseErr := strconv.ParseBool(dryRunParam)
+		if parseErr != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Parameter dryRun must be true or false")
+		}
+		dryRun = parsedDryRun
+	}
+	body, format, err := importSource(c)
 	if err != nil {
-		// Eğer ekleme sırasında doğrulama hatası oluşursa, 422 döner.
-		return c.JSON(http.StatusUnprocessableEntity, response.ErrorResponse{
-			ErrorDescription: err.Error(),
-		})
+		return err
 	}
-	// Başarılı ekleme durumunda 201 döner.
-	return c.NoContent(http.StatusCreated)
+	defer body.Close()
+	rows, parseErr := request.ParseProductImport(body, format, service.MaxImportRows)
+	if parseErr != nil {
+		// Dosyanın yapısı bozuksa 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, parseErr.Error())
+	}
+	report, err := productController.productService.Import(c.Request().Context(), rows, dryRun)
+	if err != nil {
+		return err
+	}
+	status := http.StatusCreated
+	if len(report.RowErrors) > 0 {
+		status = http.StatusUnprocessableEntity
+	} else if report.DryRun {
+		status = http.StatusOK
+	}
+	return c.JSON(status, response.ToProductImportResponse(report))
+}
+
+// ExecuteBatch, ekleme, fiyat güncelleme ve silme işlemlerini tek transaction içinde uygular
+// ve her işlemin sonucunu istekteki sırayla döner.
+// Tüm işlemler başarılıysa 200, herhangi bir işlem başarısız olduysa sonuçlarla birlikte 207 döner.
+func (productController *ProductController) ExecuteBatch(c echo.Context) error {
+	var productBatchRequest request.ProductBatchRequest
+	if err := c.Bind(&productBatchRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	batchResult, err := productController.productService.ExecuteBatch(c.Request().Context(), productBatchRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	status := http.StatusOK
+	if batchResult.Count(domain.BatchStatusSucceeded) < len(batchResult.Results) {
+		status = http.StatusMultiStatus
+	}
+	return c.JSON(status, response.ToProductBatchResponse(batchResult, func(operationErr error) response.ErrorResponse {
+		_, errorResponse := toErrorResponse(operationErr)
+		return errorResponse
+	}))
+}
+
+// UpdateProduct, bir ürünün tüm alanlarını istekte gönderilen değerlerle değiştirir.
+// Eski istemciler için "newPrice" sorgu parametresiyle gelen istekler hâlâ yalnızca fiyatı günceller;
+// bu kullanım kullanımdan kaldırılmıştır ve yanıtta Deprecation başlığı döner.
+func (productController *ProductController) UpdateProduct(c echo.Context) error {
+	if c.QueryParam("newPrice") != "" {
+		c.Response().Header().Set("Deprecation", "true")
+		return productController.UpdatePrice(c)
+	}
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	version, err := requiredVersion(c)
+	if err != nil {
+		return err
+	}
+	var updateProductRequest request.UpdateProductRequest
+	if err := c.Bind(&updateProductRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	err = productController.productService.Update(c.Request().Context(), productId, updateProductRequest.ToModel(), version)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
+}
+
+// PatchProduct, bir ürüne JSON merge-patch formatında kısmi güncelleme uygular.
+func (productController *ProductController) PatchProduct(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	version, err := requiredVersion(c)
+	if err != nil {
+		return err
+	}
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, mergePatchContentType) && !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+		// Merge-patch dışındaki içerik tipleri kabul edilmez, 415 döner.
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be "+mergePatchContentType)
+	}
+	var patchProductRequest request.PatchProductRequest
+	if decodeErr := json.NewDecoder(c.Request().Body).Decode(&patchProductRequest); decodeErr != nil {
+		// Geçersiz bir patch dokümanı gönderilirse, 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, decodeErr.Error())
+	}
+	err = productController.productService.Patch(c.Request().Context(), productId, patchProductRequest.ToModel(), version)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
 }
 
 // UpdatePrice, bir ürünün fiyatını günceller.
 func (productController *ProductController) UpdatePrice(c echo.Context) error {
-	param := c.Param("id")
-	productId, _ := strconv.Atoi(param) // ID'yi string'den int'e çevirir.
-
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	version, err := requiredVersion(c)
+	if err != nil {
+		return err
+	}
 	newPrice := c.QueryParam("newPrice") // Yeni fiyat sorgu parametresini alır.
 	if len(newPrice) == 0 {
 		// Eğer yeni fiyat belirtilmemişse, 400 döner.
-		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-			ErrorDescription: "Parameter newPrice is required!",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Parameter newPrice is required!")
 	}
-	// Yeni fiyatı float'a dönüştürür.
-	convertedPrice, err := strconv.ParseFloat(newPrice, 32)
+	// Yeni fiyatı hassasiyet kaybı olmadan ondalık sayıya dönüştürür.
+	convertedPrice, err := money.Parse(newPrice)
 	if err != nil {
 		// Dönüştürme sırasında hata olursa, 400 döner.
-		return c.JSON(http.StatusBadRequest, response.ErrorResponse{
-			ErrorDescription: "NewPrice Format Disrupted!",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "NewPrice Format Disrupted!")
 	}
 	// Ürün fiyatını servis katmanında günceller.
-	productController.productService.UpdatePrice(int64(productId), float32(convertedPrice))
+	err = productController.productService.UpdatePrice(c.Request().Context(), productId, convertedPrice, version)
+	if err != nil {
+		return err
+	}
 	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
 }
 
+// GetPriceHistory, bir ürünün fiyat değişikliklerini "from" ve "to" tarih aralığında getirir.
+func (productController *ProductController) GetPriceHistory(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	var priceHistoryRequest request.PriceHistoryRequest
+	if err := c.Bind(&priceHistoryRequest); err != nil { // Sorgu parametrelerini modele bağlar.
+		return err
+	}
+	query, parseErr := priceHistoryRequest.ToQuery()
+	if parseErr != nil {
+		// Tarihler ayrıştırılamazsa, 400 döner.
+		return echo.NewHTTPError(http.StatusBadRequest, parseErr.Error())
+	}
+	priceChanges, err := productController.productService.GetPriceHistory(c.Request().Context(), productId, query)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToPriceHistoryResponse(priceChanges))
+}
+
 // DeleteProductById, ID'ye göre bir ürünü siler.
 func (productController *ProductController) DeleteProductById(c echo.Context) error {
-	param := c.Param("id")
-	productId, _ := strconv.Atoi(param) // ID'yi string'den int'e çevirir.
-
-	// Ürünü servis katmanında siler.
-	err := productController.productService.DeleteById(int64(productId))
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	version, err := requiredVersion(c)
 	if err != nil {
-		// Eğer ürün bulunamazsa, 404 döner.
-		return c.JSON(http.StatusNotFound, response.ErrorResponse{
-			ErrorDescription: err.Error(),
-		})
+		return err
+	}
+	// Ürünü servis katmanında siler; bulunamazsa hata işleyici 404, sürüm eşleşmezse 412 döner.
+	err = productController.productService.DeleteById(c.Request().Context(), productId, version)
+	if err != nil {
+		return err
 	}
 	return c.NoContent(http.StatusOK) // Başarılı silme durumunda 200 döner.
 }
+
+// GetTrash, çöp kutusundaki ürünleri en son silinenden başlayarak listeler.
+func (productController *ProductController) GetTrash(c echo.Context) error {
+	products, err := productController.productService.GetTrash(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToResponseList(products))
+}
+
+// RestoreProduct, çöp kutusundaki bir ürünü geri yükler.
+func (productController *ProductController) RestoreProduct(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	// Ürün çöp kutusunda değilse hata işleyici 404 döner.
+	err = productController.productService.Restore(c.Request().Context(), productId)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı geri yüklemede 200 döner.
+}
+
+// parseProductId, yol parametresindeki ürün ID'sini ayrıştırır; geçersizse 400 hatası döner.
+func parseProductId(c echo.Context) (int64, error) {
+	productId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || productId < 1 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Product id must be a positive integer")
+	}
+	return productId, nil
+}
+
+// requiredVersion, If-Match başlığındaki ETag'den ürünün beklenen sürümünü okur.
+// Başlık yoksa 428, ETag geçersizse 400 döner; "*" sürümü denetlemeden herhangi bir ürünle eşleşir.
+func requiredVersion(c echo.Context) (int64, error) {
+	ifMatch := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if len(ifMatch) == 0 {
+		return 0, echo.NewHTTPError(http.StatusPreconditionRequired, "Header If-Match is required")
+	}
+	if ifMatch == "*" {
+		return domain.AnyVersion, nil
+	}
+	invalidErr := echo.NewHTTPError(http.StatusBadRequest, "Header If-Match must be an ETag returned for the product")
+	if len(ifMatch) < 3 || ifMatch[0] != '"' || ifMatch[len(ifMatch)-1] != '"' {
+		return 0, invalidErr
+	}
+	version, err := strconv.ParseInt(ifMatch[1:len(ifMatch)-1], 10, 64)
+	if err != nil || version < 1 {
+		return 0, invalidErr
+	}
+	return version, nil
+}
+
+// productETag, ürünün sürümünden güçlü bir ETag oluşturur, örneğin "3".
+func productETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// importSource, içe aktarılacak dosyayı ve biçimini istekten belirler. Biçim, gövdenin veya
+// multipart dosyasının içerik tipinden, o da yoksa dosya uzantısından anlaşılır.
+func importSource(c echo.Context) (io.ReadCloser, string, error) {
+	mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if mediaType != echo.MIMEMultipartForm {
+		format := importFormatOf(mediaType, "")
+		if len(format) == 0 {
+			return nil, "", echo.NewHTTPError(http.StatusUnsupportedMediaType, "Content-Type must be text/csv, application/x-ndjson or multipart/form-data")
+		}
+		return c.Request().Body, format, nil
+	}
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, "", echo.NewHTTPError(http.StatusBadRequest, "Multipart field file is required")
+	}
+	fileMediaType, _, _ := mime.ParseMediaType(fileHeader.Header.Get(echo.HeaderContentType))
+	format := importFormatOf(fileMediaType, fileHeader.Filename)
+	if len(format) == 0 {
+		return nil, "", echo.NewHTTPError(http.StatusUnsupportedMediaType, "File must be a .csv or .ndjson file")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	return file, format, nil
+}
+
+// importFormatOf, içerik tipine veya dosya adına göre içe aktarma biçimini döner; tanınmazsa boş döner.
+func importFormatOf(mediaType string, fileName string) string {
+	switch {
+	case mediaType == "text/csv" || strings.HasSuffix(strings.ToLower(fileName), ".csv"):
+		return request.ImportFormatCsv
+	case mediaType == "application/x-ndjson" || mediaType == "application/ndjson" ||
+		strings.HasSuffix(strings.ToLower(fileName), ".ndjson") || strings.HasSuffix(strings.ToLower(fileName), ".jsonl"):
+		return request.ImportFormatNdjson
+	}
+	return ""
+}
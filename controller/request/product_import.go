@@ -0,0 +1,181 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"strconv"
+	"strings"
+)
+
+// Toplu ürün içe aktarmada desteklenen dosya biçimleri.
+const (
+	ImportFormatCsv    = "csv"    // İlk satırı kolon başlıkları olan CSV dosyası.
+	ImportFormatNdjson = "ndjson" // Her satırı bir ürün ekleme isteği olan NDJSON dosyası.
+)
+
+// maxNdjsonLineSize, NDJSON dosyasında tek bir satırın en fazla bayt sayısıdır.
+const maxNdjsonLineSize = 1 << 20
+
+// importColumns, CSV başlığında kabul edilen kolonları ürün alanlarına eşler; başlıklar büyük/küçük harfe duyarsızdır.
+var importColumns = map[string]string{
+	"name":     "name",
+	"price":    "price",
+	"currency": "currency",
+	"discount": "discount",
+	"storeid":  "storeId",
+}
+
+// ParseProductImport, yüklenen dosyadaki ürün satırlarını okur. Dosyanın yapısı bozuksa
+// (eksik başlık, kolon sayısı tutmayan satır, geçersiz JSON) veya maxRows'tan fazla satır varsa hata döner.
+// Sayı olarak ayrıştırılamayan alanlar ise hata olarak değil, satırın ParseErrors alanında döner.
+func ParseProductImport(body io.Reader, format string, maxRows int) ([]model.ProductImportRow, error) {
+	switch format {
+	case ImportFormatCsv:
+		return parseCsvImport(body, maxRows)
+	case ImportFormatNdjson:
+		return parseNdjsonImport(body, maxRows)
+	}
+	return nil, fmt.Errorf("Import format must be %s or %s", ImportFormatCsv, ImportFormatNdjson)
+}
+
+// parseCsvImport, başlık satırındaki kolon adlarına göre CSV satırlarını okur.
+func parseCsvImport(body io.Reader, maxRows int) ([]model.ProductImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []model.ProductImportRow{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CSV file could not be read: %w", err)
+	}
+	columnFields := make([]string, len(header))
+	seenFields := map[string]bool{}
+	for index, column := range header {
+		// Excel gibi araçların dosya başına eklediği BOM karakteri atılır.
+		normalized := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		field, known := importColumns[normalized]
+		if !known {
+			return nil, fmt.Errorf("Unknown CSV column %q", column)
+		}
+		if seenFields[field] {
+			return nil, fmt.Errorf("CSV column %q is repeated", column)
+		}
+		seenFields[field] = true
+		columnFields[index] = field
+	}
+	if !seenFields["name"] || !seenFields["price"] || !seenFields["storeId"] {
+		return nil, errors.New("CSV header must contain name, price and storeId columns")
+	}
+
+	rows := []model.ProductImportRow{}
+	for {
+		record, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			return rows, nil
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("CSV file could not be read: %w", readErr)
+		}
+		if len(rows) == maxRows {
+			return nil, fmt.Errorf("Import file can not contain more than %d products", maxRows)
+		}
+		values := map[string]string{}
+		for index, value := range record {
+			values[columnFields[index]] = strings.TrimSpace(value)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, toImportRow(line, values))
+	}
+}
+
+// ndjsonProductRow, NDJSON dosyasındaki bir satırdır. Sayısal alanlar, metin veya sayı olarak
+// gönderilebilmeleri ve hatalı değerlerin satır bazında raporlanabilmesi için ham olarak okunur.
+type ndjsonProductRow struct {
+	Name     string          `json:"name"`
+	Price    json.RawMessage `json:"price"`
+	Currency string          `json:"currency"`
+	Discount json.RawMessage `json:"discount"`
+	StoreId  json.RawMessage `json:"storeId"`
+}
+
+// parseNdjsonImport, boş satırları atlayarak her satırı ayrı bir JSON nesnesi olarak okur.
+func parseNdjsonImport(body io.Reader, maxRows int) ([]model.ProductImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNdjsonLineSize)
+	rows := []model.ProductImportRow{}
+	for line := 1; scanner.Scan(); line++ {
+		content := bytes.TrimSpace(scanner.Bytes())
+		if len(content) == 0 {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, fmt.Errorf("Import file can not contain more than %d products", maxRows)
+		}
+		var productRow ndjsonProductRow
+		if err := json.Unmarshal(content, &productRow); err != nil {
+			return nil, fmt.Errorf("Line %d is not a valid product JSON object", line)
+		}
+		rows = append(rows, toImportRow(line, map[string]string{
+			"name":     productRow.Name,
+			"price":    rawJsonText(productRow.Price),
+			"currency": productRow.Currency,
+			"discount": rawJsonText(productRow.Discount),
+			"storeId":  rawJsonText(productRow.StoreId),
+		}))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("NDJSON file could not be read: %w", err)
+	}
+	return rows, nil
+}
+
+// toImportRow, bir satırın metin değerlerini ProductCreate modeline çevirir.
+// Boş sayısal alanlar sıfır kabul edilir; zorunlu olanlar doğrulamada yakalanır.
+func toImportRow(line int, values map[string]string) model.ProductImportRow {
+	row := model.ProductImportRow{
+		Line: line,
+		Product: model.ProductCreate{
+			Name:     values["name"],
+			Currency: toCurrency(values["currency"]),
+		},
+	}
+	var err error
+	if price := values["price"]; len(price) > 0 {
+		if row.Product.Price, err = money.Parse(price); err != nil {
+			row.ParseErrors = append(row.ParseErrors, domain.FieldError{Field: "price", Message: "Price must be a decimal number"})
+		}
+	}
+	if discount := values["discount"]; len(discount) > 0 {
+		if row.Product.Discount, err = money.Parse(discount); err != nil {
+			row.ParseErrors = append(row.ParseErrors, domain.FieldError{Field: "discount", Message: "Discount must be a decimal number"})
+		}
+	}
+	if storeId := values["storeId"]; len(storeId) > 0 {
+		if row.Product.StoreId, err = strconv.ParseInt(storeId, 10, 64); err != nil {
+			row.ParseErrors = append(row.ParseErrors, domain.FieldError{Field: "storeId", Message: "StoreId must be an integer"})
+		}
+	}
+	return row
+}
+
+// rawJsonText, ham JSON değerini metne çevirir: metinler tırnaksız, sayılar olduğu gibi, null ise boş döner.
+func rawJsonText(raw json.RawMessage) string {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || string(trimmed) == "null" {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(trimmed, &text) == nil {
+		return strings.TrimSpace(text)
+	}
+	return string(trimmed)
+}
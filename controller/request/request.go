@@ -1,14 +1,26 @@
 package request
 
-import "product-app/service/model"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // AddProductRequest, bir ürün ekleme isteği için kullanılan yapıdır.
 // JSON formatında gönderilen veriler bu yapıya map edilir.
+// Fiyat ve indirim, "1999.90" gibi metin veya sayı olarak gönderilebilir.
 type AddProductRequest struct {
-	Name     string  `json:"name"`     // Ürünün adı
-	Price    float32 `json:"price"`    // Ürünün fiyatı
-	Discount float32 `json:"discount"` // Ürünün indirim oranı
-	Store    string  `json:"store"`    // Ürünün mağazası
+	Name     string        `json:"name"`     // Ürünün adı
+	Price    money.Decimal `json:"price"`    // Ürünün fiyatı
+	Currency string        `json:"currency"` // Fiyatın para birimi, boşsa TRY
+	Discount money.Decimal `json:"discount"` // Ürünün indirim oranı
+	StoreId  int64         `json:"storeId"`  // Ürünün bağlı olduğu mağazanın ID'si
 }
 
 // ToModel, AddProductRequest yapısını service katmanında kullanılan
@@ -17,7 +29,442 @@ func (addProductRequest AddProductRequest) ToModel() model.ProductCreate {
 	return model.ProductCreate{
 		Name:     addProductRequest.Name,
 		Price:    addProductRequest.Price,
+		Currency: toCurrency(addProductRequest.Currency),
 		Discount: addProductRequest.Discount,
-		Store:    addProductRequest.Store,
+		StoreId:  addProductRequest.StoreId,
 	}
 }
+
+// UpdateProductRequest, bir ürünün tamamen değiştirilmesi (PUT) isteği için kullanılan yapıdır.
+type UpdateProductRequest struct {
+	Name     string        `json:"name"`     // Ürünün adı
+	Price    money.Decimal `json:"price"`    // Ürünün fiyatı
+	Currency string        `json:"currency"` // Fiyatın para birimi, boşsa TRY
+	Discount money.Decimal `json:"discount"` // Ürünün indirim oranı
+	StoreId  int64         `json:"storeId"`  // Ürünün bağlı olduğu mağazanın ID'si
+}
+
+// ToModel, UpdateProductRequest yapısını ProductUpdate modeline dönüştürür.
+func (updateProductRequest UpdateProductRequest) ToModel() model.ProductUpdate {
+	return model.ProductUpdate{
+		Name:     updateProductRequest.Name,
+		Price:    updateProductRequest.Price,
+		Currency: toCurrency(updateProductRequest.Currency),
+		Discount: updateProductRequest.Discount,
+		StoreId:  updateProductRequest.StoreId,
+	}
+}
+
+// PatchProductRequest, JSON merge-patch (RFC 7396) formatındaki kısmi güncelleme isteğidir.
+// Gönderilmeyen alanlar değiştirilmez; discount için null değeri indirimi kaldırır.
+type PatchProductRequest struct {
+	Name     *string
+	Price    *money.Decimal
+	Currency *money.Currency
+	Discount *money.Decimal
+	StoreId  *int64
+}
+
+// UnmarshalJSON, gönderilmeyen alanlarla null gönderilen alanları birbirinden ayırt eder.
+func (patchProductRequest *PatchProductRequest) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		return errors.New("Merge patch document must be a JSON object")
+	}
+	for name, value := range fields {
+		isNull := string(value) == "null"
+		switch name {
+		case "name":
+			if isNull {
+				return errors.New("Field name can not be null")
+			}
+			patchProductRequest.Name = new(string)
+			if err := json.Unmarshal(value, patchProductRequest.Name); err != nil {
+				return err
+			}
+		case "price":
+			if isNull {
+				return errors.New("Field price can not be null")
+			}
+			patchProductRequest.Price = new(money.Decimal)
+			if err := json.Unmarshal(value, patchProductRequest.Price); err != nil {
+				return err
+			}
+		case "currency":
+			if isNull {
+				return errors.New("Field currency can not be null")
+			}
+			var currency string
+			if err := json.Unmarshal(value, &currency); err != nil {
+				return err
+			}
+			patchProductRequest.Currency = new(money.Currency)
+			*patchProductRequest.Currency = toCurrency(currency)
+		case "discount":
+			patchProductRequest.Discount = new(money.Decimal)
+			if isNull {
+				continue
+			}
+			if err := json.Unmarshal(value, patchProductRequest.Discount); err != nil {
+				return err
+			}
+		case "storeId":
+			if isNull {
+				return errors.New("Field storeId can not be null")
+			}
+			patchProductRequest.StoreId = new(int64)
+			if err := json.Unmarshal(value, patchProductRequest.StoreId); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ToModel, PatchProductRequest yapısını ProductPatch modeline dönüştürür.
+func (patchProductRequest PatchProductRequest) ToModel() model.ProductPatch {
+	return model.ProductPatch{
+		Name:     patchProductRequest.Name,
+		Price:    patchProductRequest.Price,
+		Currency: patchProductRequest.Currency,
+		Discount: patchProductRequest.Discount,
+		StoreId:  patchProductRequest.StoreId,
+	}
+}
+
+// AddStoreRequest, bir mağaza ekleme isteği için kullanılan yapıdır.
+type AddStoreRequest struct {
+	Name string `json:"name"` // Mağazanın adı
+}
+
+// ToModel, AddStoreRequest yapısını StoreCreate modeline dönüştürür.
+func (addStoreRequest AddStoreRequest) ToModel() model.StoreCreate {
+	return model.StoreCreate{
+		Name: addStoreRequest.Name,
+	}
+}
+
+// UpdateStoreRequest, bir mağazanın adını değiştirme isteği için kullanılan yapıdır.
+type UpdateStoreRequest struct {
+	Name string `json:"name"` // Mağazanın yeni adı
+}
+
+// ToModel, UpdateStoreRequest yapısını StoreUpdate modeline dönüştürür.
+func (updateStoreRequest UpdateStoreRequest) ToModel() model.StoreUpdate {
+	return model.StoreUpdate{
+		Name: updateStoreRequest.Name,
+	}
+}
+
+// AddCategoryRequest, bir kategori ekleme isteği için kullanılan yapıdır.
+type AddCategoryRequest struct {
+	Name     string `json:"name"`     // Kategorinin adı
+	ParentId *int64 `json:"parentId"` // Üst kategorinin ID'si, kök kategoriler için boş
+}
+
+// ToModel, AddCategoryRequest yapısını CategoryCreate modeline dönüştürür.
+func (addCategoryRequest AddCategoryRequest) ToModel() model.CategoryCreate {
+	return model.CategoryCreate{
+		Name:     addCategoryRequest.Name,
+		ParentId: addCategoryRequest.ParentId,
+	}
+}
+
+// UpdateCategoryRequest, bir kategorinin adını ve üst kategorisini değiştirme isteğidir.
+type UpdateCategoryRequest struct {
+	Name     string `json:"name"`     // Kategorinin yeni adı
+	ParentId *int64 `json:"parentId"` // Yeni üst kategorinin ID'si, kök yapmak için boş
+}
+
+// ToModel, UpdateCategoryRequest yapısını CategoryUpdate modeline dönüştürür.
+func (updateCategoryRequest UpdateCategoryRequest) ToModel() model.CategoryUpdate {
+	return model.CategoryUpdate{
+		Name:     updateCategoryRequest.Name,
+		ParentId: updateCategoryRequest.ParentId,
+	}
+}
+
+// ProductCategoriesRequest, bir ürünün kategorilerini değiştirme isteğidir.
+type ProductCategoriesRequest struct {
+	CategoryIds []int64 `json:"categoryIds"` // Ürünün bağlanacağı kategorilerin ID'leri
+}
+
+// AddCampaignRequest, bir indirim kampanyası ekleme isteği için kullanılan yapıdır.
+// Zamanlar RFC 3339 biçiminde gönderilir, örneğin "2024-11-29T00:00:00+03:00".
+type AddCampaignRequest struct {
+	Name       string        `json:"name"`       // Kampanyanın adı
+	Discount   money.Decimal `json:"discount"`   // Kampanyanın indirim oranı
+	StartsAt   time.Time     `json:"startsAt"`   // Kampanyanın başladığı an, dahil
+	EndsAt     time.Time     `json:"endsAt"`     // Kampanyanın bittiği an, hariç
+	Priority   int           `json:"priority"`   // Aynı ürüne uyan kampanyalar arasındaki öncelik
+	ProductIds []int64       `json:"productIds"` // Hedef ürünlerin ID'leri
+	StoreIds   []int64       `json:"storeIds"`   // Tüm ürünleri hedeflenen mağazaların ID'leri
+}
+
+// ToModel, AddCampaignRequest yapısını CampaignCreate modeline dönüştürür.
+func (addCampaignRequest AddCampaignRequest) ToModel() model.CampaignCreate {
+	return model.CampaignCreate{
+		Name:       addCampaignRequest.Name,
+		Discount:   addCampaignRequest.Discount,
+		StartsAt:   addCampaignRequest.StartsAt,
+		EndsAt:     addCampaignRequest.EndsAt,
+		Priority:   addCampaignRequest.Priority,
+		ProductIds: addCampaignRequest.ProductIds,
+		StoreIds:   addCampaignRequest.StoreIds,
+	}
+}
+
+// UpdateCampaignRequest, bir kampanyanın tüm alanlarını ve hedeflerini değiştirme isteğidir.
+type UpdateCampaignRequest struct {
+	Name       string        `json:"name"`       // Kampanyanın yeni adı
+	Discount   money.Decimal `json:"discount"`   // Yeni indirim oranı
+	StartsAt   time.Time     `json:"startsAt"`   // Yeni başlangıç anı, dahil
+	EndsAt     time.Time     `json:"endsAt"`     // Yeni bitiş anı, hariç
+	Priority   int           `json:"priority"`   // Yeni öncelik
+	ProductIds []int64       `json:"productIds"` // Yeni hedef ürünlerin ID'leri
+	StoreIds   []int64       `json:"storeIds"`   // Yeni hedef mağazaların ID'leri
+}
+
+// ToModel, UpdateCampaignRequest yapısını CampaignUpdate modeline dönüştürür.
+func (updateCampaignRequest UpdateCampaignRequest) ToModel() model.CampaignUpdate {
+	return model.CampaignUpdate{
+		Name:       updateCampaignRequest.Name,
+		Discount:   updateCampaignRequest.Discount,
+		StartsAt:   updateCampaignRequest.StartsAt,
+		EndsAt:     updateCampaignRequest.EndsAt,
+		Priority:   updateCampaignRequest.Priority,
+		ProductIds: updateCampaignRequest.ProductIds,
+		StoreIds:   updateCampaignRequest.StoreIds,
+	}
+}
+
+// StockChangeRequest, stoktan miktar ayırma veya ayrılmış miktarı bırakma isteğidir.
+type StockChangeRequest struct {
+	StoreId  int64 `json:"storeId"`  // Stoğun tutulduğu mağazanın ID'si
+	Quantity int   `json:"quantity"` // Ayrılacak veya bırakılacak miktar
+}
+
+// ToModel, StockChangeRequest yapısını StockChange modeline dönüştürür.
+func (stockChangeRequest StockChangeRequest) ToModel() model.StockChange {
+	return model.StockChange{
+		StoreId:  stockChangeRequest.StoreId,
+		Quantity: stockChangeRequest.Quantity,
+	}
+}
+
+// StockAdjustmentRequest, toplam stok miktarını ve düşük stok eşiğini değiştirme isteğidir.
+type StockAdjustmentRequest struct {
+	StoreId           int64 `json:"storeId"`           // Stoğun tutulduğu mağazanın ID'si
+	Delta             int   `json:"delta"`             // Eklenecek (pozitif) veya düşülecek (negatif) miktar
+	LowStockThreshold *int  `json:"lowStockThreshold"` // Yeni düşük stok eşiği, boşsa değişmez
+}
+
+// ToModel, StockAdjustmentRequest yapısını StockAdjustment modeline dönüştürür.
+func (stockAdjustmentRequest StockAdjustmentRequest) ToModel() model.StockAdjustment {
+	return model.StockAdjustment{
+		StoreId:           stockAdjustmentRequest.StoreId,
+		Delta:             stockAdjustmentRequest.Delta,
+		LowStockThreshold: stockAdjustmentRequest.LowStockThreshold,
+	}
+}
+
+// ProductBatchRequest, tek transaction içinde uygulanacak toplu ürün işlemleri isteğidir.
+// Örnek: {"mode":"bestEffort","operations":[{"op":"create","product":{...}},{"op":"updatePrice","id":3,"newPrice":"99.90"},{"op":"delete","id":4}]}
+type ProductBatchRequest struct {
+	Mode       string                  `json:"mode"`       // atomic veya bestEffort, boşsa atomic
+	Operations []ProductBatchOperation `json:"operations"` // Sırayla uygulanacak işlemler
+}
+
+// ProductBatchOperation, toplu işlemdeki tek bir ekleme, fiyat güncelleme veya silme işlemidir.
+type ProductBatchOperation struct {
+	Op       string            `json:"op"`       // create, updatePrice veya delete
+	Id       int64             `json:"id"`       // Fiyatı güncellenecek veya silinecek ürünün ID'si
+	Product  AddProductRequest `json:"product"`  // Eklenecek ürün, yalnızca create için
+	NewPrice money.Decimal     `json:"newPrice"` // Ürünün yeni fiyatı, yalnızca updatePrice için
+}
+
+// ToModel, ProductBatchRequest yapısını ProductBatch modeline dönüştürür.
+func (productBatchRequest ProductBatchRequest) ToModel() model.ProductBatch {
+	operations := make([]model.ProductBatchOperation, 0, len(productBatchRequest.Operations))
+	for _, operation := range productBatchRequest.Operations {
+		operations = append(operations, model.ProductBatchOperation{
+			Type:      operation.Op,
+			ProductId: operation.Id,
+			Product:   operation.Product.ToModel(),
+			NewPrice:  operation.NewPrice,
+		})
+	}
+	return model.ProductBatch{
+		Mode:       productBatchRequest.Mode,
+		Operations: operations,
+	}
+}
+
+// PriceHistoryRequest, fiyat geçmişi isteğinin tarih aralığı parametrelerini taşır.
+// Tarihler RFC 3339 (2024-05-01T10:00:00Z) veya gün (2024-05-01) olarak verilebilir.
+// Gün olarak verilen "to" o günün sonuna kadar olan değişiklikleri kapsar.
+type PriceHistoryRequest struct {
+	From string `query:"from"` // Başlangıç anı, dahil
+	To   string `query:"to"`   // Bitiş anı, hariç
+}
+
+// ToQuery, tarih parametrelerini ayrıştırarak domain.PriceHistoryQuery yapısına dönüştürür.
+func (priceHistoryRequest PriceHistoryRequest) ToQuery() (domain.PriceHistoryQuery, error) {
+	var query domain.PriceHistoryQuery
+	var err error
+	if query.From, err = parseOptionalTime("from", priceHistoryRequest.From, false); err != nil {
+		return domain.PriceHistoryQuery{}, err
+	}
+	if query.To, err = parseOptionalTime("to", priceHistoryRequest.To, true); err != nil {
+		return domain.PriceHistoryQuery{}, err
+	}
+	return query, nil
+}
+
+// ProductListRequest, ürün listeleme isteğinin sorgu parametrelerini taşır.
+// Örnek: ?limit=20&sort=price,-name&minPrice=100&category=3
+// Bir mağazanın ürünleri /api/v1/stores/:id/products ile aynı parametrelerle listelenir.
+type ProductListRequest struct {
+	Limit       string `query:"limit"`       // Sayfa boyutu
+	Offset      string `query:"offset"`      // Atlanacak kayıt sayısı
+	Cursor      string `query:"cursor"`      // Bir önceki sayfanın döndürdüğü imleç
+	Sort        string `query:"sort"`        // Virgülle ayrılmış alanlar, azalan sıralama için "-" öneki
+	MinPrice    string `query:"minPrice"`    // En düşük fiyat
+	MaxPrice    string `query:"maxPrice"`    // En yüksek fiyat
+	MinDiscount string `query:"minDiscount"` // En düşük indirim oranı
+	Category    string `query:"category"`    // Alt kategorileriyle birlikte filtrelenecek kategori ID'si
+	InStock     string `query:"inStock"`     // true ise stoğu olan, false ise olmayan ürünler
+	Currency    string `query:"currency"`    // Fiyatların çevrileceği para birimi
+}
+
+// ToQuery, sorgu parametrelerini ayrıştırarak domain.ProductQuery yapısına dönüştürür.
+func (productListRequest ProductListRequest) ToQuery() (domain.ProductQuery, error) {
+	query := domain.ProductQuery{
+		Cursor: productListRequest.Cursor,
+	}
+	var err error
+	if len(productListRequest.Limit) > 0 {
+		query.Limit, err = strconv.Atoi(productListRequest.Limit)
+		if err != nil || query.Limit < 1 {
+			return domain.ProductQuery{}, errors.New("Parameter limit must be a positive integer")
+		}
+	}
+	if len(productListRequest.Offset) > 0 {
+		query.Offset, err = strconv.Atoi(productListRequest.Offset)
+		if err != nil {
+			return domain.ProductQuery{}, errors.New("Parameter offset must be an integer")
+		}
+	}
+	if len(productListRequest.Category) > 0 {
+		query.CategoryId, err = strconv.ParseInt(productListRequest.Category, 10, 64)
+		if err != nil || query.CategoryId < 1 {
+			return domain.ProductQuery{}, errors.New("Parameter category must be a positive integer")
+		}
+	}
+	if len(productListRequest.InStock) > 0 {
+		inStock, parseErr := strconv.ParseBool(productListRequest.InStock)
+		if parseErr != nil {
+			return domain.ProductQuery{}, errors.New("Parameter inStock must be true or false")
+		}
+		query.InStock = &inStock
+	}
+	if query.MinPrice, err = parseOptionalDecimal("minPrice", productListRequest.MinPrice); err != nil {
+		return domain.ProductQuery{}, err
+	}
+	if query.MaxPrice, err = parseOptionalDecimal("maxPrice", productListRequest.MaxPrice); err != nil {
+		return domain.ProductQuery{}, err
+	}
+	if query.MinDiscount, err = parseOptionalDecimal("minDiscount", productListRequest.MinDiscount); err != nil {
+		return domain.ProductQuery{}, err
+	}
+	if query.Currency, err = ParseOptionalCurrency(productListRequest.Currency); err != nil {
+		return domain.ProductQuery{}, err
+	}
+	for _, field := range strings.Split(productListRequest.Sort, ",") {
+		field = strings.TrimSpace(field)
+		if len(field) == 0 {
+			continue
+		}
+		sortField := domain.SortField{Field: field}
+		if strings.HasPrefix(field, "-") {
+			sortField = domain.SortField{Field: field[1:], Descending: true}
+		}
+		query.Sort = append(query.Sort, sortField)
+	}
+	return query, nil
+}
+
+// ProductExportRequest, ürün kataloğunu dışa aktarma isteğinin sorgu parametrelerini taşır.
+// Listeleme filtreleri ve sıralaması aynen geçerlidir; sayfalama parametreleri dikkate alınmaz.
+// Örnek: ?format=xlsx&store=1&minPrice=100
+type ProductExportRequest struct {
+	ProductListRequest
+	Format string `query:"format"` // csv, ndjson veya xlsx; boşsa csv
+	Store  string `query:"store"`  // Yalnızca ürünleri dışa aktarılacak mağazanın ID'si
+}
+
+// ToQuery, listeleme parametrelerine ek olarak mağaza filtresini ayrıştırır.
+func (productExportRequest ProductExportRequest) ToQuery() (domain.ProductQuery, error) {
+	query, err := productExportRequest.ProductListRequest.ToQuery()
+	if err != nil {
+		return domain.ProductQuery{}, err
+	}
+	if len(productExportRequest.Store) > 0 {
+		query.StoreId, err = strconv.ParseInt(productExportRequest.Store, 10, 64)
+		if err != nil || query.StoreId < 1 {
+			return domain.ProductQuery{}, errors.New("Parameter store must be a positive integer")
+		}
+	}
+	return query, nil
+}
+
+// ParseOptionalCurrency, boş olmayan "currency" sorgu parametresini para birimine çevirir.
+func ParseOptionalCurrency(value string) (money.Currency, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+	currency, err := money.ParseCurrency(value)
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("Parameter currency must be one of %s", money.SupportedCurrencyCodes()))
+	}
+	return currency, nil
+}
+
+// toCurrency, istekteki para birimi kodunu büyük harfe çevirir; doğrulama servis katmanında yapılır.
+func toCurrency(code string) money.Currency {
+	return money.Currency(strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// parseOptionalTime, boş olmayan bir tarih parametresini ayrıştırır.
+// Yalnızca gün verilmişse ve endOfDay true ise ertesi günün başlangıcı döner.
+func parseOptionalTime(name string, value string, endOfDay bool) (*time.Time, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Parameter %s must be a date (2006-01-02) or an RFC 3339 time", name))
+	}
+	if endOfDay {
+		parsed = parsed.AddDate(0, 0, 1)
+	}
+	return &parsed, nil
+}
+
+// parseOptionalDecimal, boş olmayan bir sorgu parametresini ondalık sayıya çevirir.
+func parseOptionalDecimal(name string, value string) (*money.Decimal, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+	parsed, err := money.Parse(value)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Parameter %s must be a number", name))
+	}
+	return &parsed, nil
+}
@@ -1,27 +1,81 @@
 package response
 
-import "product-app/domain"
+import (
+	"product-app/common/money"
+	"product-app/domain"
+	"time"
+)
+
+// Hata yanıtlarında dönen, istemcilerin programatik olarak kontrol edebileceği hata kodları.
+const (
+	ErrorCodeBadRequest           = "BAD_REQUEST"
+	ErrorCodeNotFound             = "NOT_FOUND"
+	ErrorCodeValidationFailed     = "VALIDATION_FAILED"
+	ErrorCodeConflict             = "CONFLICT"
+	ErrorCodePreconditionFailed   = "PRECONDITION_FAILED"
+	ErrorCodePreconditionRequired = "PRECONDITION_REQUIRED"
+	ErrorCodeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	ErrorCodeInternalError        = "INTERNAL_ERROR"
+	ErrorCodeClientClosedRequest  = "CLIENT_CLOSED_REQUEST"
+)
 
 // ErrorResponse struct, hata mesajlarının dönmesi için kullanılır.
 type ErrorResponse struct {
-	ErrorDescription string `json:"errorDescription"` // Hata açıklamasını tutar
+	ErrorCode        string               `json:"errorCode"`             // Hatanın türünü belirten sabit kod
+	ErrorDescription string               `json:"errorDescription"`      // Hata açıklamasını tutar
+	FieldErrors      []FieldErrorResponse `json:"fieldErrors,omitempty"` // Doğrulama hatalarında alan bazlı hatalar
+}
+
+// FieldErrorResponse struct, tek bir alana ait doğrulama hatasını dışa aktarır.
+type FieldErrorResponse struct {
+	Field   string `json:"field"`   // Hatalı alanın JSON adı
+	Message string `json:"message"` // Hatanın açıklaması
+}
+
+// ToFieldErrorResponseList fonksiyonu, domain.FieldError listesini FieldErrorResponse listesine dönüştürür.
+func ToFieldErrorResponseList(fieldErrors []domain.FieldError) []FieldErrorResponse {
+	var fieldErrorResponseList = []FieldErrorResponse{}
+	for _, fieldError := range fieldErrors {
+		fieldErrorResponseList = append(fieldErrorResponseList, FieldErrorResponse{
+			Field:   fieldError.Field,
+			Message: fieldError.Message,
+		})
+	}
+	return fieldErrorResponseList
 }
 
 // ProductResponse struct, ürün verilerini dışa aktarmak için kullanılır.
+// Hassasiyet kaybı olmaması için tutarlar ve indirim JSON metni olarak döner.
 type ProductResponse struct {
-	Name     string  `json:"name"`     // Ürünün ismi
-	Price    float32 `json:"price"`    // Ürünün fiyatı
-	Discount float32 `json:"discount"` // Ürüne uygulanmış indirim oranı
-	Store    string  `json:"store"`    // Ürünün satıldığı mağaza adı
+	Id             int64         `json:"id"`                  // Ürünün ID'si
+	Name           string        `json:"name"`                // Ürünün ismi
+	Price          string        `json:"price"`               // Ürünün kuruşa yuvarlanmış liste fiyatı, örneğin "1999.90"
+	Currency       string        `json:"currency"`            // Tutarların para birimi
+	Discount       money.Decimal `json:"discount"`            // Ürüne uygulanan, kampanyalar dahil geçerli indirim oranı
+	DiscountAmount string        `json:"discountAmount"`      // Liste fiyatından düşülen indirim tutarı
+	FinalPrice     string        `json:"finalPrice"`          // İndirim uygulandıktan sonraki fiyat
+	StoreId        int64         `json:"storeId"`             // Ürünün satıldığı mağazanın ID'si
+	Store          string        `json:"store"`               // Ürünün satıldığı mağaza adı
+	CampaignId     *int64        `json:"campaignId"`          // İndirimi belirleyen kampanyanın ID'si, yoksa null
+	DeletedAt      *time.Time    `json:"deletedAt,omitempty"` // Ürünün çöp kutusuna taşındığı zaman; yalnızca çöp kutusu listesinde bulunur
 }
 
 // ToResponse fonksiyonu, domain.Product tipindeki bir ürünü ProductResponse'a dönüştürür.
+// Fiyat, indirim tutarı ve son fiyat servis katmanının doldurduğu product.Pricing'den alınır.
 func ToResponse(product domain.Product) ProductResponse {
+	priceBreakdown := product.Pricing
 	return ProductResponse{
-		Name:     product.Name,
-		Price:    product.Price,
-		Discount: product.Discount,
-		Store:    product.Store,
+		Id:             product.Id,
+		Name:           product.Name,
+		Price:          priceBreakdown.Price.Amount.StringFixed(money.Places),
+		Currency:       string(priceBreakdown.Price.Currency),
+		Discount:       product.Discount,
+		DiscountAmount: priceBreakdown.DiscountAmount.Amount.StringFixed(money.Places),
+		FinalPrice:     priceBreakdown.FinalPrice.Amount.StringFixed(money.Places),
+		StoreId:        product.StoreId,
+		Store:          product.Store,
+		CampaignId:     product.CampaignId,
+		DeletedAt:      product.DeletedAt,
 	}
 }
 
@@ -33,3 +87,266 @@ func ToResponseList(products []domain.Product) []ProductResponse {
 	}
 	return productResponseList // Dönüştürülmüş ürün listesi geri döndürülür
 }
+
+// ProductListResponse struct, sayfalanmış ürün listesini dışa aktarmak için kullanılır.
+type ProductListResponse struct {
+	Items      []ProductResponse `json:"items"`                // Sayfadaki ürünler
+	TotalCount int64             `json:"totalCount"`           // Filtrelere uyan toplam ürün sayısı
+	NextCursor string            `json:"nextCursor,omitempty"` // Bir sonraki sayfa için imleç, son sayfada boştur
+}
+
+// ToListResponse fonksiyonu, domain.ProductPage yapısını ProductListResponse'a dönüştürür.
+func ToListResponse(page domain.ProductPage) ProductListResponse {
+	return ProductListResponse{
+		Items:      ToResponseList(page.Products),
+		TotalCount: page.TotalCount,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// StoreResponse struct, mağaza verilerini dışa aktarmak için kullanılır.
+type StoreResponse struct {
+	Id   int64  `json:"id"`   // Mağazanın ID'si
+	Name string `json:"name"` // Mağazanın adı
+}
+
+// ToStoreResponse fonksiyonu, domain.Store tipindeki bir mağazayı StoreResponse'a dönüştürür.
+func ToStoreResponse(store domain.Store) StoreResponse {
+	return StoreResponse{
+		Id:   store.Id,
+		Name: store.Name,
+	}
+}
+
+// ToStoreResponseList fonksiyonu, domain.Store listesini StoreResponse listesine dönüştürür.
+func ToStoreResponseList(stores []domain.Store) []StoreResponse {
+	var storeResponseList = []StoreResponse{}
+	for _, store := range stores {
+		storeResponseList = append(storeResponseList, ToStoreResponse(store))
+	}
+	return storeResponseList
+}
+
+// CategoryResponse struct, tek bir kategoriyi dışa aktarmak için kullanılır.
+type CategoryResponse struct {
+	Id       int64  `json:"id"`       // Kategorinin ID'si
+	Name     string `json:"name"`     // Kategorinin adı
+	ParentId *int64 `json:"parentId"` // Üst kategorinin ID'si, kök kategorilerde null
+}
+
+// ToCategoryResponse fonksiyonu, domain.Category tipindeki bir kategoriyi CategoryResponse'a dönüştürür.
+func ToCategoryResponse(category domain.Category) CategoryResponse {
+	return CategoryResponse{
+		Id:       category.Id,
+		Name:     category.Name,
+		ParentId: category.ParentId,
+	}
+}
+
+// ToCategoryResponseList fonksiyonu, domain.Category listesini CategoryResponse listesine dönüştürür.
+func ToCategoryResponseList(categories []domain.Category) []CategoryResponse {
+	var categoryResponseList = []CategoryResponse{}
+	for _, category := range categories {
+		categoryResponseList = append(categoryResponseList, ToCategoryResponse(category))
+	}
+	return categoryResponseList
+}
+
+// CategoryTreeResponse struct, kategori ağacındaki bir düğümü alt kategorileriyle birlikte dışa aktarır.
+type CategoryTreeResponse struct {
+	Id       int64                  `json:"id"`       // Kategorinin ID'si
+	Name     string                 `json:"name"`     // Kategorinin adı
+	Children []CategoryTreeResponse `json:"children"` // Alt kategoriler, yoksa boş liste
+}
+
+// ToCategoryTreeResponse fonksiyonu, kategori ağacını CategoryTreeResponse listesine dönüştürür.
+func ToCategoryTreeResponse(nodes []domain.CategoryNode) []CategoryTreeResponse {
+	var categoryTreeResponse = []CategoryTreeResponse{}
+	for _, node := range nodes {
+		categoryTreeResponse = append(categoryTreeResponse, CategoryTreeResponse{
+			Id:       node.Category.Id,
+			Name:     node.Category.Name,
+			Children: ToCategoryTreeResponse(node.Children),
+		})
+	}
+	return categoryTreeResponse
+}
+
+// StockResponse struct, bir ürünün bir mağazadaki stok durumunu dışa aktarmak için kullanılır.
+type StockResponse struct {
+	ProductId         int64 `json:"productId"`         // Ürünün ID'si
+	StoreId           int64 `json:"storeId"`           // Mağazanın ID'si
+	Quantity          int   `json:"quantity"`          // Toplam stok miktarı
+	Reserved          int   `json:"reserved"`          // Ayrılmış miktar
+	Available         int   `json:"available"`         // Satılabilir miktar
+	LowStockThreshold int   `json:"lowStockThreshold"` // Düşük stok eşiği
+	LowStock          bool  `json:"lowStock"`          // Satılabilir miktar eşiğe inmişse true
+}
+
+// ToStockResponse fonksiyonu, domain.Stock tipindeki bir stoğu StockResponse'a dönüştürür.
+func ToStockResponse(stock domain.Stock) StockResponse {
+	return StockResponse{
+		ProductId:         stock.ProductId,
+		StoreId:           stock.StoreId,
+		Quantity:          stock.Quantity,
+		Reserved:          stock.Reserved,
+		Available:         stock.Available(),
+		LowStockThreshold: stock.LowStockThreshold,
+		LowStock:          stock.IsLow(),
+	}
+}
+
+// ToStockResponseList fonksiyonu, domain.Stock listesini StockResponse listesine dönüştürür.
+func ToStockResponseList(stocks []domain.Stock) []StockResponse {
+	var stockResponseList = []StockResponse{}
+	for _, stock := range stocks {
+		stockResponseList = append(stockResponseList, ToStockResponse(stock))
+	}
+	return stockResponseList
+}
+
+// PriceChangeResponse struct, fiyat geçmişindeki tek bir değişikliği dışa aktarmak için kullanılır.
+type PriceChangeResponse struct {
+	Id          int64     `json:"id"`          // Kaydın ID'si
+	OldPrice    string    `json:"oldPrice"`    // Değişiklikten önceki fiyat
+	OldCurrency string    `json:"oldCurrency"` // Eski fiyatın para birimi
+	NewPrice    string    `json:"newPrice"`    // Değişiklikten sonraki fiyat
+	NewCurrency string    `json:"newCurrency"` // Yeni fiyatın para birimi
+	ChangedAt   time.Time `json:"changedAt"`   // Değişikliğin yapıldığı an
+	Actor       string    `json:"actor"`       // Değişikliği yapan kişi
+}
+
+// ToPriceHistoryResponse fonksiyonu, fiyat değişikliklerini PriceChangeResponse listesine dönüştürür.
+func ToPriceHistoryResponse(priceChanges []domain.PriceChange) []PriceChangeResponse {
+	var priceHistoryResponse = []PriceChangeResponse{}
+	for _, priceChange := range priceChanges {
+		priceHistoryResponse = append(priceHistoryResponse, PriceChangeResponse{
+			Id:          priceChange.Id,
+			OldPrice:    priceChange.OldPrice.Amount.StringFixed(money.Places),
+			OldCurrency: string(priceChange.OldPrice.Currency),
+			NewPrice:    priceChange.NewPrice.Amount.StringFixed(money.Places),
+			NewCurrency: string(priceChange.NewPrice.Currency),
+			ChangedAt:   priceChange.ChangedAt,
+			Actor:       priceChange.Actor,
+		})
+	}
+	return priceHistoryResponse
+}
+
+// CampaignResponse struct, bir indirim kampanyasını hedefleriyle birlikte dışa aktarmak için kullanılır.
+type CampaignResponse struct {
+	Id         int64         `json:"id"`         // Kampanyanın ID'si
+	Name       string        `json:"name"`       // Kampanyanın adı
+	Discount   money.Decimal `json:"discount"`   // Kampanyanın indirim oranı
+	StartsAt   time.Time     `json:"startsAt"`   // Kampanyanın başladığı an, dahil
+	EndsAt     time.Time     `json:"endsAt"`     // Kampanyanın bittiği an, hariç
+	Priority   int           `json:"priority"`   // Aynı ürüne uyan kampanyalar arasındaki öncelik
+	ProductIds []int64       `json:"productIds"` // Hedef ürünlerin ID'leri
+	StoreIds   []int64       `json:"storeIds"`   // Hedef mağazaların ID'leri
+	Active     bool          `json:"active"`     // Kampanya şu anda geçerliyse true
+}
+
+// ToCampaignResponse fonksiyonu, domain.Campaign tipindeki bir kampanyayı CampaignResponse'a dönüştürür.
+func ToCampaignResponse(campaign domain.Campaign) CampaignResponse {
+	return CampaignResponse{
+		Id:         campaign.Id,
+		Name:       campaign.Name,
+		Discount:   campaign.Discount,
+		StartsAt:   campaign.StartsAt,
+		EndsAt:     campaign.EndsAt,
+		Priority:   campaign.Priority,
+		ProductIds: nonNilIds(campaign.ProductIds),
+		StoreIds:   nonNilIds(campaign.StoreIds),
+		Active:     campaign.IsActiveAt(time.Now()),
+	}
+}
+
+// ToCampaignResponseList fonksiyonu, domain.Campaign listesini CampaignResponse listesine dönüştürür.
+func ToCampaignResponseList(campaigns []domain.Campaign) []CampaignResponse {
+	var campaignResponseList = []CampaignResponse{}
+	for _, campaign := range campaigns {
+		campaignResponseList = append(campaignResponseList, ToCampaignResponse(campaign))
+	}
+	return campaignResponseList
+}
+
+// nonNilIds, boş ID listelerinin JSON'da null yerine [] olarak dönmesini sağlar.
+func nonNilIds(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
+
+// ProductImportResponse struct, toplu ürün içe aktarma raporunu dışa aktarmak için kullanılır.
+type ProductImportResponse struct {
+	DryRun       bool                     `json:"dryRun"`       // true ise yalnızca doğrulama yapılmıştır
+	TotalRows    int                      `json:"totalRows"`    // Dosyadaki ürün satırı sayısı
+	ImportedRows int                      `json:"importedRows"` // Eklenen ürün sayısı
+	Errors       []ImportRowErrorResponse `json:"errors"`       // Hatalı satırlar, yoksa boş liste
+}
+
+// ImportRowErrorResponse struct, içe aktarılan dosyadaki hatalı bir satırı dışa aktarır.
+type ImportRowErrorResponse struct {
+	Line        int                  `json:"line"`        // Satırın dosyadaki numarası
+	FieldErrors []FieldErrorResponse `json:"fieldErrors"` // Satırdaki hatalı alanlar
+}
+
+// ToProductImportResponse fonksiyonu, domain.ProductImportReport'u ProductImportResponse'a dönüştürür.
+func ToProductImportResponse(report domain.ProductImportReport) ProductImportResponse {
+	var rowErrors = []ImportRowErrorResponse{}
+	for _, rowError := range report.RowErrors {
+		rowErrors = append(rowErrors, ImportRowErrorResponse{
+			Line:        rowError.Line,
+			FieldErrors: ToFieldErrorResponseList(rowError.FieldErrors),
+		})
+	}
+	return ProductImportResponse{
+		DryRun:       report.DryRun,
+		TotalRows:    report.TotalRows,
+		ImportedRows: report.ImportedRows,
+		Errors:       rowErrors,
+	}
+}
+
+// ProductBatchResponse struct, toplu ürün işlemlerinin sonuçlarını dışa aktarmak için kullanılır.
+type ProductBatchResponse struct {
+	Mode       string                           `json:"mode"`       // İşlemlerin uygulandığı mod
+	Succeeded  int                              `json:"succeeded"`  // Kalıcı olan işlem sayısı
+	Failed     int                              `json:"failed"`     // Başarısız olan işlem sayısı
+	RolledBack int                              `json:"rolledBack"` // Atomik modda geri alınan işlem sayısı
+	Results    []ProductOperationResultResponse `json:"results"`    // İşlemlerin istekteki sırayla sonuçları
+}
+
+// ProductOperationResultResponse struct, toplu işlemdeki tek bir işlemin sonucunu dışa aktarır.
+type ProductOperationResultResponse struct {
+	Index  int            `json:"index"`           // İşlemin istekteki sırası, 0'dan başlar
+	Status string         `json:"status"`          // succeeded, failed veya rolledBack
+	Id     *int64         `json:"id,omitempty"`    // Eklenen veya işlem yapılan ürünün ID'si
+	Error  *ErrorResponse `json:"error,omitempty"` // İşlem başarısız olduysa hata ayrıntısı
+}
+
+// ToProductBatchResponse fonksiyonu, domain.ProductBatchResult'u ProductBatchResponse'a dönüştürür.
+// Başarısız işlemlerin hataları toErrorResponse ile hata yanıtlarındaki biçime çevrilir.
+func ToProductBatchResponse(batchResult domain.ProductBatchResult, toErrorResponse func(err error) ErrorResponse) ProductBatchResponse {
+	var results = []ProductOperationResultResponse{}
+	for index, result := range batchResult.Results {
+		resultResponse := ProductOperationResultResponse{Index: index, Status: result.Status}
+		if result.ProductId > 0 {
+			productId := result.ProductId
+			resultResponse.Id = &productId
+		}
+		if result.Err != nil {
+			errorResponse := toErrorResponse(result.Err)
+			resultResponse.Error = &errorResponse
+		}
+		results = append(results, resultResponse)
+	}
+	return ProductBatchResponse{
+		Mode:       batchResult.Mode,
+		Succeeded:  batchResult.Count(domain.BatchStatusSucceeded),
+		Failed:     batchResult.Count(domain.BatchStatusFailed),
+		RolledBack: batchResult.Count(domain.BatchStatusRolledBack),
+		Results:    results,
+	}
+}
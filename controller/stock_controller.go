@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"product-app/controller/request"
+	"product-app/controller/response"
+	"product-app/service"
+)
+
+// StockController, ürün stoklarıyla ilgili işlemleri yöneten bir kontrolcü yapısıdır.
+type StockController struct {
+	stockService service.IStockService
+}
+
+// NewStockController, yeni bir StockController nesnesi oluşturur ve döndürür.
+func NewStockController(stockService service.IStockService) *StockController {
+	return &StockController{
+		stockService: stockService,
+	}
+}
+
+// RegisterRoutes, stokla ilgili API uç noktalarını Echo framework'e kaydeder.
+func (stockController *StockController) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/v1/products/:id/stock", stockController.GetStocksOfProduct)    // Ürünün mağaza bazındaki stoklarını listeler.
+	e.POST("/api/v1/products/:id/stock/reserve", stockController.ReserveStock) // Stoktan miktar ayırır.
+	e.POST("/api/v1/products/:id/stock/release", stockController.ReleaseStock) // Ayrılmış miktarı geri bırakır.
+	e.POST("/api/v1/products/:id/stock/adjust", stockController.AdjustStock)   // Toplam stok miktarını ve eşiği değiştirir.
+	e.GET("/api/v1/stock/low", stockController.GetLowStocks)                   // Düşük stok eşiğine inmiş stokları listeler.
+}
+
+// GetStocksOfProduct, bir ürünün tüm mağazalardaki stoklarını getirir.
+func (stockController *StockController) GetStocksOfProduct(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	stocks, err := stockController.stockService.GetStocksOfProduct(c.Request().Context(), productId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStockResponseList(stocks))
+}
+
+// GetLowStocks, satılabilir miktarı düşük stok eşiğine inmiş stokları getirir.
+func (stockController *StockController) GetLowStocks(c echo.Context) error {
+	stocks, err := stockController.stockService.GetLowStocks(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStockResponseList(stocks))
+}
+
+// ReserveStock, bir mağazadaki stoktan miktar ayırır. Yeterli stok yoksa 409 döner.
+func (stockController *StockController) ReserveStock(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	var stockChangeRequest request.StockChangeRequest
+	if err := c.Bind(&stockChangeRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	stock, err := stockController.stockService.Reserve(c.Request().Context(), productId, stockChangeRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStockResponse(stock))
+}
+
+// ReleaseStock, daha önce ayrılmış miktarı geri bırakır.
+func (stockController *StockController) ReleaseStock(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	var stockChangeRequest request.StockChangeRequest
+	if err := c.Bind(&stockChangeRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	stock, err := stockController.stockService.Release(c.Request().Context(), productId, stockChangeRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStockResponse(stock))
+}
+
+// AdjustStock, bir mağazadaki toplam stok miktarını ve düşük stok eşiğini değiştirir.
+func (stockController *StockController) AdjustStock(c echo.Context) error {
+	productId, err := parseProductId(c)
+	if err != nil {
+		return err
+	}
+	var stockAdjustmentRequest request.StockAdjustmentRequest
+	if err := c.Bind(&stockAdjustmentRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	stock, err := stockController.stockService.Adjust(c.Request().Context(), productId, stockAdjustmentRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStockResponse(stock))
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"product-app/controller/request"
+	"product-app/controller/response"
+	"product-app/service"
+	"strconv"
+)
+
+// StoreController, mağazalarla ilgili işlemleri yöneten bir kontrolcü yapısıdır.
+type StoreController struct {
+	storeService service.IStoreService
+}
+
+// NewStoreController, yeni bir StoreController nesnesi oluşturur ve döndürür.
+func NewStoreController(storeService service.IStoreService) *StoreController {
+	return &StoreController{
+		storeService: storeService,
+	}
+}
+
+// RegisterRoutes, mağazayla ilgili API uç noktalarını Echo framework'e kaydeder.
+func (storeController *StoreController) RegisterRoutes(e *echo.Echo) {
+	e.GET("/api/v1/stores/:id", storeController.GetStoreById)       // Belirli bir mağazayı ID ile getirir.
+	e.GET("/api/v1/stores", storeController.GetAllStores)           // Tüm mağazaları listeler.
+	e.POST("/api/v1/stores", storeController.AddStore)              // Yeni bir mağaza ekler.
+	e.PUT("/api/v1/stores/:id", storeController.UpdateStore)        // Belirli bir mağazanın adını değiştirir.
+	e.DELETE("/api/v1/stores/:id", storeController.DeleteStoreById) // Ürünü olmayan bir mağazayı siler.
+}
+
+// GetStoreById, ID'ye göre bir mağazayı getirir.
+func (storeController *StoreController) GetStoreById(c echo.Context) error {
+	storeId, err := parseStoreId(c)
+	if err != nil {
+		return err
+	}
+	// Mağaza bulunamazsa hata işleyici 404 döner.
+	store, err := storeController.storeService.GetById(c.Request().Context(), storeId)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStoreResponse(store))
+}
+
+// GetAllStores, tüm mağazaları ada göre sıralı olarak getirir.
+func (storeController *StoreController) GetAllStores(c echo.Context) error {
+	stores, err := storeController.storeService.GetAllStores(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, response.ToStoreResponseList(stores))
+}
+
+// AddStore, yeni bir mağaza ekler ve eklenen mağazayı döner.
+func (storeController *StoreController) AddStore(c echo.Context) error {
+	var addStoreRequest request.AddStoreRequest
+	if err := c.Bind(&addStoreRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	// Doğrulama hatasında 422, aynı adda mağaza varsa 409 döner.
+	store, err := storeController.storeService.Add(c.Request().Context(), addStoreRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, response.ToStoreResponse(store))
+}
+
+// UpdateStore, bir mağazanın adını istekte gönderilen değerle değiştirir.
+func (storeController *StoreController) UpdateStore(c echo.Context) error {
+	storeId, err := parseStoreId(c)
+	if err != nil {
+		return err
+	}
+	var updateStoreRequest request.UpdateStoreRequest
+	if err := c.Bind(&updateStoreRequest); err != nil { // Gelen isteği modele bağlar.
+		return err
+	}
+	err = storeController.storeService.Update(c.Request().Context(), storeId, updateStoreRequest.ToModel())
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı güncelleme durumunda 200 döner.
+}
+
+// DeleteStoreById, ID'ye göre bir mağazayı siler. Mağazaya bağlı ürün varsa 409 döner.
+func (storeController *StoreController) DeleteStoreById(c echo.Context) error {
+	storeId, err := parseStoreId(c)
+	if err != nil {
+		return err
+	}
+	err = storeController.storeService.DeleteById(c.Request().Context(), storeId)
+	if err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusOK) // Başarılı silme durumunda 200 döner.
+}
+
+// parseStoreId, yol parametresindeki mağaza ID'sini ayrıştırır; geçersizse 400 hatası döner.
+func parseStoreId(c echo.Context) (int64, error) {
+	storeId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || storeId < 1 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Store id must be a positive integer")
+	}
+	return storeId, nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// AnonymousActor, isteği yapan kişi bilinmediğinde kullanılan aktördür.
+const AnonymousActor = "anonymous"
+
+// actorKey, aktörün bağlamda saklandığı anahtardır.
+type actorKey struct{}
+
+// WithActor, işlemi yapan kişiyi bağlama ekler. Denetim kayıtları aktörü bu bağlamdan okur.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext, bağlamdaki aktörü döner; aktör yoksa AnonymousActor döner.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && len(actor) > 0 {
+		return actor
+	}
+	return AnonymousActor
+}
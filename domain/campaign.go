@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"product-app/common/money"
+	"time"
+)
+
+// Campaign, belirli bir zaman aralığında hedef ürünlere veya mağazaların tüm ürünlerine
+// uygulanan indirim kampanyasıdır.
+type Campaign struct {
+	Id         int64
+	Name       string
+	Discount   money.Decimal // Kampanyanın yüzde indirim oranı.
+	StartsAt   time.Time     // Kampanyanın başladığı an, dahil.
+	EndsAt     time.Time     // Kampanyanın bittiği an, hariç.
+	Priority   int           // Aynı ürüne uyan kampanyalardan önceliği yüksek olan uygulanır.
+	ProductIds []int64       // Kampanyanın doğrudan uygulandığı ürünler.
+	StoreIds   []int64       // Tüm ürünlerine kampanyanın uygulandığı mağazalar.
+}
+
+// IsActiveAt, kampanyanın verilen anda geçerli olup olmadığını döner.
+func (campaign Campaign) IsActiveAt(at time.Time) bool {
+	return !at.Before(campaign.StartsAt) && at.Before(campaign.EndsAt)
+}
+
+// AppliesTo, kampanyanın ürünü doğrudan veya mağazası üzerinden hedefleyip hedeflemediğini döner.
+func (campaign Campaign) AppliesTo(product Product) bool {
+	for _, productId := range campaign.ProductIds {
+		if productId == product.Id {
+			return true
+		}
+	}
+	for _, storeId := range campaign.StoreIds {
+		if storeId == product.StoreId {
+			return true
+		}
+	}
+	return false
+}
+
+// Outranks, aynı ürüne uyan iki kampanyadan hangisinin uygulanacağını belirler:
+// önce öncelik, eşitse indirim oranı büyük olan, o da eşitse önce oluşturulan kampanya seçilir.
+func (campaign Campaign) Outranks(other Campaign) bool {
+	if campaign.Priority != other.Priority {
+		return campaign.Priority > other.Priority
+	}
+	if discountCmp := campaign.Discount.Cmp(other.Discount); discountCmp != 0 {
+		return discountCmp > 0
+	}
+	return campaign.Id < other.Id
+}
@@ -0,0 +1,15 @@
+package domain
+
+// Category, ürünleri sınıflandırmak için kullanılan ağaç yapısındaki kategoridir.
+// ParentId nil ise kategori köktür; örneğin "Ütüler" kategorisinin üstü "Küçük Ev Aletleri" olabilir.
+type Category struct {
+	Id       int64
+	Name     string
+	ParentId *int64
+}
+
+// CategoryNode, kategori ağacındaki bir kategoriyi alt kategorileriyle birlikte taşır.
+type CategoryNode struct {
+	Category Category
+	Children []CategoryNode
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Uygulama genelinde kullanılan hata türleri. Katmanlar bu hataları doğrudan döndürmek yerine
+// NewXxxError fonksiyonlarıyla mesaj ekleyerek sarmalar; kontrol errors.Is ile yapılır.
+var (
+	ErrNotFound           = errors.New("not found")           // İstenen kayıt bulunamadı.
+	ErrValidation         = errors.New("validation failed")   // Girdi iş kurallarına uymuyor.
+	ErrConflict           = errors.New("conflict")            // İşlem mevcut bir kayıtla çakışıyor.
+	ErrUnavailable        = errors.New("service unavailable") // Veritabanı gibi bir bağımlılığa şu an ulaşılamıyor.
+	ErrPreconditionFailed = errors.New("precondition failed") // Kayıt, istemcinin beklediği sürümde değil.
+)
+
+// Error, bir hata türünü kullanıcıya gösterilecek mesaj ve varsa asıl nedenle birlikte taşır.
+// Error() yalnızca mesajı döner; errors.Is hem türle hem de nedenle eşleşir.
+type Error struct {
+	Kind    error
+	Message string
+	Cause   error
+}
+
+func (domainError *Error) Error() string {
+	return domainError.Message
+}
+
+// Unwrap, errors.Is ve errors.As için hata türünü ve varsa asıl nedeni döner.
+func (domainError *Error) Unwrap() []error {
+	if domainError.Cause == nil {
+		return []error{domainError.Kind}
+	}
+	return []error{domainError.Kind, domainError.Cause}
+}
+
+// NewNotFoundError, ErrNotFound türünde bir hata oluşturur.
+func NewNotFoundError(message string) error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+// NewValidationError, ErrValidation türünde bir hata oluşturur.
+func NewValidationError(message string) error {
+	return &Error{Kind: ErrValidation, Message: message}
+}
+
+// NewConflictError, ErrConflict türünde bir hata oluşturur.
+func NewConflictError(message string, cause error) error {
+	return &Error{Kind: ErrConflict, Message: message, Cause: cause}
+}
+
+// NewUnavailableError, ErrUnavailable türünde bir hata oluşturur.
+func NewUnavailableError(message string, cause error) error {
+	return &Error{Kind: ErrUnavailable, Message: message, Cause: cause}
+}
+
+// NewPreconditionFailedError, ErrPreconditionFailed türünde bir hata oluşturur.
+func NewPreconditionFailedError(message string) error {
+	return &Error{Kind: ErrPreconditionFailed, Message: message}
+}
+
+// FieldError, tek bir alana ait doğrulama hatasını tanımlar.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError, bir girdide bulunan tüm alan hatalarını birlikte taşır.
+// errors.Is(err, ErrValidation) ile eşleşir.
+type ValidationError struct {
+	FieldErrors []FieldError
+}
+
+// Error, alan hatalarının mesajlarını "; " ile birleştirerek döner.
+func (validationError *ValidationError) Error() string {
+	messages := make([]string, 0, len(validationError.FieldErrors))
+	for _, fieldError := range validationError.FieldErrors {
+		messages = append(messages, fieldError.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (validationError *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,16 @@
+package domain
+
+// IdempotentResponse, bir idempotency anahtarıyla yapılan ilk isteğe verilen ve saklanan yanıttır.
+// Aynı anahtarla tekrarlanan isteklere bu yanıt aynen döndürülür.
+type IdempotentResponse struct {
+	StatusCode int
+	Header     map[string][]string // Yanıtla birlikte tekrar gönderilecek başlıklar (ör. Content-Type, Location).
+	Body       []byte
+}
+
+// IdempotencyRecord, saklanan bir idempotency anahtarıdır.
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string              // İlk isteğin yöntemi, yolu ve gövdesinden hesaplanan SHA-256 özeti.
+	Response    *IdempotentResponse // İlk istek henüz tamamlanmadıysa nil.
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"product-app/common/money"
+	"time"
+)
+
+// PriceChange, bir ürünün fiyatındaki tek bir değişikliğin kaydıdır.
+type PriceChange struct {
+	Id        int64
+	ProductId int64
+	OldPrice  money.Money
+	NewPrice  money.Money
+	ChangedAt time.Time
+	Actor     string // Değişikliği yapan kişi veya sistem.
+}
+
+// PriceHistoryQuery, fiyat geçmişini tarih aralığına göre filtreler.
+// From dahil, To hariç tutulur; nil olan sınırlar uygulanmaz.
+type PriceHistoryQuery struct {
+	From *time.Time
+	To   *time.Time
+}
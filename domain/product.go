@@ -1,9 +1,33 @@
 package domain
 
+import (
+	"product-app/common/money"
+	"time"
+)
+
+// AnyVersion, koşullu güncelleme ve silmelerde ürünün sürümünün denetlenmeyeceğini belirtir.
+const AnyVersion int64 = 0
+
 type Product struct {
 	Id       int64
 	Name     string
-	Price    float32
-	Discount float32
-	Store    string
+	Price    money.Money   // Ürünün para birimiyle birlikte fiyatı.
+	Discount money.Decimal // Ürüne uygulanan yüzde indirim oranı.
+	StoreId  int64         // Ürünün satıldığı mağazanın ID'si.
+	Store    string        // Mağazanın adı; ürün okunurken mağaza kaydından doldurulur.
+	// CampaignId, okuma anında indirimi belirleyen kampanyanın ID'sidir; nil ise ürünün kendi indirimi geçerlidir.
+	CampaignId *int64
+	// Version, ürün her güncellendiğinde artan sürümdür. Güncellemelerde beklenen sürüm olarak kullanılır.
+	Version int64
+	// DeletedAt, ürünün çöp kutusuna taşındığı zamandır; nil ise ürün silinmemiştir.
+	DeletedAt *time.Time
+	// Pricing, geçerli indirime ve istenen para birimine göre hesaplanmış fiyat dökümüdür; servis katmanı doldurur.
+	Pricing PriceBreakdown
+}
+
+// PriceBreakdown, bir ürünün liste fiyatını, indirim tutarını ve indirimli son fiyatını taşır.
+type PriceBreakdown struct {
+	Price          money.Money // Kuruşa yuvarlanmış liste fiyatı.
+	DiscountAmount money.Money // Liste fiyatından düşülen indirim tutarı.
+	FinalPrice     money.Money // İndirim uygulandıktan sonra ödenecek fiyat.
 }
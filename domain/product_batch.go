@@ -0,0 +1,55 @@
+package domain
+
+import "product-app/common/money"
+
+// Toplu ürün işlemlerinde desteklenen işlem türleri.
+const (
+	BatchOperationCreate      = "create"
+	BatchOperationUpdatePrice = "updatePrice"
+	BatchOperationDelete      = "delete"
+)
+
+// Toplu ürün işlemlerinin çalıştırılma modları.
+const (
+	BatchModeAtomic     = "atomic"     // İşlemlerden biri başarısız olursa hiçbiri kalıcı olmaz.
+	BatchModeBestEffort = "bestEffort" // Başarısız işlemler geri alınır, diğerleri kalıcı olur.
+)
+
+// Toplu işlemdeki tek bir işlemin sonuç durumları.
+const (
+	BatchStatusSucceeded  = "succeeded"  // İşlem uygulandı ve kalıcı oldu.
+	BatchStatusFailed     = "failed"     // İşlem doğrulanamadı veya veritabanında başarısız oldu.
+	BatchStatusRolledBack = "rolledBack" // Atomik modda başka bir işlem başarısız olduğu için işlem geri alındı veya hiç çalıştırılmadı.
+)
+
+// ProductOperation, toplu işlemde tek transaction içinde uygulanacak bir ürün işlemidir.
+type ProductOperation struct {
+	Type      string        // BatchOperationCreate, BatchOperationUpdatePrice veya BatchOperationDelete.
+	ProductId int64         // Fiyatı güncellenecek veya silinecek ürünün ID'si.
+	Product   Product       // Eklenecek ürün; yalnızca create işleminde kullanılır.
+	NewPrice  money.Decimal // Ürünün yeni fiyatı; yalnızca updatePrice işleminde kullanılır.
+}
+
+// ProductOperationResult, toplu işlemdeki tek bir işlemin sonucudur.
+type ProductOperationResult struct {
+	Status    string // BatchStatusSucceeded, BatchStatusFailed veya BatchStatusRolledBack.
+	ProductId int64  // Eklenen ürünün veya işlemin hedeflediği ürünün ID'si; bilinmiyorsa 0.
+	Err       error  // İşlem başarısız olduysa nedeni.
+}
+
+// ProductBatchResult, toplu ürün işlemlerinin sonuçlarını istekteki sırayla taşır.
+type ProductBatchResult struct {
+	Mode    string
+	Results []ProductOperationResult
+}
+
+// Count, verilen durumdaki işlem sayısını döner.
+func (batchResult ProductBatchResult) Count(status string) int {
+	count := 0
+	for _, result := range batchResult.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
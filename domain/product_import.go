@@ -0,0 +1,16 @@
+package domain
+
+// ProductImportReport, toplu ürün içe aktarma isteğinin sonucunu satır bazında raporlar.
+// Herhangi bir satır hatalıysa hiçbir ürün eklenmez.
+type ProductImportReport struct {
+	DryRun       bool             // true ise satırlar yalnızca doğrulanmış, ürün eklenmemiştir.
+	TotalRows    int              // Dosyadaki ürün satırı sayısı.
+	ImportedRows int              // Veritabanına eklenen ürün sayısı.
+	RowErrors    []ImportRowError // Hatalı satırlar ve alan bazlı hataları.
+}
+
+// ImportRowError, içe aktarılan dosyadaki tek bir satırın doğrulama hatalarını taşır.
+type ImportRowError struct {
+	Line        int          // Satırın dosyadaki numarası, 1'den başlar.
+	FieldErrors []FieldError // Satırdaki hatalı alanlar.
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"product-app/common/money"
+)
+
+// Sıralamada kullanılabilecek ürün alanları.
+const (
+	SortFieldId       = "id"
+	SortFieldName     = "name"
+	SortFieldPrice    = "price"
+	SortFieldDiscount = "discount"
+	SortFieldStore    = "store"
+)
+
+// SortField, listelemede kullanılan tek bir sıralama kriterini tanımlar.
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// ProductQuery, ürün listeleme için sayfalama, sıralama ve filtre kriterlerini taşır.
+// Nil olan filtreler uygulanmaz. Fiyat filtreleri ürünün kendi para birimindeki fiyata uygulanır.
+type ProductQuery struct {
+	Limit       int
+	Offset      int
+	Cursor      string
+	Sort        []SortField
+	MinPrice    *money.Decimal
+	MaxPrice    *money.Decimal
+	MinDiscount *money.Decimal
+	StoreId     int64          // Sıfırdan farklıysa yalnızca bu mağazanın ürünleri listelenir.
+	CategoryId  int64          // Sıfırdan farklıysa bu kategorideki veya alt kategorilerindeki ürünler listelenir.
+	InStock     *bool          // true ise herhangi bir mağazada satılabilir stoğu olan, false ise olmayan ürünler listelenir.
+	Currency    money.Currency // Boş değilse sonuçtaki fiyatlar bu para birimine çevrilir.
+}
+
+// ProductPage, sayfalanmış ürün listesini, filtrelere uyan toplam kayıt sayısını
+// ve bir sonraki sayfanın imlecini taşır.
+type ProductPage struct {
+	Products   []Product
+	TotalCount int64
+	NextCursor string
+}
+
+// productCursor, bir sayfanın son ürününün sıralama anahtarlarını saklar.
+// İmleç yalnızca oluşturulduğu sıralama ile birlikte kullanılabilir.
+type productCursor struct {
+	Sort     []SortField   `json:"s"`
+	Id       int64         `json:"i"`
+	Name     string        `json:"n"`
+	Price    money.Decimal `json:"p"`
+	Discount money.Decimal `json:"d"`
+	Store    string        `json:"st"`
+}
+
+// EncodeProductCursor, verilen ürünü ve sıralamayı opak bir imleç değerine dönüştürür.
+func EncodeProductCursor(product Product, sort []SortField) string {
+	encoded, _ := json.Marshal(productCursor{
+		Sort:     sort,
+		Id:       product.Id,
+		Name:     product.Name,
+		Price:    product.Price.Amount,
+		Discount: product.Discount,
+		Store:    product.Store,
+	})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeProductCursor, imleci çözerek sayfanın son ürününün sıralama anahtarlarını döner.
+// İmleç farklı bir sıralama için oluşturulmuşsa hata döner.
+func DecodeProductCursor(cursor string, sort []SortField) (Product, error) {
+	decoded, decodeErr := base64.RawURLEncoding.DecodeString(cursor)
+	if decodeErr != nil {
+		return Product{}, NewValidationError("Cursor is malformed")
+	}
+	var parsed productCursor
+	if err := json.Unmarshal(decoded, &parsed); err != nil {
+		return Product{}, NewValidationError("Cursor is malformed")
+	}
+	if len(parsed.Sort) != len(sort) {
+		return Product{}, NewValidationError("Cursor does not match the requested sort")
+	}
+	for i := range sort {
+		if parsed.Sort[i] != sort[i] {
+			return Product{}, NewValidationError("Cursor does not match the requested sort")
+		}
+	}
+	return Product{
+		Id:       parsed.Id,
+		Name:     parsed.Name,
+		Price:    money.New(parsed.Price, money.DefaultCurrency),
+		Discount: parsed.Discount,
+		Store:    parsed.Store,
+	}, nil
+}
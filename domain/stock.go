@@ -0,0 +1,51 @@
+package domain
+
+import "fmt"
+
+// Stock, bir ürünün bir mağazadaki stok durumunu temsil eder.
+// Reserved, satılmak üzere ayrılmış ve henüz düşülmemiş miktardır; satılabilir miktar Quantity - Reserved'dir.
+type Stock struct {
+	ProductId         int64
+	StoreId           int64
+	Quantity          int
+	Reserved          int
+	LowStockThreshold int // Satılabilir miktar bu değere düştüğünde stok azalmış sayılır.
+}
+
+// Available, ayrılmamış ve satılabilir stok miktarını döner.
+func (stock Stock) Available() int {
+	return stock.Quantity - stock.Reserved
+}
+
+// IsLow, satılabilir miktarın düşük stok eşiğine inip inmediğini döner.
+func (stock Stock) IsLow() bool {
+	return stock.Available() <= stock.LowStockThreshold
+}
+
+// Reserve, verilen miktarı satılabilir stoktan ayırır. Yeterli stok yoksa ErrConflict döner.
+func (stock *Stock) Reserve(quantity int) error {
+	if stock.Available() < quantity {
+		return NewConflictError(fmt.Sprintf("Insufficient stock: %d available, %d requested", stock.Available(), quantity), nil)
+	}
+	stock.Reserved += quantity
+	return nil
+}
+
+// Release, daha önce ayrılmış miktarı geri bırakır. Ayrılmış miktardan fazlası bırakılamaz.
+func (stock *Stock) Release(quantity int) error {
+	if stock.Reserved < quantity {
+		return NewConflictError(fmt.Sprintf("Can not release %d, only %d reserved", quantity, stock.Reserved), nil)
+	}
+	stock.Reserved -= quantity
+	return nil
+}
+
+// Adjust, toplam stok miktarını verilen fark kadar artırır veya azaltır.
+// Miktar, ayrılmış miktarın altına düşürülemez.
+func (stock *Stock) Adjust(delta int) error {
+	if stock.Quantity+delta < stock.Reserved {
+		return NewConflictError(fmt.Sprintf("Stock can not drop below the reserved quantity %d", stock.Reserved), nil)
+	}
+	stock.Quantity += delta
+	return nil
+}
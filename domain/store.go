@@ -0,0 +1,8 @@
+package domain
+
+// Store, ürünlerin satıldığı mağazayı temsil eder.
+// Mağaza adları harf büyüklüğünden bağımsız olarak tekildir.
+type Store struct {
+	Id   int64
+	Name string
+}
@@ -2,39 +2,176 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"os"
 	"product-app/common/app"
 	"product-app/common/postgresql"
 	"product-app/controller"
 	"product-app/persistence"
+	"product-app/persistence/migration"
 	"product-app/service"
+	"strconv"
 )
 
 func main() {
-	// Uygulama bağlamını başlatıyoruz.
-	ctx := context.Background()
+	// Konfigürasyonu dosyadan ve ortam değişkenlerinden yüklüyoruz.
+	configurationManager, configErr := app.NewConfigurationManager()
+	if configErr != nil {
+		log.Fatal(configErr)
+	}
 
-	// Echo framework'ü başlatıyoruz.
+	// SIGINT/SIGTERM ile iptal edilen uygulama yaşam döngüsünü oluşturuyoruz.
+	lifecycle := app.NewLifecycle(context.Background(), configurationManager.ServerConfig.ShutdownTimeout)
+	ctx := lifecycle.Context()
+
+	// PostgreSQL bağlantı havuzunu oluşturuyoruz, bağlantı kurulamazsa yeniden deneniyor.
+	dbPool, dbErr := postgresql.GetConnectionPool(ctx, configurationManager.PostgreSqlConfig)
+	if dbErr != nil {
+		log.Fatal(dbErr)
+	}
+
+	// Şema migration'larını yöneten yapıyı oluşturuyoruz.
+	migrator := migration.NewMigrator(dbPool)
+
+	// "migrate" komutu verilmişse yalnızca migration işlemini yapıp çıkıyoruz.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrateErr := runMigrateCommand(ctx, migrator, os.Args[2:])
+		dbPool.Close()
+		if migrateErr != nil {
+			log.Error(migrateErr)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Sunucu başlamadan önce bekleyen migration'ları uyguluyoruz.
+	if err := migrator.Up(ctx); err != nil {
+		dbPool.Close()
+		log.Fatal(err)
+	}
+
+	// Bağlantı havuzu, sunucudaki istekler tamamlandıktan sonra kapatılıyor.
+	lifecycle.OnShutdown("PostgreSQL bağlantı havuzu", func(ctx context.Context) error {
+		dbPool.Close()
+		return nil
+	})
+
+	// Echo framework'ü başlatıyoruz ve sunucu zaman aşımlarını ayarlıyoruz.
 	e := echo.New()
+	e.Server.ReadTimeout = configurationManager.ServerConfig.ReadTimeout
+	e.Server.WriteTimeout = configurationManager.ServerConfig.WriteTimeout
+	e.Server.IdleTimeout = configurationManager.ServerConfig.IdleTimeout
 
-	// Konfigürasyon yöneticisini oluşturuyoruz.
-	configurationManager := app.NewConfigurationManager()
+	// Tüm hatalar tek bir noktadan HTTP durum kodlarına çevriliyor.
+	e.HTTPErrorHandler = controller.HTTPErrorHandler
 
-	// PostgreSQL bağlantı havuzunu oluşturuyoruz.
-	dbPool := postgresql.GetConnectionPool(ctx, configurationManager.PostgreSqlConfig)
+	// Değişikliği yapan kişi X-Actor başlığından alınıp denetim kayıtları için bağlama ekleniyor.
+	e.Use(controller.ActorMiddleware)
 
-	// Ürün repository'sini (veri erişim katmanı) oluşturuyoruz.
-	productRepository := persistence.NewProductRepository(dbPool)
+	// Ürün, mağaza, kategori, stok ve kampanya repository'lerini (veri erişim katmanı) oluşturuyoruz.
+	productRepository := persistence.NewProductRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout,
+		configurationManager.PostgreSqlConfig.ExportTimeout)
+	storeRepository := persistence.NewStoreRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout)
+	categoryRepository := persistence.NewCategoryRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout)
+	stockRepository := persistence.NewStockRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout)
+	campaignRepository := persistence.NewCampaignRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout)
+	idempotencyRepository := persistence.NewIdempotencyRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout)
 
-	// Ürün servisini (iş mantığı katmanı) oluşturuyoruz.
-	productService := service.NewProductService(productRepository)
+	// Birden fazla repository çağrısını tek transaction içinde çalıştırmak için unit of work oluşturuyoruz.
+	unitOfWork := persistence.NewUnitOfWork(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout, persistence.TxOptions{
+		IsolationLevel: configurationManager.PostgreSqlConfig.TxIsolationLevel,
+		MaxRetries:     configurationManager.PostgreSqlConfig.TxMaxRetries,
+	})
 
-	// Ürün kontrolcüsünü (API uç noktalarını yöneten katman) oluşturuyoruz.
-	productController := controller.NewProductController(productService)
+	// Döviz kuru kaynağını seçiyoruz: dosya verilmişse dosyadan, aksi halde veritabanından okunur.
+	var rateProvider persistence.RateProvider
+	if exchangeRatesFile := configurationManager.ExchangeRateConfig.File; len(exchangeRatesFile) > 0 {
+		fileRateProvider, rateErr := persistence.NewFileRateProvider(exchangeRatesFile)
+		if rateErr != nil {
+			dbPool.Close()
+			log.Fatal(rateErr)
+		}
+		rateProvider = fileRateProvider
+	} else {
+		rateProvider = persistence.NewExchangeRateRepository(dbPool, configurationManager.PostgreSqlConfig.QueryTimeout)
+	}
 
-	// Kontrolcünün API rotalarını Echo'ya kaydediyoruz.
+	// Ürün, mağaza, kategori, stok ve kampanya servislerini (iş mantığı katmanı) oluşturuyoruz.
+	productService := service.NewProductService(productRepository, storeRepository, rateProvider, campaignRepository, unitOfWork)
+	storeService := service.NewStoreService(storeRepository)
+	categoryService := service.NewCategoryService(categoryRepository, productRepository, unitOfWork)
+	stockService := service.NewStockService(stockRepository, productRepository, storeRepository)
+	campaignService := service.NewCampaignService(campaignRepository, productRepository, storeRepository)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepository, configurationManager.IdempotencyConfig.TTL,
+		configurationManager.IdempotencyConfig.Lease)
+
+	// Ürün, mağaza, kategori, stok ve kampanya kontrolcülerini (API uç noktalarını yöneten katman) oluşturuyoruz.
+	productController := controller.NewProductController(productService, idempotencyService,
+		int64(configurationManager.IdempotencyConfig.MaxBodySize))
+	storeController := controller.NewStoreController(storeService)
+	categoryController := controller.NewCategoryController(categoryService)
+	stockController := controller.NewStockController(stockService)
+	campaignController := controller.NewCampaignController(campaignService)
+
+	// Kontrolcülerin API rotalarını Echo'ya kaydediyoruz.
 	productController.RegisterRoutes(e)
+	storeController.RegisterRoutes(e)
+	categoryController.RegisterRoutes(e)
+	stockController.RegisterRoutes(e)
+	campaignController.RegisterRoutes(e)
+
+	// Çöp kutusunda saklama süresini aşan ürünleri periyodik olarak kalıcı olarak siliyoruz.
+	trashConfig := configurationManager.TrashConfig
+	lifecycle.Every("Çöp kutusu temizleme işi", trashConfig.PurgeInterval, func(ctx context.Context) error {
+		_, err := productService.PurgeTrash(ctx, trashConfig.Retention)
+		return err
+	})
+	// Süresi dolan idempotency anahtarlarını ve saklanan yanıtlarını periyodik olarak siliyoruz.
+	lifecycle.Every("Idempotency anahtarı temizleme işi", configurationManager.IdempotencyConfig.PurgeInterval, func(ctx context.Context) error {
+		_, err := idempotencyService.PurgeExpired(ctx)
+		return err
+	})
+
+	// Sunucuyu başlatıyoruz ve kapanış sinyaline kadar bekliyoruz.
+	if err := lifecycle.Serve(e, configurationManager.ServerConfig.Address); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	// Sunucuyu başlatıyoruz.
-	e.Start("localhost:8080")
+// runMigrateCommand, "migrate up", "migrate down [adım]" ve "migrate status" komutlarını çalıştırır.
+func runMigrateCommand(ctx context.Context, migrator *migration.Migrator, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("Kullanım: migrate up | down [adım] | status")
+	}
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			parsedSteps, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("Geçersiz adım sayısı: %s", args[1])
+			}
+			steps = parsedSteps
+		}
+		return migrator.Down(ctx, steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "bekliyor"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	default:
+		return fmt.Errorf("Bilinmeyen migrate komutu: %s", args[0])
+	}
 }
@@ -0,0 +1,184 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/labstack/gommon/log"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"time"
+)
+
+// ICampaignRepository, indirim kampanyalarıyla ilgili veritabanı işlemlerini tanımlayan arayüzdür.
+type ICampaignRepository interface {
+	GetAllCampaigns(ctx context.Context) ([]domain.Campaign, error)                     // Tüm kampanyaları başlangıç zamanına göre sıralı getirir.
+	GetActiveCampaigns(ctx context.Context, at time.Time) ([]domain.Campaign, error)    // Verilen anda geçerli olan kampanyaları getirir.
+	GetById(ctx context.Context, campaignId int64) (domain.Campaign, error)             // Belirli bir ID'ye sahip kampanyayı getirir.
+	AddCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error) // Yeni bir kampanyayı hedefleriyle ekler ve ID'si atanmış hâlini döner.
+	UpdateCampaign(ctx context.Context, campaign domain.Campaign) error                 // Kampanyanın tüm alanlarını ve hedeflerini değiştirir.
+	DeleteById(ctx context.Context, campaignId int64) error                             // Kampanyayı hedefleriyle birlikte siler.
+}
+
+// CampaignRepository, ICampaignRepository arayüzünü uygulayan yapıdır.
+type CampaignRepository struct {
+	db           DB            // Sorguların çalıştırıldığı bağlantı havuzu veya unit of work transaction'ı.
+	queryTimeout time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+}
+
+// NewCampaignRepository, yeni bir CampaignRepository örneği oluşturur.
+func NewCampaignRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration) ICampaignRepository {
+	return &CampaignRepository{
+		db:           dbPool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// selectCampaignSql, kampanyaları hedef ürün ve mağaza ID'leriyle birlikte okuyan ortak sorgudur.
+const selectCampaignSql = `Select c.id, c.name, c.discount, c.starts_at, c.ends_at, c.priority,
+  coalesce((select array_agg(cp.product_id order by cp.product_id) from campaign_products cp where cp.campaign_id = c.id), '{}'),
+  coalesce((select array_agg(cs.store_id order by cs.store_id) from campaign_stores cs where cs.campaign_id = c.id), '{}')
+  from campaigns c`
+
+// GetAllCampaigns, tüm kampanyaları başlangıç zamanına göre sıralı olarak getirir.
+func (campaignRepository *CampaignRepository) GetAllCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	ctx, cancel := withQueryTimeout(ctx, campaignRepository.queryTimeout)
+	defer cancel()
+
+	campaignRows, err := campaignRepository.db.Query(ctx, selectCampaignSql+" order by c.starts_at, c.id")
+	if err != nil {
+		return nil, common.TranslateError(err, "Tüm kampanyalar alınırken hata oluştu")
+	}
+	return extractCampaignsFromRows(campaignRows)
+}
+
+// GetActiveCampaigns, verilen anda başlamış ve henüz bitmemiş kampanyaları getirir.
+func (campaignRepository *CampaignRepository) GetActiveCampaigns(ctx context.Context, at time.Time) ([]domain.Campaign, error) {
+	ctx, cancel := withQueryTimeout(ctx, campaignRepository.queryTimeout)
+	defer cancel()
+
+	campaignRows, err := campaignRepository.db.Query(ctx, selectCampaignSql+" where c.starts_at <= $1 and c.ends_at > $1 order by c.id", at)
+	if err != nil {
+		return nil, common.TranslateError(err, "Geçerli kampanyalar alınırken hata oluştu")
+	}
+	return extractCampaignsFromRows(campaignRows)
+}
+
+// GetById, belirli bir ID'ye sahip kampanyayı getirir.
+func (campaignRepository *CampaignRepository) GetById(ctx context.Context, campaignId int64) (domain.Campaign, error) {
+	ctx, cancel := withQueryTimeout(ctx, campaignRepository.queryTimeout)
+	defer cancel()
+
+	var campaign domain.Campaign
+	scanErr := campaignRepository.db.QueryRow(ctx, selectCampaignSql+" where c.id = $1", campaignId).
+		Scan(&campaign.Id, &campaign.Name, &campaign.Discount, &campaign.StartsAt, &campaign.EndsAt, &campaign.Priority, &campaign.ProductIds, &campaign.StoreIds)
+
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return domain.Campaign{}, domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan kampanya bulunamadı", campaignId))
+	}
+	if scanErr != nil {
+		return domain.Campaign{}, common.TranslateError(scanErr, fmt.Sprintf("ID'si %d olan kampanya alınırken hata oluştu", campaignId))
+	}
+	return campaign, nil
+}
+
+// AddCampaign, kampanyayı ve hedeflerini tek bir transaction içinde ekler.
+func (campaignRepository *CampaignRepository) AddCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error) {
+	ctx, cancel := withQueryTimeout(ctx, campaignRepository.queryTimeout)
+	defer cancel()
+
+	err := campaignRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		insertSql := `Insert into campaigns (name, discount, starts_at, ends_at, priority) VALUES ($1, $2, $3, $4, $5) returning id`
+		if insertErr := tx.QueryRow(ctx, insertSql, campaign.Name, campaign.Discount, campaign.StartsAt, campaign.EndsAt, campaign.Priority).Scan(&campaign.Id); insertErr != nil {
+			return insertErr
+		}
+		return insertCampaignTargets(ctx, tx, campaign)
+	})
+	if err != nil {
+		return domain.Campaign{}, common.TranslateError(err, fmt.Sprintf("%s adlı kampanya eklenemedi", campaign.Name))
+	}
+	log.Infof("Kampanya eklendi: %d", campaign.Id)
+	return campaign, nil
+}
+
+// UpdateCampaign, kampanyanın alanlarını günceller ve hedeflerini verilen listelerle değiştirir.
+func (campaignRepository *CampaignRepository) UpdateCampaign(ctx context.Context, campaign domain.Campaign) error {
+	ctx, cancel := withQueryTimeout(ctx, campaignRepository.queryTimeout)
+	defer cancel()
+
+	err := campaignRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		updateSql := `Update campaigns set name = $1, discount = $2, starts_at = $3, ends_at = $4, priority = $5 where id = $6`
+		commandTag, updateErr := tx.Exec(ctx, updateSql, campaign.Name, campaign.Discount, campaign.StartsAt, campaign.EndsAt, campaign.Priority, campaign.Id)
+		if updateErr != nil {
+			return updateErr
+		}
+		if commandTag.RowsAffected() == 0 {
+			return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan kampanya bulunamadı", campaign.Id))
+		}
+		if _, deleteErr := tx.Exec(ctx, `Delete from campaign_products where campaign_id = $1`, campaign.Id); deleteErr != nil {
+			return deleteErr
+		}
+		if _, deleteErr := tx.Exec(ctx, `Delete from campaign_stores where campaign_id = $1`, campaign.Id); deleteErr != nil {
+			return deleteErr
+		}
+		return insertCampaignTargets(ctx, tx, campaign)
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan kampanya güncellenemedi", campaign.Id))
+	}
+	log.Infof("Kampanya %d güncellendi", campaign.Id)
+	return nil
+}
+
+// DeleteById, belirli bir ID'ye sahip kampanyayı siler. Hedefleri cascade ile silinir.
+func (campaignRepository *CampaignRepository) DeleteById(ctx context.Context, campaignId int64) error {
+	ctx, cancel := withQueryTimeout(ctx, campaignRepository.queryTimeout)
+	defer cancel()
+
+	commandTag, err := campaignRepository.db.Exec(ctx, `Delete from campaigns where id = $1`, campaignId)
+
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan kampanya silinirken hata oluştu", campaignId))
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan kampanya bulunamadı", campaignId))
+	}
+	log.Infof("Kampanya %d silindi", campaignId)
+	return nil
+}
+
+// insertCampaignTargets, kampanyanın hedef ürün ve mağazalarını verilen transaction içinde ekler.
+func insertCampaignTargets(ctx context.Context, tx pgx.Tx, campaign domain.Campaign) error {
+	productsSql := `Insert into campaign_products (campaign_id, product_id) select $1, unnest($2::bigint[])`
+	if _, err := tx.Exec(ctx, productsSql, campaign.Id, campaign.ProductIds); err != nil {
+		return err
+	}
+	storesSql := `Insert into campaign_stores (campaign_id, store_id) select $1, unnest($2::bigint[])`
+	_, err := tx.Exec(ctx, storesSql, campaign.Id, campaign.StoreIds)
+	return err
+}
+
+// extractCampaignsFromRows, sorgu satırlarını kampanya listesine dönüştürür ve satırları kapatır.
+func extractCampaignsFromRows(campaignRows pgx.Rows) ([]domain.Campaign, error) {
+	defer campaignRows.Close()
+
+	var campaigns = []domain.Campaign{}
+	for campaignRows.Next() {
+		var campaign domain.Campaign
+		scanErr := campaignRows.Scan(&campaign.Id, &campaign.Name, &campaign.Discount, &campaign.StartsAt, &campaign.EndsAt,
+			&campaign.Priority, &campaign.ProductIds, &campaign.StoreIds)
+		if scanErr != nil {
+			return nil, common.TranslateError(scanErr, "Kampanya satırı okunurken hata oluştu")
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	if rowsErr := campaignRows.Err(); rowsErr != nil {
+		return nil, common.TranslateError(rowsErr, "Kampanyalar okunurken hata oluştu")
+	}
+	return campaigns, nil
+}
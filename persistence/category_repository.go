@@ -0,0 +1,180 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/labstack/gommon/log"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"time"
+)
+
+// ICategoryRepository, kategori ağacı ve ürün-kategori ilişkileriyle ilgili işlemleri tanımlayan arayüzdür.
+type ICategoryRepository interface {
+	GetAllCategories(ctx context.Context) ([]domain.Category, error)                        // Tüm kategorileri ada göre sıralı getirir.
+	GetById(ctx context.Context, categoryId int64) (domain.Category, error)                 // Belirli bir ID'ye sahip kategoriyi getirir.
+	AddCategory(ctx context.Context, category domain.Category) (domain.Category, error)     // Yeni bir kategori ekler ve ID'si atanmış hâlini döner.
+	UpdateCategory(ctx context.Context, category domain.Category) error                     // Kategorinin adını ve üst kategorisini değiştirir.
+	DeleteById(ctx context.Context, categoryId int64) error                                 // Alt kategorisi ve ürünü olmayan bir kategoriyi siler.
+	GetCategoriesOfProduct(ctx context.Context, productId int64) ([]domain.Category, error) // Ürünün bağlı olduğu kategorileri getirir.
+	SetCategoriesOfProduct(ctx context.Context, productId int64, categoryIds []int64) error // Ürünün kategorilerini verilenlerle değiştirir.
+}
+
+// CategoryRepository, ICategoryRepository arayüzünü uygulayan yapıdır.
+type CategoryRepository struct {
+	db           DB            // Sorguların çalıştırıldığı bağlantı havuzu veya unit of work transaction'ı.
+	queryTimeout time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+}
+
+// NewCategoryRepository, yeni bir CategoryRepository örneği oluşturur.
+func NewCategoryRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration) ICategoryRepository {
+	return &CategoryRepository{
+		db:           dbPool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// GetAllCategories, tüm kategorileri ada göre sıralı olarak getirir.
+func (categoryRepository *CategoryRepository) GetAllCategories(ctx context.Context) ([]domain.Category, error) {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	categoryRows, err := categoryRepository.db.Query(ctx, "Select id, name, parent_id from categories order by name, id")
+	if err != nil {
+		return nil, common.TranslateError(err, "Tüm kategoriler alınırken hata oluştu")
+	}
+	return extractCategoriesFromRows(categoryRows)
+}
+
+// GetById, belirli bir ID'ye sahip kategoriyi getirir.
+func (categoryRepository *CategoryRepository) GetById(ctx context.Context, categoryId int64) (domain.Category, error) {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	var category domain.Category
+	scanErr := categoryRepository.db.QueryRow(ctx, "Select id, name, parent_id from categories where id = $1", categoryId).
+		Scan(&category.Id, &category.Name, &category.ParentId)
+
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return domain.Category{}, domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan kategori bulunamadı", categoryId))
+	}
+	if scanErr != nil {
+		return domain.Category{}, common.TranslateError(scanErr, fmt.Sprintf("ID'si %d olan kategori alınırken hata oluştu", categoryId))
+	}
+	return category, nil
+}
+
+// AddCategory, yeni bir kategori ekler. Aynı üst kategoride aynı adda bir kategori varsa ErrConflict döner.
+func (categoryRepository *CategoryRepository) AddCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	insertSql := `Insert into categories (name, parent_id) VALUES ($1, $2) returning id`
+
+	if err := categoryRepository.db.QueryRow(ctx, insertSql, category.Name, category.ParentId).Scan(&category.Id); err != nil {
+		return domain.Category{}, common.TranslateError(err, fmt.Sprintf("%s adlı kategori eklenemedi", category.Name))
+	}
+	log.Infof("Kategori eklendi: %d", category.Id)
+	return category, nil
+}
+
+// UpdateCategory, kategorinin adını ve üst kategorisini değiştirir.
+// Döngü oluşmaması servis katmanında denetlenir.
+func (categoryRepository *CategoryRepository) UpdateCategory(ctx context.Context, category domain.Category) error {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	updateSql := `Update categories set name = $1, parent_id = $2 where id = $3`
+
+	commandTag, err := categoryRepository.db.Exec(ctx, updateSql, category.Name, category.ParentId, category.Id)
+
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan kategori güncellenemedi", category.Id))
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan kategori bulunamadı", category.Id))
+	}
+	log.Infof("Kategori %d güncellendi", category.Id)
+	return nil
+}
+
+// DeleteById, belirli bir ID'ye sahip kategoriyi siler.
+// Kategorinin alt kategorileri veya bağlı ürünleri varsa ErrConflict döner.
+func (categoryRepository *CategoryRepository) DeleteById(ctx context.Context, categoryId int64) error {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	commandTag, err := categoryRepository.db.Exec(ctx, `Delete from categories where id = $1`, categoryId)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == common.ForeignKeyViolationCode {
+		return domain.NewConflictError(fmt.Sprintf("ID'si %d olan kategorinin alt kategorileri veya ürünleri olduğu için silinemez", categoryId), err)
+	}
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan kategori silinirken hata oluştu", categoryId))
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan kategori bulunamadı", categoryId))
+	}
+	log.Infof("Kategori %d silindi", categoryId)
+	return nil
+}
+
+// GetCategoriesOfProduct, ürünün doğrudan bağlı olduğu kategorileri ada göre sıralı getirir.
+func (categoryRepository *CategoryRepository) GetCategoriesOfProduct(ctx context.Context, productId int64) ([]domain.Category, error) {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	getCategoriesSql := `Select c.id, c.name, c.parent_id from categories c
+		join product_categories pc on pc.category_id = c.id
+		where pc.product_id = $1 order by c.name, c.id`
+
+	categoryRows, err := categoryRepository.db.Query(ctx, getCategoriesSql, productId)
+	if err != nil {
+		return nil, common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürünün kategorileri alınırken hata oluştu", productId))
+	}
+	return extractCategoriesFromRows(categoryRows)
+}
+
+// SetCategoriesOfProduct, ürünün mevcut kategori bağlantılarını silip verilen kategorilere bağlar.
+// İki adım tek bir transaction içinde çalışır; ürün veya kategori yoksa ErrValidation döner.
+func (categoryRepository *CategoryRepository) SetCategoriesOfProduct(ctx context.Context, productId int64, categoryIds []int64) error {
+	ctx, cancel := withQueryTimeout(ctx, categoryRepository.queryTimeout)
+	defer cancel()
+
+	err := categoryRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, deleteErr := tx.Exec(ctx, `Delete from product_categories where product_id = $1`, productId); deleteErr != nil {
+			return deleteErr
+		}
+		insertSql := `Insert into product_categories (product_id, category_id) select $1, unnest($2::bigint[])`
+		_, insertErr := tx.Exec(ctx, insertSql, productId, categoryIds)
+		return insertErr
+	})
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürünün kategorileri güncellenemedi", productId))
+	}
+	log.Infof("Ürün %d kategorileri güncellendi", productId)
+	return nil
+}
+
+// extractCategoriesFromRows, sorgu satırlarını kategori listesine dönüştürür ve satırları kapatır.
+func extractCategoriesFromRows(categoryRows pgx.Rows) ([]domain.Category, error) {
+	defer categoryRows.Close()
+
+	var categories = []domain.Category{}
+	for categoryRows.Next() {
+		var category domain.Category
+		if scanErr := categoryRows.Scan(&category.Id, &category.Name, &category.ParentId); scanErr != nil {
+			return nil, common.TranslateError(scanErr, "Kategori satırı okunurken hata oluştu")
+		}
+		categories = append(categories, category)
+	}
+	if rowsErr := categoryRows.Err(); rowsErr != nil {
+		return nil, common.TranslateError(rowsErr, "Kategoriler okunurken hata oluştu")
+	}
+	return categories, nil
+}
@@ -1,3 +0,0 @@
-package common
-
-var NOT_FOUND = "no rows in result set"
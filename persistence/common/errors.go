@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/puddle"
+	"net"
+	"product-app/domain"
+	"strings"
+)
+
+// PostgreSQL hata kodları (https://www.postgresql.org/docs/current/errcodes-appendix.html).
+const (
+	UniqueViolationCode      = "23505"
+	ForeignKeyViolationCode  = "23503"
+	CheckViolationCode       = "23514"
+	SerializationFailureCode = "40001"
+	DeadlockDetectedCode     = "40P01"
+)
+
+// TranslateError, veritabanı sürücüsünden gelen hatayı domain hata türlerine çevirir.
+// Bağlantı, zaman aşımı ve iptal hataları ErrUnavailable, tekillik ihlalleri ErrConflict,
+// kısıt ihlalleri ErrValidation olur. Yeniden denemelere rağmen çözülemeyen serileştirme
+// hataları ve kilitlenmeler de ErrConflict olur. İstemcinin isteği iptal etmesi bir erişim sorunu
+// olmadığından context.Canceled ve tanınmayan hatalar mesajla sarmalanarak döner.
+func TranslateError(err error, message string) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == UniqueViolationCode, IsRetryable(err):
+			return domain.NewConflictError(message, err)
+		case pgErr.Code == ForeignKeyViolationCode, pgErr.Code == CheckViolationCode:
+			return &domain.Error{Kind: domain.ErrValidation, Message: message, Cause: err}
+		case isUnavailableCode(pgErr.Code):
+			return domain.NewUnavailableError(message, err)
+		}
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	if isUnavailable(err) {
+		return domain.NewUnavailableError(message, err)
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// isUnavailable, hatanın geçici bir erişim sorunundan kaynaklanıp kaynaklanmadığını kontrol eder.
+// Kapanmış havuzdan bağlantı istenirse pgxpool, puddle.ErrClosedPool hatasını sarmalamadan döner.
+func isUnavailable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) ||
+		pgconn.Timeout(err) ||
+		pgconn.SafeToRetry(err) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, puddle.ErrClosedPool)
+}
+
+// isUnavailableCode, bağlantı (08), kaynak yetersizliği (53) ve yönetici kaynaklı
+// kapanma (57P) sınıfındaki PostgreSQL hata kodlarını tanır.
+func isUnavailableCode(code string) bool {
+	return strings.HasPrefix(code, "08") || strings.HasPrefix(code, "53") || strings.HasPrefix(code, "57P")
+}
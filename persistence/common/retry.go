@@ -0,0 +1,39 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgconn"
+	"github.com/labstack/gommon/log"
+	"math/rand"
+	"time"
+)
+
+// IsRetryable, hatanın transaction baştan çalıştırılarak çözülebilecek bir serileştirme
+// hatası veya kilitlenme olup olmadığını kontrol eder.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == SerializationFailureCode || pgErr.Code == DeadlockDetectedCode)
+}
+
+// RetryTx, run fonksiyonunu çalıştırır ve serileştirme hatası veya kilitlenme ile başarısız
+// olursa en fazla maxRetries kez yeniden dener. Denemeler arasındaki bekleme backoff ile
+// başlar ve her denemede iki katına çıkar; çakışan transaction'ların aynı anda yeniden
+// başlamaması için beklemeye rastgele bir pay eklenir. Bağlam iptal edilirse son hata döner.
+func RetryTx(ctx context.Context, maxRetries int, backoff time.Duration, run func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := run()
+		if err == nil || !IsRetryable(err) || attempt >= maxRetries {
+			return err
+		}
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)+1))
+		log.Warnf("Transaction çakışma nedeniyle geri alındı, %v sonra yeniden denenecek (%d/%d): %v", wait, attempt+1, maxRetries, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"time"
+)
+
+// RateProvider, iki para birimi arasındaki döviz kurunu sağlayan kaynaktır.
+// Dönen kur, kaynak para biriminin bir biriminin hedef para birimindeki karşılığıdır.
+// Kur tanımlı değilse domain.ErrNotFound türünde hata döner.
+type RateProvider interface {
+	GetRate(ctx context.Context, source money.Currency, target money.Currency) (money.Decimal, error)
+}
+
+// ExchangeRateRepository, kurları exchange_rates tablosundan okuyan RateProvider'dır.
+type ExchangeRateRepository struct {
+	dbPool       *pgxpool.Pool // PostgreSQL bağlantı havuzunu temsil eder.
+	queryTimeout time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+}
+
+// NewExchangeRateRepository, yeni bir ExchangeRateRepository örneği oluşturur.
+func NewExchangeRateRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration) RateProvider {
+	return &ExchangeRateRepository{
+		dbPool:       dbPool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// GetRate, kaynak para biriminden hedef para birimine olan kuru getirir.
+func (exchangeRateRepository *ExchangeRateRepository) GetRate(ctx context.Context, source money.Currency, target money.Currency) (money.Decimal, error) {
+	ctx, cancel := withQueryTimeout(ctx, exchangeRateRepository.queryTimeout)
+	defer cancel()
+
+	getRateSql := `Select rate from exchange_rates where source_currency = $1 and target_currency = $2`
+
+	var rate money.Decimal
+	scanErr := exchangeRateRepository.dbPool.QueryRow(ctx, getRateSql, string(source), string(target)).Scan(&rate)
+
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return money.Decimal{}, domain.NewNotFoundError(fmt.Sprintf("%s -> %s kuru bulunamadı", source, target))
+	}
+	if scanErr != nil {
+		return money.Decimal{}, common.TranslateError(scanErr, fmt.Sprintf("%s -> %s kuru alınırken hata oluştu", source, target))
+	}
+	return rate, nil
+}
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"product-app/common/money"
+	"product-app/domain"
+)
+
+// FileRateProvider, kurları bir YAML/JSON dosyasından bir kez okuyup bellekte tutan RateProvider'dır.
+// Veritabanı olmadan çalışan testler ve yerel geliştirme için kullanılır. Dosya biçimi:
+//
+//	EUR:
+//	  TRY: "35.25"
+//	  USD: "1.08"
+type FileRateProvider struct {
+	rates map[money.Currency]map[money.Currency]money.Decimal
+}
+
+// NewFileRateProvider, verilen dosyadaki kurları okuyarak yeni bir FileRateProvider oluşturur.
+func NewFileRateProvider(path string) (RateProvider, error) {
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		return nil, fmt.Errorf("Kur dosyası okunamadı: %w", readErr)
+	}
+	var rawRates map[string]map[string]string
+	if err := yaml.Unmarshal(content, &rawRates); err != nil {
+		return nil, fmt.Errorf("%s kur dosyası çözümlenemedi: %w", path, err)
+	}
+
+	rates := map[money.Currency]map[money.Currency]money.Decimal{}
+	for sourceCode, targets := range rawRates {
+		source, err := money.ParseCurrency(sourceCode)
+		if err != nil {
+			return nil, fmt.Errorf("%s kur dosyası geçersiz: %w", path, err)
+		}
+		rates[source] = map[money.Currency]money.Decimal{}
+		for targetCode, rateText := range targets {
+			target, err := money.ParseCurrency(targetCode)
+			if err != nil {
+				return nil, fmt.Errorf("%s kur dosyası geçersiz: %w", path, err)
+			}
+			rate, err := money.Parse(rateText)
+			if err != nil || rate.Sign() <= 0 {
+				return nil, fmt.Errorf("%s kur dosyasında %s -> %s kuru geçersiz: %q", path, source, target, rateText)
+			}
+			rates[source][target] = rate
+		}
+	}
+	return &FileRateProvider{rates: rates}, nil
+}
+
+// GetRate, kaynak para biriminden hedef para birimine olan kuru döner.
+func (fileRateProvider *FileRateProvider) GetRate(ctx context.Context, source money.Currency, target money.Currency) (money.Decimal, error) {
+	if err := ctx.Err(); err != nil {
+		return money.Decimal{}, err
+	}
+	rate, ok := fileRateProvider.rates[source][target]
+	if !ok {
+		return money.Decimal{}, domain.NewNotFoundError(fmt.Sprintf("%s -> %s kuru bulunamadı", source, target))
+	}
+	return rate, nil
+}
@@ -0,0 +1,127 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/labstack/gommon/log"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"time"
+)
+
+// IIdempotencyRepository, idempotency anahtarlarını ve bu anahtarlarla verilen yanıtları saklayan arayüzdür.
+type IIdempotencyRepository interface {
+	// Reserve, anahtarı verilen istek özeti için ttl süresince ayırır, lease süresince kilitler ve true döner.
+	// Anahtar süresi dolmamış bir kayıtta kullanılıyorsa ayırmaz ve mevcut kaydı false ile döner.
+	Reserve(ctx context.Context, key string, requestHash string, ttl time.Duration, lease time.Duration) (domain.IdempotencyRecord, bool, error)
+	SaveResponse(ctx context.Context, key string, response domain.IdempotentResponse) error // Ayrılmış anahtara verilen yanıtı kaydeder.
+	Release(ctx context.Context, key string) error                                          // Yanıtı saklanmayacak bir anahtarı siler.
+	DeleteExpired(ctx context.Context) (int64, error)                                       // Süresi dolan anahtarları siler.
+}
+
+// IdempotencyRepository, IIdempotencyRepository arayüzünü idempotency_keys tablosuyla uygulayan yapıdır.
+type IdempotencyRepository struct {
+	dbPool       *pgxpool.Pool // PostgreSQL bağlantı havuzunu temsil eder.
+	queryTimeout time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+}
+
+// NewIdempotencyRepository, yeni bir IdempotencyRepository örneği oluşturur.
+func NewIdempotencyRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration) IIdempotencyRepository {
+	return &IdempotencyRepository{
+		dbPool:       dbPool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// Reserve, anahtarı ekler; anahtar varsa yalnızca süresi dolmuşsa veya yanıtı kaydedilmeden kilidinin
+// süresi dolmuşsa (isteği işleyen süreç sonlanmışsa) yeni istek için sıfırlanır.
+// Ayrılamayan anahtarın kaydı okunurken silinmişse, kayıt yanıtı olmayan (işlenmekte olan) bir kayıt olarak döner.
+func (idempotencyRepository *IdempotencyRepository) Reserve(ctx context.Context, key string, requestHash string, ttl time.Duration, lease time.Duration) (domain.IdempotencyRecord, bool, error) {
+	ctx, cancel := withQueryTimeout(ctx, idempotencyRepository.queryTimeout)
+	defer cancel()
+
+	reserveSql := `Insert into idempotency_keys (key, request_hash, expires_at, locked_until)
+		VALUES ($1, $2, now() + $3 * interval '1 millisecond', now() + $4 * interval '1 millisecond')
+		on conflict (key) do update set request_hash = excluded.request_hash, status_code = null, response_header = null,
+			response_body = null, created_at = now(), expires_at = excluded.expires_at, locked_until = excluded.locked_until
+		where idempotency_keys.expires_at <= now()
+			or (idempotency_keys.status_code is null and idempotency_keys.locked_until <= now())`
+
+	commandTag, err := idempotencyRepository.dbPool.Exec(ctx, reserveSql, key, requestHash, ttl.Milliseconds(), lease.Milliseconds())
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, common.TranslateError(err, fmt.Sprintf("%s idempotency anahtarı ayrılırken hata oluştu", key))
+	}
+	if commandTag.RowsAffected() == 1 {
+		return domain.IdempotencyRecord{Key: key, RequestHash: requestHash}, true, nil
+	}
+
+	record := domain.IdempotencyRecord{Key: key, RequestHash: requestHash}
+	var statusCode *int32
+	var header []byte
+	var body []byte
+	scanErr := idempotencyRepository.dbPool.QueryRow(ctx,
+		"Select request_hash, status_code, response_header, response_body from idempotency_keys where key = $1", key).
+		Scan(&record.RequestHash, &statusCode, &header, &body)
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return record, false, nil
+	}
+	if scanErr != nil {
+		return domain.IdempotencyRecord{}, false, common.TranslateError(scanErr, fmt.Sprintf("%s idempotency anahtarı alınırken hata oluştu", key))
+	}
+	if statusCode != nil {
+		record.Response = &domain.IdempotentResponse{StatusCode: int(*statusCode), Body: body}
+		if unmarshalErr := json.Unmarshal(header, &record.Response.Header); unmarshalErr != nil {
+			return domain.IdempotencyRecord{}, false, fmt.Errorf("%s idempotency anahtarının yanıt başlıkları okunamadı: %w", key, unmarshalErr)
+		}
+	}
+	return record, false, nil
+}
+
+// SaveResponse, ayrılmış anahtara verilen yanıtı kaydeder; sonraki istekler bu yanıtı alır.
+func (idempotencyRepository *IdempotencyRepository) SaveResponse(ctx context.Context, key string, response domain.IdempotentResponse) error {
+	ctx, cancel := withQueryTimeout(ctx, idempotencyRepository.queryTimeout)
+	defer cancel()
+
+	header, marshalErr := json.Marshal(response.Header)
+	if marshalErr != nil {
+		return fmt.Errorf("%s idempotency anahtarının yanıt başlıkları yazılamadı: %w", key, marshalErr)
+	}
+	saveSql := `Update idempotency_keys set status_code = $2, response_header = $3, response_body = $4 where key = $1`
+
+	_, err := idempotencyRepository.dbPool.Exec(ctx, saveSql, key, response.StatusCode, header, response.Body)
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("%s idempotency anahtarının yanıtı kaydedilirken hata oluştu", key))
+	}
+	return nil
+}
+
+// Release, yanıtı henüz kaydedilmemiş anahtarı siler; böylece istek aynı anahtarla yeniden denenebilir.
+func (idempotencyRepository *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	ctx, cancel := withQueryTimeout(ctx, idempotencyRepository.queryTimeout)
+	defer cancel()
+
+	_, err := idempotencyRepository.dbPool.Exec(ctx, "Delete from idempotency_keys where key = $1 and status_code is null", key)
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("%s idempotency anahtarı silinirken hata oluştu", key))
+	}
+	return nil
+}
+
+// DeleteExpired, süresi dolan anahtarları ve yanıtlarını siler ve silinen anahtar sayısını döner.
+func (idempotencyRepository *IdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	ctx, cancel := withQueryTimeout(ctx, idempotencyRepository.queryTimeout)
+	defer cancel()
+
+	commandTag, err := idempotencyRepository.dbPool.Exec(ctx, "Delete from idempotency_keys where expires_at <= now()")
+	if err != nil {
+		return 0, common.TranslateError(err, "Süresi dolan idempotency anahtarları silinirken hata oluştu")
+	}
+	if commandTag.RowsAffected() > 0 {
+		log.Infof("Süresi dolan %d idempotency anahtarı silindi", commandTag.RowsAffected())
+	}
+	return commandTag.RowsAffected(), nil
+}
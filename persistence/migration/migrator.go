@@ -0,0 +1,258 @@
+package migration
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/labstack/gommon/log"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed sql/*.sql
+var migrationFiles embed.FS
+
+// migrationFileName, "0001_create_products_table.up.sql" biçimindeki dosya adlarını ayrıştırır.
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationLockId, aynı anda birden fazla uygulama örneğinin migration çalıştırmasını
+// engelleyen PostgreSQL advisory lock anahtarıdır.
+const migrationLockId = 73546271
+
+const createSchemaMigrationsSql = `create table if not exists schema_migrations
+(
+  version bigint not null primary key,
+  name varchar(255) not null,
+  applied_at timestamptz not null default now()
+)`
+
+// Migration, sürüm numarasıyla sıralanan tek bir şema değişikliğini temsil eder.
+type Migration struct {
+	Version int64
+	Name    string
+	UpSql   string
+	DownSql string
+}
+
+// MigrationStatus, bir migration'ın veritabanına uygulanıp uygulanmadığını gösterir.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Migrator, gömülü SQL dosyalarını schema_migrations tablosunu kullanarak uygular ve geri alır.
+type Migrator struct {
+	dbPool *pgxpool.Pool // PostgreSQL bağlantı havuzunu temsil eder.
+}
+
+// NewMigrator, yeni bir Migrator örneği oluşturur.
+func NewMigrator(dbPool *pgxpool.Pool) *Migrator {
+	return &Migrator{
+		dbPool: dbPool,
+	}
+}
+
+// Up, henüz uygulanmamış tüm migration'ları sürüm sırasıyla uygular.
+// Her migration kendi transaction'ı içinde çalışır; hata olursa sonraki migration'lar uygulanmaz.
+func (migrator *Migrator) Up(ctx context.Context) error {
+	migrations, loadErr := loadMigrations()
+	if loadErr != nil {
+		return loadErr
+	}
+	return migrator.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err := conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.UpSql); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "Insert into schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("%d_%s migration'ı uygulanırken hata oluştu: %w", migration.Version, migration.Name, err)
+			}
+			log.Infof("Migration uygulandı: %d_%s", migration.Version, migration.Name)
+		}
+		return nil
+	})
+}
+
+// Down, en son uygulanan migration'lardan başlayarak verilen sayıda migration'ı geri alır.
+func (migrator *Migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return errors.New("Geri alınacak migration sayısı en az 1 olmalıdır")
+	}
+	migrations, loadErr := loadMigrations()
+	if loadErr != nil {
+		return loadErr
+	}
+	return migrator.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+			migration := migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			err := conn.BeginFunc(ctx, func(tx pgx.Tx) error {
+				if _, err := tx.Exec(ctx, migration.DownSql); err != nil {
+					return err
+				}
+				_, err := tx.Exec(ctx, "Delete from schema_migrations where version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("%d_%s migration'ı geri alınırken hata oluştu: %w", migration.Version, migration.Name, err)
+			}
+			log.Infof("Migration geri alındı: %d_%s", migration.Version, migration.Name)
+			steps--
+		}
+		return nil
+	})
+}
+
+// Status, bilinen tüm migration'ları uygulanma durumlarıyla birlikte sürüm sırasıyla döner.
+// Veritabanını değiştirmez ve migration kilidini almaz; schema_migrations tablosu henüz yoksa
+// tüm migration'lar uygulanmamış olarak döner.
+func (migrator *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, loadErr := loadMigrations()
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	conn, err := migrator.dbPool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Migration için veritabanı bağlantısı alınamadı: %w", err)
+	}
+	defer conn.Release()
+
+	var tableExists bool
+	if err := conn.QueryRow(ctx, "Select to_regclass('schema_migrations') is not null").Scan(&tableExists); err != nil {
+		return nil, fmt.Errorf("schema_migrations tablosu kontrol edilemedi: %w", err)
+	}
+	applied := map[int64]time.Time{}
+	if tableExists {
+		if applied, err = appliedVersions(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+	var statuses []MigrationStatus
+	for _, migration := range migrations {
+		status := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// withLock, havuzdan tek bir bağlantı alır, advisory lock'u bu bağlantı üzerinde tutarak
+// schema_migrations tablosunu hazırlar ve verilen fonksiyonu çalıştırır.
+func (migrator *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := migrator.dbPool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("Migration için veritabanı bağlantısı alınamadı: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "Select pg_advisory_lock($1)", migrationLockId); err != nil {
+		return fmt.Errorf("Migration kilidi alınamadı: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), "Select pg_advisory_unlock($1)", migrationLockId); err != nil {
+			log.Errorf("Migration kilidi bırakılamadı: %v", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, createSchemaMigrationsSql); err != nil {
+		return fmt.Errorf("schema_migrations tablosu oluşturulamadı: %w", err)
+	}
+	return fn(conn)
+}
+
+// appliedVersions, uygulanmış migration sürümlerini uygulanma zamanlarıyla birlikte döner.
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.Query(ctx, "Select version, applied_at from schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("Uygulanmış migration'lar alınamadı: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// loadMigrations, gömülü SQL dosyalarını okuyarak sürüm sırasına dizilmiş migration listesini oluşturur.
+// Her sürüm için hem up hem down dosyası bulunmalıdır.
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+	migrationsByVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("Geçersiz migration dosya adı: %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := migrationFiles.ReadFile("sql/" + entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := migrationsByVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrationsByVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("%d sürümlü migration dosyalarının adları uyuşmuyor", version)
+		}
+		if match[3] == "up" {
+			migration.UpSql = string(content)
+		} else {
+			migration.DownSql = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range migrationsByVersion {
+		if len(migration.UpSql) == 0 || len(migration.DownSql) == 0 {
+			return nil, fmt.Errorf("%d_%s migration'ının up ve down dosyaları birlikte bulunmalıdır", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
@@ -0,0 +1,8 @@
+create table if not exists products
+(
+  id bigserial not null primary key,
+  name varchar(255) not null,
+  price double precision not null,
+  discount double precision,
+  store varchar(255) not null
+);
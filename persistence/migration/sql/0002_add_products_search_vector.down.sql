@@ -0,0 +1,3 @@
+drop index if exists products_search_vector_idx;
+
+alter table products drop column if exists search_vector;
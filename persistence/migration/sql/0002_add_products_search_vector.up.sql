@@ -0,0 +1,6 @@
+-- Türkçe büyük/küçük harf dönüşümü için önce 'I' -> 'ı' ve 'İ' -> 'i' çevrilir, ardından
+-- Türkçe kök bulma (stemming) yapan 'turkish' yapılandırması kullanılır.
+alter table products add column if not exists search_vector tsvector
+  generated always as (to_tsvector('turkish', lower(translate(name, 'Iİ', 'ıi')))) stored;
+
+create index if not exists products_search_vector_idx on products using gin (search_vector);
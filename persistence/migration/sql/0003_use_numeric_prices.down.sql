@@ -0,0 +1,5 @@
+alter table products
+  alter column discount drop not null,
+  alter column discount drop default,
+  alter column discount type double precision using discount::double precision,
+  alter column price type double precision using price::double precision;
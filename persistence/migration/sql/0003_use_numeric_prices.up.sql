@@ -0,0 +1,9 @@
+-- Fiyat ve indirim, kayan nokta hatalarını önlemek için iki ondalık basamaklı NUMERIC olarak tutulur.
+-- Mevcut değerler kuruşa yuvarlanır; indirimi olmayan ürünlerde indirim 0 kabul edilir.
+update products set discount = 0 where discount is null;
+
+alter table products
+  alter column price type numeric(14, 2) using round(price::numeric, 2),
+  alter column discount type numeric(5, 2) using round(discount::numeric, 2),
+  alter column discount set default 0,
+  alter column discount set not null;
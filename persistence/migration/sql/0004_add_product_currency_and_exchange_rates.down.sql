@@ -0,0 +1,3 @@
+drop table if exists exchange_rates;
+
+alter table products drop column if exists currency;
@@ -0,0 +1,12 @@
+-- Her ürün kendi para biriminde fiyatlandırılır; mevcut ürünler TRY kabul edilir.
+alter table products add column if not exists currency char(3) not null default 'TRY';
+
+-- Kaynak para biriminin bir biriminin hedef para birimindeki karşılığı.
+create table if not exists exchange_rates
+(
+  source_currency char(3) not null,
+  target_currency char(3) not null,
+  rate numeric(18, 8) not null check (rate > 0),
+  updated_at timestamptz not null default now(),
+  primary key (source_currency, target_currency)
+);
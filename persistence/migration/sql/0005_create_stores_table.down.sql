@@ -0,0 +1,9 @@
+alter table products add column if not exists store varchar(255);
+
+update products p set store = s.name from stores s where s.id = p.store_id;
+
+alter table products alter column store set not null;
+
+alter table products drop column if exists store_id;
+
+drop table if exists stores;
@@ -0,0 +1,26 @@
+create table if not exists stores
+(
+  id bigserial not null primary key,
+  name varchar(255) not null
+);
+
+-- "ABC TECH" ve "ABC Tech" gibi yalnızca harf büyüklüğü farklı adlar aynı mağazayı gösterir.
+-- Türkçe büyük/küçük harf dönüşümü için önce 'I' -> 'ı' ve 'İ' -> 'i' çevrilir.
+create unique index if not exists stores_name_key on stores (lower(translate(name, 'Iİ', 'ıi')));
+
+-- Ürünlerdeki mağaza adlarından mağazalar oluşturulur; aynı mağazanın farklı yazımlarından biri seçilir.
+insert into stores (name)
+select min(trim(store)) from products group by lower(translate(trim(store), 'Iİ', 'ıi'))
+on conflict do nothing;
+
+alter table products add column if not exists store_id bigint references stores (id);
+
+update products p set store_id = s.id
+from stores s
+where lower(translate(trim(p.store), 'Iİ', 'ıi')) = lower(translate(s.name, 'Iİ', 'ıi'));
+
+alter table products alter column store_id set not null;
+
+alter table products drop column if exists store;
+
+create index if not exists products_store_id_idx on products (store_id);
@@ -0,0 +1,3 @@
+drop table if exists product_categories;
+
+drop table if exists categories;
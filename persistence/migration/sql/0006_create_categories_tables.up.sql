@@ -0,0 +1,23 @@
+-- Kategoriler bir ağaç oluşturur; parent_id boş olan kategoriler köktür.
+create table if not exists categories
+(
+  id bigserial not null primary key,
+  name varchar(255) not null,
+  parent_id bigint references categories (id)
+);
+
+-- Aynı üst kategorinin altında yalnızca harf büyüklüğü farklı iki kategori olamaz.
+create unique index if not exists categories_parent_name_key
+  on categories (coalesce(parent_id, 0), lower(translate(name, 'Iİ', 'ıi')));
+
+create index if not exists categories_parent_id_idx on categories (parent_id);
+
+-- Ürünler ile kategoriler arasındaki çoka çok ilişki.
+create table if not exists product_categories
+(
+  product_id bigint not null references products (id) on delete cascade,
+  category_id bigint not null references categories (id),
+  primary key (product_id, category_id)
+);
+
+create index if not exists product_categories_category_id_idx on product_categories (category_id);
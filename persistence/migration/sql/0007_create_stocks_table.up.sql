@@ -0,0 +1,14 @@
+-- Her ürünün her mağazadaki stok miktarı. Ayrılan (reserved) miktar toplam miktarı aşamaz.
+create table if not exists stocks
+(
+  product_id bigint not null references products (id) on delete cascade,
+  store_id bigint not null references stores (id),
+  quantity integer not null default 0 check (quantity >= 0),
+  reserved integer not null default 0 check (reserved >= 0),
+  low_stock_threshold integer not null default 0 check (low_stock_threshold >= 0),
+  updated_at timestamptz not null default now(),
+  primary key (product_id, store_id),
+  check (reserved <= quantity)
+);
+
+create index if not exists stocks_store_id_idx on stocks (store_id);
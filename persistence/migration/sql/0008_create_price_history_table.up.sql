@@ -0,0 +1,14 @@
+-- Ürün fiyatındaki her değişiklik, değişikliği yapan kişiyle birlikte kaydedilir.
+create table if not exists price_history
+(
+  id bigserial not null primary key,
+  product_id bigint not null references products (id) on delete cascade,
+  old_price numeric(14, 2) not null,
+  old_currency char(3) not null,
+  new_price numeric(14, 2) not null,
+  new_currency char(3) not null,
+  changed_at timestamptz not null default now(),
+  actor varchar(255) not null
+);
+
+create index if not exists price_history_product_id_changed_at_idx on price_history (product_id, changed_at);
@@ -0,0 +1,5 @@
+drop table if exists campaign_stores;
+
+drop table if exists campaign_products;
+
+drop table if exists campaigns;
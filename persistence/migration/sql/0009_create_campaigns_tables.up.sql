@@ -0,0 +1,28 @@
+-- Belirli bir zaman aralığında seçili ürünlere veya mağazalara uygulanan indirim kampanyaları.
+-- Aynı ürüne birden fazla kampanya uyarsa önceliği en yüksek olan uygulanır.
+create table if not exists campaigns
+(
+  id bigserial not null primary key,
+  name varchar(255) not null,
+  discount numeric(5, 2) not null check (discount > 0 and discount <= 100),
+  starts_at timestamptz not null,
+  ends_at timestamptz not null,
+  priority integer not null default 0,
+  check (ends_at > starts_at)
+);
+
+create index if not exists campaigns_period_idx on campaigns (starts_at, ends_at);
+
+create table if not exists campaign_products
+(
+  campaign_id bigint not null references campaigns (id) on delete cascade,
+  product_id bigint not null references products (id) on delete cascade,
+  primary key (campaign_id, product_id)
+);
+
+create table if not exists campaign_stores
+(
+  campaign_id bigint not null references campaigns (id) on delete cascade,
+  store_id bigint not null references stores (id) on delete cascade,
+  primary key (campaign_id, store_id)
+);
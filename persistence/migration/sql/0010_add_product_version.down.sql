@@ -0,0 +1 @@
+alter table products drop column if exists version;
@@ -0,0 +1,2 @@
+-- İyimser eşzamanlılık denetimi için ürünün sürümü; ürün her güncellendiğinde bir artar.
+alter table products add column if not exists version bigint not null default 1;
@@ -0,0 +1,3 @@
+drop index if exists products_deleted_at_idx;
+
+alter table products drop column if exists deleted_at;
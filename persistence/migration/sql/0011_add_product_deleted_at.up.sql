@@ -0,0 +1,4 @@
+-- Silinen ürünler çöp kutusunda tutulur; silinme zamanı boş olmayan ürünler listelenmez.
+alter table products add column if not exists deleted_at timestamptz;
+
+create index if not exists products_deleted_at_idx on products (deleted_at) where deleted_at is not null;
@@ -0,0 +1,15 @@
+-- Idempotency-Key başlığıyla gelen isteklerin yanıtları saklanır; aynı anahtarla tekrarlanan
+-- istekler yeniden işlenmez, kaydedilen yanıt aynen döndürülür. Yanıtı henüz olmayan anahtarlar
+-- işlenmekte olan isteklere aittir.
+create table if not exists idempotency_keys
+(
+  key varchar(255) not null primary key,
+  request_hash char(64) not null,
+  status_code integer,
+  response_header jsonb,
+  response_body bytea,
+  created_at timestamptz not null default now(),
+  expires_at timestamptz not null
+);
+
+create index if not exists idempotency_keys_expires_at_idx on idempotency_keys (expires_at);
@@ -0,0 +1,5 @@
+-- İşlenmekte olan bir anahtar yalnızca locked_until zamanına kadar kilitli kalır. Süreç yanıtı
+-- kaydetmeden sonlanırsa, anahtar kilidin süresi dolduktan sonra aynı istekle yeniden ayrılabilir.
+alter table idempotency_keys add column if not exists locked_until timestamptz;
+
+update idempotency_keys set locked_until = created_at where status_code is null;
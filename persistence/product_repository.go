@@ -4,163 +4,827 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/labstack/gommon/log"
+	"product-app/common/money"
+	"product-app/common/text"
 	"product-app/domain"
 	"product-app/persistence/common"
+	"strings"
+	"time"
 )
 
 // IProductRepository, ürünlerle ilgili CRUD işlemlerini tanımlayan arayüzdür.
 type IProductRepository interface {
-	GetAllProducts() []domain.Product                        // Tüm ürünleri getirir.
-	GetAllProductsByStore(storeName string) []domain.Product // Belirli bir mağazaya ait ürünleri getirir.
-	AddProduct(product domain.Product) error                 // Yeni bir ürün ekler.
-	GetById(productId int64) (domain.Product, error)         // Belirli bir ID'ye sahip ürünü getirir.
-	DeleteById(productId int64) error                        // Belirli bir ID'ye sahip ürünü siler.
-	UpdatePrice(productId int64, newPrice float32) error     // Ürünün fiyatını günceller.
+	GetAllProducts(ctx context.Context) ([]domain.Product, error)                       // Tüm ürünleri getirir.
+	GetAllProductsByStore(ctx context.Context, storeId int64) ([]domain.Product, error) // Belirli bir mağazaya ait ürünleri getirir.
+	AddProduct(ctx context.Context, product domain.Product) (domain.Product, error)     // Yeni bir ürün ekler ve ID'si atanmış hâlini döner.
+	GetById(ctx context.Context, productId int64) (domain.Product, error)               // Belirli bir ID'ye sahip ürünü getirir.
+	// DeleteById, ürünü çöp kutusuna taşır. version domain.AnyVersion değilse ürün yalnızca o sürümdeyse silinir.
+	DeleteById(ctx context.Context, productId int64, version int64) error
+	GetDeletedProducts(ctx context.Context) ([]domain.Product, error) // Çöp kutusundaki ürünleri en son silinenden başlayarak getirir.
+	RestoreById(ctx context.Context, productId int64) error           // Çöp kutusundaki ürünü geri yükler.
+	// PurgeDeleted, verilen zamandan önce silinmiş ürünleri kalıcı olarak siler ve silinen ürün sayısını döner.
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// UpdatePrice, ürünün fiyatını günceller. version domain.AnyVersion değilse ürün yalnızca o sürümdeyse güncellenir.
+	UpdatePrice(ctx context.Context, productId int64, newPrice money.Decimal, version int64) error
+	// UpdateProduct, ürünün tüm alanlarını günceller. product.Version domain.AnyVersion değilse sürüm denetlenir.
+	UpdateProduct(ctx context.Context, product domain.Product) error
+	FindProducts(ctx context.Context, query domain.ProductQuery) (domain.ProductPage, error) // Ürünleri filtreleyip sıralayarak sayfa sayfa getirir.
+	Search(ctx context.Context, searchText string, limit int) ([]domain.Product, error)      // Ürün adlarında tam metin araması yapar.
+	// GetPriceHistory, ürünün fiyat değişikliklerini tarih aralığına göre en yeniden eskiye getirir.
+	GetPriceHistory(ctx context.Context, productId int64, query domain.PriceHistoryQuery) ([]domain.PriceChange, error)
+	// ImportProducts, ürünleri tek bir transaction içinde toplu olarak ekler ve eklenen satır sayısını döner.
+	ImportProducts(ctx context.Context, products []domain.Product) (int64, error)
+	// ExecuteBatch, ekleme, fiyat güncelleme ve silme işlemlerini tek transaction içinde uygular ve her işlemin sonucunu döner.
+	ExecuteBatch(ctx context.Context, operations []domain.ProductOperation, atomic bool) ([]domain.ProductOperationResult, error)
+	// ExportProducts, filtrelere uyan ürünleri belleğe toplamadan sırayla handle fonksiyonuna verir.
+	ExportProducts(ctx context.Context, query domain.ProductQuery, handle func(product domain.Product) error) error
+}
+
+// productColumns, ürün sorgularında okunan kolonları extractProductsFromRows ile aynı sırada listeler.
+const productColumns = "p.id, p.name, p.price, p.currency, p.discount, p.store_id, s.name, p.version, p.deleted_at"
+
+// productTables, ürünleri mağaza adlarıyla birlikte okumak için kullanılan tablo ifadesidir.
+const productTables = "products p join stores s on s.id = p.store_id"
+
+// notDeletedCondition, çöp kutusundaki ürünleri sorgu sonuçlarının dışında bırakır.
+const notDeletedCondition = "p.deleted_at is null"
+
+// categoryTreeCondition, ürünün verilen kategoride veya onun herhangi bir alt kategorisinde olmasını şart koşar.
+// Alt kategoriler özyinelemeli bir CTE ile bulunur; %s yerine kategori ID'si parametresi gelir.
+// Tekrarlanan satırları eleyen union, hatalı veride bir döngü olsa bile sorgunun bitmesini sağlar.
+const categoryTreeCondition = `exists (
+	with recursive category_tree as (
+		select id from categories where id = %s
+		union
+		select c.id from categories c join category_tree t on c.parent_id = t.id
+	)
+	select 1 from product_categories pc
+	where pc.product_id = p.id and pc.category_id in (select id from category_tree)
+)`
+
+// inStockCondition, ürünün en az bir mağazada satılabilir stoğu olmasını şart koşar.
+const inStockCondition = "exists (select 1 from stocks st where st.product_id = p.id and st.quantity > st.reserved)"
+
+// productExportBatchSize, dışa aktarmada imleçten tek seferde okunan ürün sayısıdır.
+const productExportBatchSize = 500
+
+// productSortColumns, sıralama alanlarını veritabanı kolonlarına eşler.
+var productSortColumns = map[string]string{
+	domain.SortFieldId:       "p.id",
+	domain.SortFieldName:     "p.name",
+	domain.SortFieldPrice:    "p.price",
+	domain.SortFieldDiscount: "p.discount",
+	domain.SortFieldStore:    "s.name",
 }
 
 // ProductRepository, IProductRepository arayüzünü uygulayan yapıdır.
 type ProductRepository struct {
-	dbPool *pgxpool.Pool // PostgreSQL bağlantı havuzunu temsil eder.
+	db            DB            // Sorguların çalıştırıldığı bağlantı havuzu veya unit of work transaction'ı.
+	queryTimeout  time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+	exportTimeout time.Duration // Dışa aktarmanın tamamı için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
 }
 
 // NewProductRepository, yeni bir ProductRepository örneği oluşturur.
-func NewProductRepository(dbPool *pgxpool.Pool) IProductRepository {
+func NewProductRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration, exportTimeout time.Duration) IProductRepository {
 	return &ProductRepository{
-		dbPool: dbPool,
+		db:            dbPool,
+		queryTimeout:  queryTimeout,
+		exportTimeout: exportTimeout,
 	}
 }
 
+// withTimeout, çağıranın bağlamına sorgu zaman aşımını ekler.
+// Dönen cancel fonksiyonu sorgu sonuçları okunduktan sonra çağrılmalıdır.
+func (productRepository *ProductRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withQueryTimeout(ctx, productRepository.queryTimeout)
+}
+
+// withQueryTimeout, bağlama verilen sorgu zaman aşımını ekler; süre sıfırsa yalnızca iptal edilebilir yapar.
+func withQueryTimeout(ctx context.Context, queryTimeout time.Duration) (context.Context, context.CancelFunc) {
+	if queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, queryTimeout)
+}
+
 // GetAllProducts, tüm ürünleri veritabanından getirir.
-func (productRepository *ProductRepository) GetAllProducts() []domain.Product {
-	ctx := context.Background()
-	productRows, err := productRepository.dbPool.Query(ctx, "Select * from products")
+func (productRepository *ProductRepository) GetAllProducts(ctx context.Context) ([]domain.Product, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+	productRows, err := productRepository.db.Query(ctx, "Select "+productColumns+" from "+productTables+" where "+notDeletedCondition)
 
 	if err != nil {
-		log.Error("Tüm ürünler alınırken hata oluştu: %v", err)
-		return []domain.Product{}
+		return nil, common.TranslateError(err, "Tüm ürünler alınırken hata oluştu")
 	}
 	return extractProductsFromRows(productRows)
 }
 
 // GetAllProductsByStore, belirli bir mağazaya ait ürünleri getirir.
-func (productRepository *ProductRepository) GetAllProductsByStore(storeName string) []domain.Product {
-	ctx := context.Background()
+func (productRepository *ProductRepository) GetAllProductsByStore(ctx context.Context, storeId int64) ([]domain.Product, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
 
-	getProductsByStoreNameSql := `Select * from products where store = $1`
+	getProductsByStoreSql := "Select " + productColumns + " from " + productTables + " where p.store_id = $1 and " + notDeletedCondition
 
-	productRows, err := productRepository.dbPool.Query(ctx, getProductsByStoreNameSql, storeName)
+	productRows, err := productRepository.db.Query(ctx, getProductsByStoreSql, storeId)
 
 	if err != nil {
-		log.Error("Belirli bir mağazanın ürünleri alınırken hata oluştu: %v", err)
-		return []domain.Product{}
+		return nil, common.TranslateError(err, "Belirli bir mağazanın ürünleri alınırken hata oluştu")
 	}
 	return extractProductsFromRows(productRows)
 }
 
-// AddProduct, yeni bir ürünü veritabanına ekler.
-func (productRepository *ProductRepository) AddProduct(product domain.Product) error {
-	ctx := context.Background()
+// AddProduct, yeni bir ürünü veritabanına ekler ve veritabanının atadığı ID ve sürümle birlikte döner.
+func (productRepository *ProductRepository) AddProduct(ctx context.Context, product domain.Product) (domain.Product, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
 
-	insert_sql := `Insert into products (name,price,discount,store) VALUES ($1,$2,$3,$4)`
+	insert_sql := `Insert into products (name,price,currency,discount,store_id) VALUES ($1,$2,$3,$4,$5) returning id, version`
 
-	addNewProduct, err := productRepository.dbPool.Exec(ctx, insert_sql, product.Name, product.Price, product.Discount, product.Store)
+	err := productRepository.db.QueryRow(ctx, insert_sql,
+		product.Name, product.Price.Amount, string(product.Price.Currency), product.Discount, product.StoreId).
+		Scan(&product.Id, &product.Version)
 
 	if err != nil {
-		log.Error("Yeni ürün eklenirken hata oluştu", err)
-		return err
+		return domain.Product{}, common.TranslateError(err, "Yeni ürün eklenirken hata oluştu")
 	}
-	log.Info(fmt.Printf("Ürün eklendi: %v", addNewProduct))
-	return nil
+	log.Infof("Ürün eklendi: %d", product.Id)
+	return product, nil
+}
+
+// ImportProducts, ürünleri PostgreSQL COPY protokolüyle tek bir transaction içinde ekler.
+// Satırlardan biri eklenemezse hiçbir ürün eklenmez.
+func (productRepository *ProductRepository) ImportProducts(ctx context.Context, products []domain.Product) (int64, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	var importedRows int64
+	err := productRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		var copyErr error
+		importedRows, copyErr = tx.CopyFrom(ctx, pgx.Identifier{"products"},
+			[]string{"name", "price", "currency", "discount", "store_id"},
+			pgx.CopyFromSlice(len(products), func(index int) ([]interface{}, error) {
+				product := products[index]
+				price, priceErr := toNumeric(product.Price.Amount)
+				if priceErr != nil {
+					return nil, priceErr
+				}
+				discount, discountErr := toNumeric(product.Discount)
+				if discountErr != nil {
+					return nil, discountErr
+				}
+				return []interface{}{product.Name, price, string(product.Price.Currency), discount, product.StoreId}, nil
+			}))
+		return copyErr
+	})
+	if err != nil {
+		return 0, common.TranslateError(err, fmt.Sprintf("%d ürün toplu olarak eklenemedi", len(products)))
+	}
+	log.Infof("%d ürün toplu olarak eklendi", importedRows)
+	return importedRows, nil
+}
+
+// errBatchAborted, atomik modda bir işlem başarısız olduğunda transaction'ı geri almak için kullanılır.
+var errBatchAborted = errors.New("toplu işlem geri alındı")
+
+// ExecuteBatch, işlemleri tek bir transaction içinde sırayla uygular ve her işlemin sonucunu aynı sırayla döner.
+// Her işlem kendi savepoint'inde çalışır; başarısız olan işlem geri alınır. Atomik modda ilk başarısız
+// işlemde transaction tamamen geri alınır ve diğer işlemler BatchStatusRolledBack olarak işaretlenir.
+// Veritabanına ulaşılamazsa toplu işlem yarıda kesilir ve hata döner.
+func (productRepository *ProductRepository) ExecuteBatch(ctx context.Context, operations []domain.ProductOperation, atomic bool) ([]domain.ProductOperationResult, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	results := make([]domain.ProductOperationResult, len(operations))
+	err := productRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		for index, operation := range operations {
+			productId := operation.ProductId
+			operationErr := tx.BeginFunc(ctx, func(savepoint pgx.Tx) error {
+				var executeErr error
+				productId, executeErr = executeProductOperation(ctx, savepoint, operation)
+				return executeErr
+			})
+			if operationErr == nil {
+				results[index] = domain.ProductOperationResult{Status: domain.BatchStatusSucceeded, ProductId: productId}
+				continue
+			}
+			if !errors.Is(operationErr, domain.ErrNotFound) {
+				operationErr = common.TranslateError(operationErr, productOperationErrorMessage(operation))
+			}
+			if errors.Is(operationErr, domain.ErrUnavailable) {
+				return operationErr
+			}
+			results[index] = domain.ProductOperationResult{Status: domain.BatchStatusFailed, ProductId: operation.ProductId, Err: operationErr}
+			if atomic {
+				return errBatchAborted
+			}
+		}
+		return nil
+	})
+
+	if errors.Is(err, errBatchAborted) {
+		for index := range results {
+			if results[index].Status != domain.BatchStatusFailed {
+				results[index] = domain.ProductOperationResult{Status: domain.BatchStatusRolledBack, ProductId: operations[index].ProductId}
+			}
+		}
+		log.Infof("%d işlemlik toplu ürün işlemi geri alındı", len(operations))
+		return results, nil
+	}
+	if errors.Is(err, domain.ErrUnavailable) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, common.TranslateError(err, fmt.Sprintf("%d işlemlik toplu ürün işlemi uygulanamadı", len(operations)))
+	}
+	log.Infof("%d işlemlik toplu ürün işlemi uygulandı", len(operations))
+	return results, nil
+}
+
+// executeProductOperation, tek bir toplu işlemi verilen transaction içinde uygular ve ürünün ID'sini döner.
+func executeProductOperation(ctx context.Context, tx pgx.Tx, operation domain.ProductOperation) (int64, error) {
+	switch operation.Type {
+	case domain.BatchOperationCreate:
+		product := operation.Product
+		var productId int64
+		err := tx.QueryRow(ctx, `Insert into products (name,price,currency,discount,store_id) VALUES ($1,$2,$3,$4,$5) returning id`,
+			product.Name, product.Price.Amount, string(product.Price.Currency), product.Discount, product.StoreId).Scan(&productId)
+		return productId, err
+	case domain.BatchOperationUpdatePrice:
+		return operation.ProductId, updatePriceInTx(ctx, tx, operation.ProductId, operation.NewPrice, domain.AnyVersion)
+	case domain.BatchOperationDelete:
+		commandTag, err := tx.Exec(ctx, `Update products set deleted_at = now(), version = version + 1
+			where id = $1 and deleted_at is null`, operation.ProductId)
+		if err == nil && commandTag.RowsAffected() == 0 {
+			return operation.ProductId, domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan ürün bulunamadı", operation.ProductId))
+		}
+		return operation.ProductId, err
+	}
+	return 0, fmt.Errorf("desteklenmeyen toplu işlem türü: %s", operation.Type)
 }
 
-// extractProductsFromRows, ürün bilgilerini pgx.Rows nesnesinden çıkarır.
-func extractProductsFromRows(productRows pgx.Rows) []domain.Product {
+// productOperationErrorMessage, başarısız bir toplu işlem için log ve hata mesajını oluşturur.
+func productOperationErrorMessage(operation domain.ProductOperation) string {
+	switch operation.Type {
+	case domain.BatchOperationUpdatePrice:
+		return fmt.Sprintf("ID'si %d olan ürünün fiyatı güncellenirken hata oluştu", operation.ProductId)
+	case domain.BatchOperationDelete:
+		return fmt.Sprintf("ID'si %d olan ürün silinirken hata oluştu", operation.ProductId)
+	}
+	return "Yeni ürün eklenirken hata oluştu"
+}
+
+// toNumeric, COPY protokolü değerleri ikili biçimde gönderdiği için ondalık değeri pgtype.Numeric'e çevirir.
+func toNumeric(value money.Decimal) (pgtype.Numeric, error) {
+	var numeric pgtype.Numeric
+	err := numeric.Set(value.String())
+	return numeric, err
+}
+
+// extractProductsFromRows, ürün bilgilerini pgx.Rows nesnesinden çıkarır ve satırları kapatır.
+func extractProductsFromRows(productRows pgx.Rows) ([]domain.Product, error) {
+	defer productRows.Close()
+
 	var products = []domain.Product{}
 	var id int64
 	var name string
-	var price float32
-	var discount float32
+	var price money.Decimal
+	var currency string
+	var discount money.Decimal
+	var storeId int64
 	var store string
+	var version int64
+	var deletedAt *time.Time
 
 	for productRows.Next() {
-		productRows.Scan(&id, &name, &price, &discount, &store)
+		if scanErr := productRows.Scan(&id, &name, &price, &currency, &discount, &storeId, &store, &version, &deletedAt); scanErr != nil {
+			return nil, common.TranslateError(scanErr, "Ürün satırı okunurken hata oluştu")
+		}
 		products = append(products, domain.Product{
-			Id:       id,
-			Name:     name,
-			Price:    price,
-			Discount: discount,
-			Store:    store,
+			Id:        id,
+			Name:      name,
+			Price:     money.New(price, money.Currency(currency)),
+			Discount:  discount,
+			StoreId:   storeId,
+			Store:     store,
+			Version:   version,
+			DeletedAt: deletedAt,
 		})
 	}
-	return products
+	if rowsErr := productRows.Err(); rowsErr != nil {
+		return nil, common.TranslateError(rowsErr, "Ürünler okunurken hata oluştu")
+	}
+	return products, nil
 }
 
 // GetById, belirli bir ID'ye sahip ürünü veritabanından getirir.
-func (productRepository *ProductRepository) GetById(productId int64) (domain.Product, error) {
-	ctx := context.Background()
+func (productRepository *ProductRepository) GetById(ctx context.Context, productId int64) (domain.Product, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
 
-	getByIdSql := `Select * from products where id = $1`
+	getByIdSql := "Select " + productColumns + " from " + productTables + " where p.id = $1 and " + notDeletedCondition
 
-	queryRow := productRepository.dbPool.QueryRow(ctx, getByIdSql, productId)
+	queryRow := productRepository.db.QueryRow(ctx, getByIdSql, productId)
 
 	var id int64
 	var name string
-	var price float32
-	var discount float32
+	var price money.Decimal
+	var currency string
+	var discount money.Decimal
+	var storeId int64
 	var store string
+	var version int64
+	var deletedAt *time.Time
 
-	scanErr := queryRow.Scan(&id, &name, &price, &discount, &store)
+	scanErr := queryRow.Scan(&id, &name, &price, &currency, &discount, &storeId, &store, &version, &deletedAt)
 
-	if scanErr != nil && scanErr.Error() == common.NOT_FOUND {
-		return domain.Product{}, errors.New(fmt.Sprintf("ID'si %d olan ürün bulunamadı", productId))
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return domain.Product{}, domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan ürün bulunamadı", productId))
 	}
 	if scanErr != nil {
-		return domain.Product{}, errors.New(fmt.Sprintf("ID'si %d olan ürün alınırken hata oluştu", productId))
+		return domain.Product{}, common.TranslateError(scanErr, fmt.Sprintf("ID'si %d olan ürün alınırken hata oluştu", productId))
 	}
 
 	return domain.Product{
-		Id:       id,
-		Name:     name,
-		Price:    price,
-		Discount: discount,
-		Store:    store,
+		Id:        id,
+		Name:      name,
+		Price:     money.New(price, money.Currency(currency)),
+		Discount:  discount,
+		StoreId:   storeId,
+		Store:     store,
+		Version:   version,
+		DeletedAt: deletedAt,
 	}, nil
 }
 
-// DeleteById, belirli bir ID'ye sahip ürünü veritabanından siler.
-func (productRepository *ProductRepository) DeleteById(productId int64) error {
-	ctx := context.Background()
+// DeleteById, belirli bir ID'ye sahip ürünü silinme zamanını işaretleyerek çöp kutusuna taşır.
+// Ürün PurgeDeleted ile kalıcı olarak silinene kadar RestoreById ile geri yüklenebilir.
+// version domain.AnyVersion değilse silme koşulludur; ürün başka bir sürümdeyse ErrPreconditionFailed döner.
+func (productRepository *ProductRepository) DeleteById(ctx context.Context, productId int64, version int64) error {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	deleteSql := `Update products set deleted_at = now(), version = version + 1
+		where id = $1 and ($2::bigint = 0 or version = $2)`
+
+	// Satır önce kilitlenir; böylece varlık kontrolü ile silme arasında başka bir işlem araya giremez.
+	err := productRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, lockErr := lockProductPrice(ctx, tx, productId); lockErr != nil {
+			return lockErr
+		}
+		commandTag, deleteErr := tx.Exec(ctx, deleteSql, productId, version)
+		if deleteErr != nil {
+			return deleteErr
+		}
+		if commandTag.RowsAffected() == 0 {
+			return versionMismatchError(productId)
+		}
+		return nil
+	})
+
+	if isDomainError(err) {
+		return err
+	}
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürün silinirken hata oluştu", productId))
+	}
+	log.Infof("Ürün %d çöp kutusuna taşındı", productId)
+	return nil
+}
+
+// GetDeletedProducts, çöp kutusundaki ürünleri en son silinenden başlayarak getirir.
+func (productRepository *ProductRepository) GetDeletedProducts(ctx context.Context) ([]domain.Product, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
 
-	_, getErr := productRepository.GetById(productId)
+	getDeletedSql := "Select " + productColumns + " from " + productTables + " where p.deleted_at is not null order by p.deleted_at desc, p.id"
 
-	if getErr != nil {
-		return errors.New("Ürün bulunamadı")
+	productRows, err := productRepository.db.Query(ctx, getDeletedSql)
+	if err != nil {
+		return nil, common.TranslateError(err, "Çöp kutusundaki ürünler alınırken hata oluştu")
 	}
+	return extractProductsFromRows(productRows)
+}
 
-	deleteSql := `Delete from products where id = $1`
+// RestoreById, çöp kutusundaki ürünün silinme işaretini kaldırır ve sürümünü artırır.
+// Ürün yoksa veya silinmemişse ErrNotFound döner.
+func (productRepository *ProductRepository) RestoreById(ctx context.Context, productId int64) error {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
 
-	_, err := productRepository.dbPool.Exec(ctx, deleteSql, productId)
+	restoreSql := `Update products set deleted_at = null, version = version + 1 where id = $1 and deleted_at is not null`
+
+	commandTag, err := productRepository.db.Exec(ctx, restoreSql, productId)
 	if err != nil {
-		return errors.New(fmt.Sprintf("ID'si %d olan ürün silinirken hata oluştu", productId))
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürün geri yüklenirken hata oluştu", productId))
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan ürün çöp kutusunda bulunamadı", productId))
 	}
-	log.Info("Ürün silindi")
+	log.Infof("Ürün %d çöp kutusundan geri yüklendi", productId)
 	return nil
 }
 
+// PurgeDeleted, deletedBefore zamanından önce çöp kutusuna taşınmış ürünleri kalıcı olarak siler.
+// Ürüne bağlı stok, kategori, kampanya ve fiyat geçmişi kayıtları da cascade ile silinir.
+func (productRepository *ProductRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	commandTag, err := productRepository.db.Exec(ctx, `Delete from products where deleted_at < $1`, deletedBefore)
+	if err != nil {
+		return 0, common.TranslateError(err, "Çöp kutusundaki ürünler kalıcı olarak silinirken hata oluştu")
+	}
+	if commandTag.RowsAffected() > 0 {
+		log.Infof("Çöp kutusundaki %d ürün kalıcı olarak silindi", commandTag.RowsAffected())
+	}
+	return commandTag.RowsAffected(), nil
+}
+
 // UpdatePrice, belirli bir ID'ye sahip ürünün fiyatını günceller.
-func (productRepository *ProductRepository) UpdatePrice(productId int64, newPrice float32) error {
-	ctx := context.Background()
+// Fiyat değiştiyse eski ve yeni fiyat aynı transaction içinde fiyat geçmişine yazılır.
+// version domain.AnyVersion değilse güncelleme koşulludur; ürün başka bir sürümdeyse ErrPreconditionFailed döner.
+func (productRepository *ProductRepository) UpdatePrice(ctx context.Context, productId int64, newPrice money.Decimal, version int64) error {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
 
-	updateSql := `Update products set price = $1 where id = $2`
+	err := productRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		return updatePriceInTx(ctx, tx, productId, newPrice, version)
+	})
 
-	_, err := productRepository.dbPool.Exec(ctx, updateSql, newPrice, productId)
+	if isDomainError(err) {
+		return err
+	}
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürünün fiyatı güncellenirken hata oluştu", productId))
+	}
+	log.Infof("Ürün %d fiyatı %v olarak güncellendi", productId, newPrice)
+	return nil
+}
 
+// UpdateProduct, belirli bir ID'ye sahip ürünün tüm alanlarını verilen değerlerle değiştirir.
+// Fiyat veya para birimi değiştiyse değişiklik aynı transaction içinde fiyat geçmişine yazılır.
+// product.Version domain.AnyVersion değilse güncelleme koşulludur; ürün başka bir sürümdeyse ErrPreconditionFailed döner.
+func (productRepository *ProductRepository) UpdateProduct(ctx context.Context, product domain.Product) error {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	updateSql := `Update products set name = $1, price = $2, currency = $3, discount = $4, store_id = $5, version = version + 1
+		where id = $6 and ($7::bigint = 0 or version = $7)`
+
+	err := productRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		oldPrice, lockErr := lockProductPrice(ctx, tx, product.Id)
+		if lockErr != nil {
+			return lockErr
+		}
+		commandTag, updateErr := tx.Exec(ctx, updateSql,
+			product.Name, product.Price.Amount, string(product.Price.Currency), product.Discount, product.StoreId, product.Id, product.Version)
+		if updateErr != nil {
+			return updateErr
+		}
+		if commandTag.RowsAffected() == 0 {
+			return versionMismatchError(product.Id)
+		}
+		return recordPriceChange(ctx, tx, product.Id, oldPrice, product.Price)
+	})
+
+	if isDomainError(err) {
+		return err
+	}
 	if err != nil {
-		return errors.New(fmt.Sprintf("ID'si %d olan ürünün fiyatı güncellenirken hata oluştu", productId))
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürün güncellenirken hata oluştu", product.Id))
 	}
-	log.Info("Ürün %d fiyatı %v olarak güncellendi", productId, newPrice)
+	log.Infof("Ürün %d güncellendi", product.Id)
 	return nil
 }
+
+// GetPriceHistory, ürünün fiyat değişikliklerini en yeniden eskiye doğru getirir.
+func (productRepository *ProductRepository) GetPriceHistory(ctx context.Context, productId int64, query domain.PriceHistoryQuery) ([]domain.PriceChange, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	conditions := []string{"product_id = $1"}
+	args := []interface{}{productId}
+	if query.From != nil {
+		args = append(args, *query.From)
+		conditions = append(conditions, fmt.Sprintf("changed_at >= $%d", len(args)))
+	}
+	if query.To != nil {
+		args = append(args, *query.To)
+		conditions = append(conditions, fmt.Sprintf("changed_at < $%d", len(args)))
+	}
+	historySql := "Select id, product_id, old_price, old_currency, new_price, new_currency, changed_at, actor from price_history" +
+		whereClause(conditions) + " order by changed_at desc, id desc"
+
+	historyRows, err := productRepository.db.Query(ctx, historySql, args...)
+	if err != nil {
+		return nil, common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürünün fiyat geçmişi alınırken hata oluştu", productId))
+	}
+	defer historyRows.Close()
+
+	var priceChanges = []domain.PriceChange{}
+	for historyRows.Next() {
+		var priceChange domain.PriceChange
+		var oldPrice, newPrice money.Decimal
+		var oldCurrency, newCurrency string
+		scanErr := historyRows.Scan(&priceChange.Id, &priceChange.ProductId, &oldPrice, &oldCurrency,
+			&newPrice, &newCurrency, &priceChange.ChangedAt, &priceChange.Actor)
+		if scanErr != nil {
+			return nil, common.TranslateError(scanErr, "Fiyat geçmişi satırı okunurken hata oluştu")
+		}
+		priceChange.OldPrice = money.New(oldPrice, money.Currency(oldCurrency))
+		priceChange.NewPrice = money.New(newPrice, money.Currency(newCurrency))
+		priceChanges = append(priceChanges, priceChange)
+	}
+	if rowsErr := historyRows.Err(); rowsErr != nil {
+		return nil, common.TranslateError(rowsErr, "Fiyat geçmişi okunurken hata oluştu")
+	}
+	return priceChanges, nil
+}
+
+// lockProductPrice, ürün satırını transaction sonuna kadar kilitler ve mevcut fiyatını döner.
+func lockProductPrice(ctx context.Context, tx pgx.Tx, productId int64) (money.Money, error) {
+	var price money.Decimal
+	var currency string
+	scanErr := tx.QueryRow(ctx, "Select price, currency from products where id = $1 and deleted_at is null for update", productId).Scan(&price, &currency)
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return money.Money{}, domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan ürün bulunamadı", productId))
+	}
+	if scanErr != nil {
+		return money.Money{}, scanErr
+	}
+	return money.New(price, money.Currency(currency)), nil
+}
+
+// updatePriceInTx, ürün satırını kilitleyip fiyatını günceller ve değişikliği fiyat geçmişine yazar.
+// version domain.AnyVersion değilse ürün yalnızca o sürümdeyse güncellenir.
+func updatePriceInTx(ctx context.Context, tx pgx.Tx, productId int64, newPrice money.Decimal, version int64) error {
+	oldPrice, lockErr := lockProductPrice(ctx, tx, productId)
+	if lockErr != nil {
+		return lockErr
+	}
+	updateSql := `Update products set price = $1, version = version + 1 where id = $2 and ($3::bigint = 0 or version = $3)`
+	commandTag, updateErr := tx.Exec(ctx, updateSql, newPrice, productId, version)
+	if updateErr != nil {
+		return updateErr
+	}
+	if commandTag.RowsAffected() == 0 {
+		return versionMismatchError(productId)
+	}
+	return recordPriceChange(ctx, tx, productId, oldPrice, money.New(newPrice, oldPrice.Currency))
+}
+
+// versionMismatchError, koşullu güncelleme veya silme, ürün başka bir sürümde olduğu için uygulanamadığında döner.
+func versionMismatchError(productId int64) error {
+	return domain.NewPreconditionFailedError(fmt.Sprintf("ID'si %d olan ürün, beklenen sürümden sonra değiştirilmiş", productId))
+}
+
+// isDomainError, transaction içinden dönen hatanın zaten bir domain hatası olup olmadığını kontrol eder.
+func isDomainError(err error) bool {
+	var domainErr *domain.Error
+	return errors.As(err, &domainErr)
+}
+
+// recordPriceChange, fiyat veya para birimi değiştiyse değişikliği bağlamdaki aktörle birlikte fiyat geçmişine yazar.
+func recordPriceChange(ctx context.Context, tx pgx.Tx, productId int64, oldPrice money.Money, newPrice money.Money) error {
+	if oldPrice.Amount.Cmp(newPrice.Amount) == 0 && oldPrice.Currency == newPrice.Currency {
+		return nil
+	}
+	insertSql := `Insert into price_history (product_id, old_price, old_currency, new_price, new_currency, actor)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.Exec(ctx, insertSql, productId, oldPrice.Amount, string(oldPrice.Currency),
+		newPrice.Amount, string(newPrice.Currency), domain.ActorFromContext(ctx))
+	return err
+}
+
+// FindProducts, filtreleri, sıralamayı ve sayfalamayı SQL sorgusuna uygulayarak ürünleri getirir.
+// İmleç verilmişse, imlecin gösterdiği üründen sonraki kayıtlar keyset sayfalama ile alınır.
+func (productRepository *ProductRepository) FindProducts(ctx context.Context, query domain.ProductQuery) (domain.ProductPage, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	var args []interface{}
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := productFilterConditions(query, addArg)
+
+	// Toplam kayıt sayısı imleçten bağımsız olarak yalnızca filtrelere göre hesaplanır.
+	countSql := "Select count(*) from " + productTables + whereClause(conditions)
+	var totalCount int64
+	countErr := productRepository.db.QueryRow(ctx, countSql, args...).Scan(&totalCount)
+	if countErr != nil {
+		return domain.ProductPage{}, common.TranslateError(countErr, "Ürünler listelenirken hata oluştu")
+	}
+
+	if len(query.Cursor) > 0 {
+		cursorProduct, cursorErr := domain.DecodeProductCursor(query.Cursor, query.Sort)
+		if cursorErr != nil {
+			return domain.ProductPage{}, cursorErr
+		}
+		conditions = append(conditions, keysetCondition(query.Sort, cursorProduct, addArg))
+	}
+
+	// Bir sonraki sayfanın olup olmadığını anlamak için limitten bir fazla kayıt istenir.
+	selectSql := "Select " + productColumns + " from " + productTables + whereClause(conditions) +
+		orderByClause(query.Sort) +
+		" limit " + addArg(query.Limit+1) + " offset " + addArg(query.Offset)
+
+	productRows, err := productRepository.db.Query(ctx, selectSql, args...)
+	if err != nil {
+		return domain.ProductPage{}, common.TranslateError(err, "Ürünler listelenirken hata oluştu")
+	}
+	products, extractErr := extractProductsFromRows(productRows)
+	if extractErr != nil {
+		return domain.ProductPage{}, extractErr
+	}
+
+	page := domain.ProductPage{
+		Products:   products,
+		TotalCount: totalCount,
+	}
+	if len(products) > query.Limit {
+		page.Products = products[:query.Limit]
+		page.NextCursor = domain.EncodeProductCursor(page.Products[query.Limit-1], query.Sort)
+	}
+	return page, nil
+}
+
+// ExportProducts, listeleme filtrelerine uyan tüm ürünleri sunucu tarafı bir imleçle
+// productExportBatchSize'lık gruplar hâlinde okur ve her ürün için handle fonksiyonunu çağırır.
+// Böylece ürünlerin tamamı belleğe alınmaz. Sayfalama alanları (limit, offset, cursor) dikkate alınmaz.
+// handle bir hata dönerse okuma durdurulur ve o hata olduğu gibi döner.
+// Sorgu zaman aşımı her okuma grubuna ayrı ayrı, exportTimeout ise handle çağrıları dahil dışa aktarmanın tamamına uygulanır.
+// İstemci bağlantıyı keser veya süre dolarsa transaction geri alınır ve imleç kapanır.
+func (productRepository *ProductRepository) ExportProducts(ctx context.Context, query domain.ProductQuery, handle func(product domain.Product) error) error {
+	var args []interface{}
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := productFilterConditions(query, addArg)
+	selectSql := "Select " + productColumns + " from " + productTables + whereClause(conditions) + orderByClause(query.Sort)
+
+	exportCtx, cancelExport := withQueryTimeout(ctx, productRepository.exportTimeout)
+	defer cancelExport()
+	tx, err := productRepository.db.Begin(exportCtx)
+	if err != nil {
+		return common.TranslateError(err, "Ürünler dışa aktarılırken hata oluştu")
+	}
+	defer func() {
+		// Dışa aktarma yalnızca okuduğundan transaction her durumda geri alınır; imleç de onunla kapanır.
+		// İstemci bağlantıyı kesmiş veya süre dolmuş olsa da geri alma çalışsın diye iptal edilmeyen bir bağlam kullanılır.
+		// Yarıda kesilen bir sorgu bağlantıyı kapattıysa PostgreSQL transaction'ı zaten geri almıştır.
+		rollbackCtx, cancelRollback := productRepository.withTimeout(context.WithoutCancel(ctx))
+		defer cancelRollback()
+		if rollbackErr := tx.Rollback(rollbackCtx); rollbackErr != nil && exportCtx.Err() == nil {
+			log.Errorf("Dışa aktarma transaction'ı geri alınamadı: %v", rollbackErr)
+		}
+	}()
+
+	declareCtx, cancel := productRepository.withTimeout(exportCtx)
+	_, declareErr := tx.Exec(declareCtx, "Declare product_export no scroll cursor for "+selectSql, args...)
+	cancel()
+	if declareErr != nil {
+		return common.TranslateError(declareErr, "Ürünler dışa aktarılırken hata oluştu")
+	}
+	fetchSql := fmt.Sprintf("Fetch %d from product_export", productExportBatchSize)
+	for {
+		fetchCtx, cancel := productRepository.withTimeout(exportCtx)
+		productRows, fetchErr := tx.Query(fetchCtx, fetchSql)
+		if fetchErr != nil {
+			cancel()
+			return common.TranslateError(fetchErr, "Ürünler dışa aktarılırken hata oluştu")
+		}
+		products, extractErr := extractProductsFromRows(productRows)
+		cancel()
+		if extractErr != nil {
+			return common.TranslateError(extractErr, "Ürünler dışa aktarılırken hata oluştu")
+		}
+		for _, product := range products {
+			if handleErr := handle(product); handleErr != nil {
+				return handleErr
+			}
+		}
+		if len(products) < productExportBatchSize {
+			return nil
+		}
+	}
+}
+
+// productFilterConditions, listeleme sorgusundaki filtreleri where koşullarına çevirir.
+// Parametreler addArg ile eklenir ve yer tutucuları döner.
+func productFilterConditions(query domain.ProductQuery, addArg func(value interface{}) string) []string {
+	conditions := []string{notDeletedCondition}
+	if query.MinPrice != nil {
+		conditions = append(conditions, "p.price >= "+addArg(*query.MinPrice))
+	}
+	if query.MaxPrice != nil {
+		conditions = append(conditions, "p.price <= "+addArg(*query.MaxPrice))
+	}
+	if query.MinDiscount != nil {
+		conditions = append(conditions, "p.discount >= "+addArg(*query.MinDiscount))
+	}
+	if query.StoreId != 0 {
+		conditions = append(conditions, "p.store_id = "+addArg(query.StoreId))
+	}
+	if query.CategoryId != 0 {
+		conditions = append(conditions, fmt.Sprintf(categoryTreeCondition, addArg(query.CategoryId)))
+	}
+	if query.InStock != nil {
+		if *query.InStock {
+			conditions = append(conditions, inStockCondition)
+		} else {
+			conditions = append(conditions, "not "+inStockCondition)
+		}
+	}
+	return conditions
+}
+
+// orderByClause, sıralama alanlarını bir order by ifadesine çevirir.
+func orderByClause(sort []domain.SortField) string {
+	var orderBy []string
+	for _, sortField := range sort {
+		direction := "asc"
+		if sortField.Descending {
+			direction = "desc"
+		}
+		orderBy = append(orderBy, productSortColumns[sortField.Field]+" "+direction)
+	}
+	return " order by " + strings.Join(orderBy, ", ")
+}
+
+// whereClause, verilen koşulları "and" ile birleştirerek bir where ifadesi oluşturur.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " where " + strings.Join(conditions, " and ")
+}
+
+// keysetCondition, sıralamaya göre imleçteki üründen sonra gelen kayıtları seçen koşulu oluşturur.
+// Örneğin "price asc, name desc" için: (price > $1) or (price = $1 and name < $2).
+func keysetCondition(sort []domain.SortField, cursorProduct domain.Product, addArg func(value interface{}) string) string {
+	var alternatives []string
+	var equalities []string
+	for _, sortField := range sort {
+		column := productSortColumns[sortField.Field]
+		placeholder := addArg(sortValue(cursorProduct, sortField.Field))
+		operator := ">"
+		if sortField.Descending {
+			operator = "<"
+		}
+		alternative := append(append([]string{}, equalities...), column+" "+operator+" "+placeholder)
+		alternatives = append(alternatives, "("+strings.Join(alternative, " and ")+")")
+		equalities = append(equalities, column+" = "+placeholder)
+	}
+	return "(" + strings.Join(alternatives, " or ") + ")"
+}
+
+// sortValue, ürünün verilen sıralama alanındaki değerini döner.
+func sortValue(product domain.Product, field string) interface{} {
+	switch field {
+	case domain.SortFieldName:
+		return product.Name
+	case domain.SortFieldPrice:
+		return product.Price.Amount
+	case domain.SortFieldDiscount:
+		return product.Discount
+	case domain.SortFieldStore:
+		return product.Store
+	default:
+		return product.Id
+	}
+}
+
+// Search, ürün adlarında PostgreSQL tam metin araması yapar ve sonuçları ilgiye göre sıralar.
+// Arama metni Türkçe kurallarla normalize edilir; her kelime önek olarak aranır ve
+// tüm kelimelerin eşleşmesi gerekir.
+func (productRepository *ProductRepository) Search(ctx context.Context, searchText string, limit int) ([]domain.Product, error) {
+	ctx, cancel := productRepository.withTimeout(ctx)
+	defer cancel()
+
+	tokens := text.Tokenize(searchText)
+	if len(tokens) == 0 {
+		return []domain.Product{}, nil
+	}
+	for i, token := range tokens {
+		tokens[i] = token + ":*"
+	}
+
+	searchSql := "Select " + productColumns + " from " + productTables + `, to_tsquery('turkish', $1) query
+		where p.search_vector @@ query and p.deleted_at is null
+		order by ts_rank(p.search_vector, query) desc, p.id
+		limit $2`
+
+	productRows, err := productRepository.db.Query(ctx, searchSql, strings.Join(tokens, " & "), limit)
+	if err != nil {
+		return nil, common.TranslateError(err, "Ürün araması yapılırken hata oluştu")
+	}
+	return extractProductsFromRows(productRows)
+}
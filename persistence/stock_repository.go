@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/labstack/gommon/log"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"time"
+)
+
+// IStockRepository, ürünlerin mağaza bazındaki stoklarıyla ilgili işlemleri tanımlayan arayüzdür.
+type IStockRepository interface {
+	GetStocksOfProduct(ctx context.Context, productId int64) ([]domain.Stock, error) // Ürünün tüm mağazalardaki stoklarını getirir.
+	GetLowStocks(ctx context.Context) ([]domain.Stock, error)                        // Satılabilir miktarı eşiğe inmiş stokları getirir.
+	// ModifyStock, stok satırını kilitleyip modify fonksiyonuyla değiştirir ve sonucu aynı transaction'da kaydeder.
+	ModifyStock(ctx context.Context, productId int64, storeId int64, modify func(stock *domain.Stock) error) (domain.Stock, error)
+}
+
+// StockRepository, IStockRepository arayüzünü uygulayan yapıdır.
+type StockRepository struct {
+	db           DB            // Sorguların çalıştırıldığı bağlantı havuzu veya unit of work transaction'ı.
+	queryTimeout time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+}
+
+// NewStockRepository, yeni bir StockRepository örneği oluşturur.
+func NewStockRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration) IStockRepository {
+	return &StockRepository{
+		db:           dbPool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// stockColumns, stok sorgularında okunan kolonları extractStocksFromRows ile aynı sırada listeler.
+const stockColumns = "product_id, store_id, quantity, reserved, low_stock_threshold"
+
+// GetStocksOfProduct, ürünün tüm mağazalardaki stoklarını mağaza ID'sine göre sıralı getirir.
+func (stockRepository *StockRepository) GetStocksOfProduct(ctx context.Context, productId int64) ([]domain.Stock, error) {
+	ctx, cancel := withQueryTimeout(ctx, stockRepository.queryTimeout)
+	defer cancel()
+
+	stockRows, err := stockRepository.db.Query(ctx,
+		"Select "+stockColumns+" from stocks where product_id = $1 order by store_id", productId)
+	if err != nil {
+		return nil, common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürünün stokları alınırken hata oluştu", productId))
+	}
+	return extractStocksFromRows(stockRows)
+}
+
+// GetLowStocks, satılabilir miktarı düşük stok eşiğine veya altına inmiş stokları getirir.
+func (stockRepository *StockRepository) GetLowStocks(ctx context.Context) ([]domain.Stock, error) {
+	ctx, cancel := withQueryTimeout(ctx, stockRepository.queryTimeout)
+	defer cancel()
+
+	stockRows, err := stockRepository.db.Query(ctx,
+		"Select "+stockColumns+" from stocks where quantity - reserved <= low_stock_threshold order by product_id, store_id")
+	if err != nil {
+		return nil, common.TranslateError(err, "Düşük stoklar alınırken hata oluştu")
+	}
+	return extractStocksFromRows(stockRows)
+}
+
+// ModifyStock, stok satırını "SELECT ... FOR UPDATE" ile kilitler, modify fonksiyonunu uygular ve
+// sonucu aynı transaction içinde kaydeder. Böylece eşzamanlı ayırma işlemleri aynı stoğu iki kez satamaz.
+// Satır yoksa önce "on conflict do nothing" ile sıfır stokla eklenir; böylece eşzamanlı ilk ayırmalar da aynı satırı kilitler.
+// modify hata dönerse transaction geri alınır ve hata olduğu gibi döner.
+func (stockRepository *StockRepository) ModifyStock(ctx context.Context, productId int64, storeId int64, modify func(stock *domain.Stock) error) (domain.Stock, error) {
+	ctx, cancel := withQueryTimeout(ctx, stockRepository.queryTimeout)
+	defer cancel()
+
+	var modifyErr error
+	var stock domain.Stock
+	err := stockRepository.db.BeginFunc(ctx, func(tx pgx.Tx) error {
+		insertSql := "Insert into stocks (product_id, store_id) VALUES ($1, $2) on conflict (product_id, store_id) do nothing"
+		if _, insertErr := tx.Exec(ctx, insertSql, productId, storeId); insertErr != nil {
+			return insertErr
+		}
+		stock = domain.Stock{ProductId: productId, StoreId: storeId}
+		selectSql := "Select quantity, reserved, low_stock_threshold from stocks where product_id = $1 and store_id = $2 for update"
+		if scanErr := tx.QueryRow(ctx, selectSql, productId, storeId).Scan(&stock.Quantity, &stock.Reserved, &stock.LowStockThreshold); scanErr != nil {
+			return scanErr
+		}
+		if modifyErr = modify(&stock); modifyErr != nil {
+			return modifyErr
+		}
+		updateSql := `Update stocks set quantity = $1, reserved = $2, low_stock_threshold = $3, updated_at = now()
+			where product_id = $4 and store_id = $5`
+		_, updateErr := tx.Exec(ctx, updateSql, stock.Quantity, stock.Reserved, stock.LowStockThreshold, productId, storeId)
+		return updateErr
+	})
+	if modifyErr != nil {
+		return domain.Stock{}, modifyErr
+	}
+	if err != nil {
+		return domain.Stock{}, common.TranslateError(err, fmt.Sprintf("ID'si %d olan ürünün %d numaralı mağazadaki stoğu güncellenemedi", productId, storeId))
+	}
+	log.Infof("Ürün %d için mağaza %d stoğu güncellendi", productId, storeId)
+	return stock, nil
+}
+
+// extractStocksFromRows, sorgu satırlarını stok listesine dönüştürür ve satırları kapatır.
+func extractStocksFromRows(stockRows pgx.Rows) ([]domain.Stock, error) {
+	defer stockRows.Close()
+
+	var stocks = []domain.Stock{}
+	for stockRows.Next() {
+		var stock domain.Stock
+		if scanErr := stockRows.Scan(&stock.ProductId, &stock.StoreId, &stock.Quantity, &stock.Reserved, &stock.LowStockThreshold); scanErr != nil {
+			return nil, common.TranslateError(scanErr, "Stok satırı okunurken hata oluştu")
+		}
+		stocks = append(stocks, stock)
+	}
+	if rowsErr := stockRows.Err(); rowsErr != nil {
+		return nil, common.TranslateError(rowsErr, "Stoklar okunurken hata oluştu")
+	}
+	return stocks, nil
+}
@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/labstack/gommon/log"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"time"
+)
+
+// IStoreRepository, mağazalarla ilgili CRUD işlemlerini tanımlayan arayüzdür.
+type IStoreRepository interface {
+	GetAllStores(ctx context.Context) ([]domain.Store, error)               // Tüm mağazaları ada göre sıralı getirir.
+	GetById(ctx context.Context, storeId int64) (domain.Store, error)       // Belirli bir ID'ye sahip mağazayı getirir.
+	AddStore(ctx context.Context, store domain.Store) (domain.Store, error) // Yeni bir mağaza ekler ve ID'si atanmış hâlini döner.
+	UpdateStore(ctx context.Context, store domain.Store) error              // Mağazanın adını değiştirir.
+	DeleteById(ctx context.Context, storeId int64) error                    // Ürünü olmayan bir mağazayı siler.
+}
+
+// StoreRepository, IStoreRepository arayüzünü uygulayan yapıdır.
+type StoreRepository struct {
+	db           DB            // Sorguların çalıştırıldığı bağlantı havuzu veya unit of work transaction'ı.
+	queryTimeout time.Duration // Her sorgu için azami süre; sıfır ise yalnızca çağıranın bağlamı geçerlidir.
+}
+
+// NewStoreRepository, yeni bir StoreRepository örneği oluşturur.
+func NewStoreRepository(dbPool *pgxpool.Pool, queryTimeout time.Duration) IStoreRepository {
+	return &StoreRepository{
+		db:           dbPool,
+		queryTimeout: queryTimeout,
+	}
+}
+
+// GetAllStores, tüm mağazaları ada göre sıralı olarak getirir.
+func (storeRepository *StoreRepository) GetAllStores(ctx context.Context) ([]domain.Store, error) {
+	ctx, cancel := withQueryTimeout(ctx, storeRepository.queryTimeout)
+	defer cancel()
+
+	storeRows, err := storeRepository.db.Query(ctx, "Select id, name from stores order by name, id")
+	if err != nil {
+		return nil, common.TranslateError(err, "Tüm mağazalar alınırken hata oluştu")
+	}
+	defer storeRows.Close()
+
+	var stores = []domain.Store{}
+	for storeRows.Next() {
+		var store domain.Store
+		if scanErr := storeRows.Scan(&store.Id, &store.Name); scanErr != nil {
+			return nil, common.TranslateError(scanErr, "Mağaza satırı okunurken hata oluştu")
+		}
+		stores = append(stores, store)
+	}
+	if rowsErr := storeRows.Err(); rowsErr != nil {
+		return nil, common.TranslateError(rowsErr, "Mağazalar okunurken hata oluştu")
+	}
+	return stores, nil
+}
+
+// GetById, belirli bir ID'ye sahip mağazayı getirir.
+func (storeRepository *StoreRepository) GetById(ctx context.Context, storeId int64) (domain.Store, error) {
+	ctx, cancel := withQueryTimeout(ctx, storeRepository.queryTimeout)
+	defer cancel()
+
+	var store domain.Store
+	scanErr := storeRepository.db.QueryRow(ctx, "Select id, name from stores where id = $1", storeId).Scan(&store.Id, &store.Name)
+
+	if errors.Is(scanErr, pgx.ErrNoRows) {
+		return domain.Store{}, domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan mağaza bulunamadı", storeId))
+	}
+	if scanErr != nil {
+		return domain.Store{}, common.TranslateError(scanErr, fmt.Sprintf("ID'si %d olan mağaza alınırken hata oluştu", storeId))
+	}
+	return store, nil
+}
+
+// AddStore, yeni bir mağaza ekler. Aynı adda bir mağaza varsa ErrConflict döner.
+func (storeRepository *StoreRepository) AddStore(ctx context.Context, store domain.Store) (domain.Store, error) {
+	ctx, cancel := withQueryTimeout(ctx, storeRepository.queryTimeout)
+	defer cancel()
+
+	insertSql := `Insert into stores (name) VALUES ($1) returning id`
+
+	if err := storeRepository.db.QueryRow(ctx, insertSql, store.Name).Scan(&store.Id); err != nil {
+		return domain.Store{}, common.TranslateError(err, fmt.Sprintf("%s adlı mağaza eklenemedi", store.Name))
+	}
+	log.Infof("Mağaza eklendi: %d", store.Id)
+	return store, nil
+}
+
+// UpdateStore, mağazanın adını değiştirir. Yeni ad başka bir mağazada kullanılıyorsa ErrConflict döner.
+func (storeRepository *StoreRepository) UpdateStore(ctx context.Context, store domain.Store) error {
+	ctx, cancel := withQueryTimeout(ctx, storeRepository.queryTimeout)
+	defer cancel()
+
+	commandTag, err := storeRepository.db.Exec(ctx, `Update stores set name = $1 where id = $2`, store.Name, store.Id)
+
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan mağaza güncellenemedi", store.Id))
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan mağaza bulunamadı", store.Id))
+	}
+	log.Infof("Mağaza %d güncellendi", store.Id)
+	return nil
+}
+
+// DeleteById, belirli bir ID'ye sahip mağazayı siler. Mağazaya bağlı ürünler varsa ErrConflict döner.
+func (storeRepository *StoreRepository) DeleteById(ctx context.Context, storeId int64) error {
+	ctx, cancel := withQueryTimeout(ctx, storeRepository.queryTimeout)
+	defer cancel()
+
+	commandTag, err := storeRepository.db.Exec(ctx, `Delete from stores where id = $1`, storeId)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == common.ForeignKeyViolationCode {
+		return domain.NewConflictError(fmt.Sprintf("ID'si %d olan mağazaya bağlı ürünler olduğu için silinemez", storeId), err)
+	}
+	if err != nil {
+		return common.TranslateError(err, fmt.Sprintf("ID'si %d olan mağaza silinirken hata oluştu", storeId))
+	}
+	if commandTag.RowsAffected() == 0 {
+		return domain.NewNotFoundError(fmt.Sprintf("ID'si %d olan mağaza bulunamadı", storeId))
+	}
+	log.Infof("Mağaza %d silindi", storeId)
+	return nil
+}
@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"product-app/common/postgresql"
+	"product-app/persistence/common"
+	"time"
+)
+
+// txRetryBackoff, serileştirme hatasından sonraki ilk yeniden denemeden önceki beklemedir.
+const txRetryBackoff = 20 * time.Millisecond
+
+// DB, repository'lerin sorgu çalıştırdığı bağlantıdır. *pgxpool.Pool ve pgx.Tx bu arayüzü
+// sağlar; transaction üzerinde BeginFunc yeni bir transaction yerine savepoint açar.
+type DB interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+	BeginFunc(ctx context.Context, f func(pgx.Tx) error) error
+}
+
+// Repositories, aynı transaction'a bağlı repository'leri bir arada taşır.
+type Repositories struct {
+	Products   IProductRepository
+	Stores     IStoreRepository
+	Categories ICategoryRepository
+	Stocks     IStockRepository
+	Campaigns  ICampaignRepository
+}
+
+// TxOptions, unit of work transaction'ının yalıtım düzeyini ve yeniden deneme sayısını belirler.
+type TxOptions struct {
+	IsolationLevel postgresql.IsolationLevel // Transaction'ın yalıtım düzeyi; boşsa ReadCommitted kullanılır.
+	MaxRetries     int                       // Serileştirme hatası veya kilitlenmede en fazla yeniden deneme sayısı.
+}
+
+// IUnitOfWork, birden fazla repository çağrısını tek bir transaction içinde çalıştıran arayüzdür.
+type IUnitOfWork interface {
+	// WithTx, fn fonksiyonunu varsayılan ayarlarla açılan bir transaction'a bağlı repository'lerle çalıştırır.
+	// fn hata dönerse transaction geri alınır, aksi halde onaylanır.
+	WithTx(ctx context.Context, fn func(repositories Repositories) error) error
+	// WithTxOptions, WithTx gibi çalışır ancak transaction ayarlarını çağıran belirler.
+	WithTxOptions(ctx context.Context, options TxOptions, fn func(repositories Repositories) error) error
+}
+
+// UnitOfWork, IUnitOfWork arayüzünü pgx transaction'larıyla uygulayan yapıdır.
+type UnitOfWork struct {
+	dbPool         *pgxpool.Pool // PostgreSQL bağlantı havuzunu temsil eder.
+	queryTimeout   time.Duration // Transaction içindeki her sorgu için azami süre.
+	defaultOptions TxOptions     // WithTx ile açılan transaction'ların ayarları.
+}
+
+// NewUnitOfWork, yeni bir UnitOfWork örneği oluşturur.
+func NewUnitOfWork(dbPool *pgxpool.Pool, queryTimeout time.Duration, defaultOptions TxOptions) IUnitOfWork {
+	return &UnitOfWork{
+		dbPool:         dbPool,
+		queryTimeout:   queryTimeout,
+		defaultOptions: defaultOptions,
+	}
+}
+
+// WithTx, fn fonksiyonunu varsayılan transaction ayarlarıyla çalıştırır.
+func (unitOfWork *UnitOfWork) WithTx(ctx context.Context, fn func(repositories Repositories) error) error {
+	return unitOfWork.WithTxOptions(ctx, unitOfWork.defaultOptions, fn)
+}
+
+// WithTxOptions, fn fonksiyonunu verilen ayarlarla açılan transaction içinde çalıştırır.
+// Transaction serileştirme hatası veya kilitlenme ile geri alınırsa fn baştan çalıştırılır;
+// bu yüzden fn transaction dışında kalıcı yan etki bırakmamalıdır.
+func (unitOfWork *UnitOfWork) WithTxOptions(ctx context.Context, options TxOptions, fn func(repositories Repositories) error) error {
+	txOptions := pgx.TxOptions{IsoLevel: options.IsolationLevel.TxIsoLevel()}
+
+	err := common.RetryTx(ctx, options.MaxRetries, txRetryBackoff, func() error {
+		return unitOfWork.dbPool.BeginTxFunc(ctx, txOptions, func(tx pgx.Tx) error {
+			return fn(unitOfWork.repositories(tx))
+		})
+	})
+
+	if isDomainError(err) {
+		return err
+	}
+	if err != nil {
+		return common.TranslateError(err, "Transaction tamamlanırken hata oluştu")
+	}
+	return nil
+}
+
+// repositories, verilen transaction üzerinde çalışan repository'leri oluşturur.
+func (unitOfWork *UnitOfWork) repositories(tx pgx.Tx) Repositories {
+	return Repositories{
+		Products:   &ProductRepository{db: tx, queryTimeout: unitOfWork.queryTimeout},
+		Stores:     &StoreRepository{db: tx, queryTimeout: unitOfWork.queryTimeout},
+		Categories: &CategoryRepository{db: tx, queryTimeout: unitOfWork.queryTimeout},
+		Stocks:     &StockRepository{db: tx, queryTimeout: unitOfWork.queryTimeout},
+		Campaigns:  &CampaignRepository{db: tx, queryTimeout: unitOfWork.queryTimeout},
+	}
+}
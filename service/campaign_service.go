@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/persistence"
+	"product-app/service/model"
+	"product-app/service/validation"
+	"strings"
+	"time"
+)
+
+// MaxCampaignNameLength, kampanya adının en fazla karakter sayısıdır.
+// Kampanya indirimi, ürüne uygulanan oranla aynı MaxDiscount sınırına tabidir.
+const MaxCampaignNameLength = 255
+
+// ICampaignService, indirim kampanyalarıyla ilgili servis işlemleri için bir arayüzdür.
+type ICampaignService interface {
+	GetAllCampaigns(ctx context.Context) ([]domain.Campaign, error)
+	GetActiveCampaigns(ctx context.Context) ([]domain.Campaign, error)
+	GetById(ctx context.Context, campaignId int64) (domain.Campaign, error)
+	Add(ctx context.Context, campaignCreate model.CampaignCreate) (domain.Campaign, error)
+	Update(ctx context.Context, campaignId int64, campaignUpdate model.CampaignUpdate) error
+	DeleteById(ctx context.Context, campaignId int64) error
+}
+
+// CampaignService, ICampaignService arayüzünü uygulayan yapıdır.
+type CampaignService struct {
+	campaignRepository persistence.ICampaignRepository
+	productRepository  persistence.IProductRepository // Hedef ürünlerin varlığını denetlemek için kullanılır.
+	storeRepository    persistence.IStoreRepository   // Hedef mağazaların varlığını denetlemek için kullanılır.
+}
+
+// Yeni bir CampaignService oluşturur ve gerekli repository'leri alır.
+func NewCampaignService(campaignRepository persistence.ICampaignRepository, productRepository persistence.IProductRepository,
+	storeRepository persistence.IStoreRepository) ICampaignService {
+	return &CampaignService{
+		campaignRepository: campaignRepository,
+		productRepository:  productRepository,
+		storeRepository:    storeRepository,
+	}
+}
+
+// Tüm kampanyaları başlangıç zamanına göre sıralı getirir.
+func (campaignService *CampaignService) GetAllCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	return campaignService.campaignRepository.GetAllCampaigns(ctx)
+}
+
+// Şu anda geçerli olan kampanyaları getirir.
+func (campaignService *CampaignService) GetActiveCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	return campaignService.campaignRepository.GetActiveCampaigns(ctx, time.Now())
+}
+
+// Belirli bir ID'ye sahip kampanyayı getirir.
+func (campaignService *CampaignService) GetById(ctx context.Context, campaignId int64) (domain.Campaign, error) {
+	return campaignService.campaignRepository.GetById(ctx, campaignId)
+}
+
+// Yeni bir kampanya ekler ve ID'si atanmış kampanyayı döner.
+func (campaignService *CampaignService) Add(ctx context.Context, campaignCreate model.CampaignCreate) (domain.Campaign, error) {
+	campaign, validateErr := campaignService.validateCampaign(ctx, domain.Campaign{
+		Name:       strings.TrimSpace(campaignCreate.Name),
+		Discount:   campaignCreate.Discount,
+		StartsAt:   campaignCreate.StartsAt,
+		EndsAt:     campaignCreate.EndsAt,
+		Priority:   campaignCreate.Priority,
+		ProductIds: campaignCreate.ProductIds,
+		StoreIds:   campaignCreate.StoreIds,
+	})
+	if validateErr != nil {
+		return domain.Campaign{}, validateErr
+	}
+	return campaignService.campaignRepository.AddCampaign(ctx, campaign)
+}
+
+// Kampanyanın tüm alanlarını ve hedeflerini verilen değerlerle değiştirir.
+func (campaignService *CampaignService) Update(ctx context.Context, campaignId int64, campaignUpdate model.CampaignUpdate) error {
+	if _, getErr := campaignService.campaignRepository.GetById(ctx, campaignId); getErr != nil {
+		return getErr
+	}
+	campaign, validateErr := campaignService.validateCampaign(ctx, domain.Campaign{
+		Id:         campaignId,
+		Name:       strings.TrimSpace(campaignUpdate.Name),
+		Discount:   campaignUpdate.Discount,
+		StartsAt:   campaignUpdate.StartsAt,
+		EndsAt:     campaignUpdate.EndsAt,
+		Priority:   campaignUpdate.Priority,
+		ProductIds: campaignUpdate.ProductIds,
+		StoreIds:   campaignUpdate.StoreIds,
+	})
+	if validateErr != nil {
+		return validateErr
+	}
+	return campaignService.campaignRepository.UpdateCampaign(ctx, campaign)
+}
+
+// Belirli bir ID'ye sahip kampanyayı siler.
+func (campaignService *CampaignService) DeleteById(ctx context.Context, campaignId int64) error {
+	return campaignService.campaignRepository.DeleteById(ctx, campaignId)
+}
+
+// Kaydedilecek kampanyanın alanları ve hedefleri doğrulanır, bulunan bütün hatalar birlikte döner.
+// Tekrarlanan hedef ID'leri bir kez tutulur; olmayan ürün ve mağazalar için doğrulama hatası döner.
+func (campaignService *CampaignService) validateCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error) {
+	validator := validation.New()
+
+	validator.Required("name", campaign.Name, "Name is required")
+	validator.MaxLength("name", campaign.Name, MaxCampaignNameLength, fmt.Sprintf("Name can not be longer than %d characters", MaxCampaignNameLength))
+
+	validator.Check(campaign.Discount.Sign() > 0, "discount", "Discount must be greater than 0")
+	validator.Check(campaign.Discount.Cmp(money.NewFromInt(MaxDiscount)) <= 0, "discount", fmt.Sprintf("Discount can not be greater than %d", MaxDiscount))
+	validator.MaxDecimalPlaces("discount", campaign.Discount, MaxDecimalPlaces, fmt.Sprintf("Discount can have at most %d decimal places", MaxDecimalPlaces))
+
+	validator.Check(!campaign.StartsAt.IsZero(), "startsAt", "StartsAt is required")
+	validator.Check(!campaign.EndsAt.IsZero(), "endsAt", "EndsAt is required")
+	validator.Check(campaign.StartsAt.IsZero() || campaign.EndsAt.IsZero() || campaign.EndsAt.After(campaign.StartsAt),
+		"endsAt", "EndsAt must be after startsAt")
+
+	campaign.ProductIds = uniqueIds(campaign.ProductIds)
+	campaign.StoreIds = uniqueIds(campaign.StoreIds)
+	validator.Check(len(campaign.ProductIds) > 0 || len(campaign.StoreIds) > 0, "productIds", "At least one product or store is required")
+
+	for _, productId := range campaign.ProductIds {
+		_, getErr := campaignService.productRepository.GetById(ctx, productId)
+		if errors.Is(getErr, domain.ErrNotFound) {
+			validator.Check(false, "productIds", fmt.Sprintf("Product with id %d does not exist", productId))
+		} else if getErr != nil {
+			return domain.Campaign{}, getErr
+		}
+	}
+	for _, storeId := range campaign.StoreIds {
+		_, getErr := campaignService.storeRepository.GetById(ctx, storeId)
+		if errors.Is(getErr, domain.ErrNotFound) {
+			validator.Check(false, "storeIds", fmt.Sprintf("Store with id %d does not exist", storeId))
+		} else if getErr != nil {
+			return domain.Campaign{}, getErr
+		}
+	}
+	if validateErr := validator.Err(); validateErr != nil {
+		return domain.Campaign{}, validateErr
+	}
+	return campaign, nil
+}
+
+// Verilen ID'leri ilk görülme sırasını koruyarak tekilleştirir.
+func uniqueIds(ids []int64) []int64 {
+	unique := []int64{}
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
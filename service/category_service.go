@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-app/common/postgresql"
+	"product-app/domain"
+	"product-app/persistence"
+	"product-app/service/model"
+	"product-app/service/validation"
+	"strings"
+)
+
+// MaxCategoryNameLength, kategori adının en fazla karakter sayısıdır.
+const MaxCategoryNameLength = 255
+
+// categoryMoveTxOptions, kategori güncellemesinin transaction ayarlarıdır. Eşzamanlı iki taşıma
+// (A'yı B'nin, B'yi A'nın altına) döngü kontrolünü birlikte geçemesin diye serializable çalışır.
+var categoryMoveTxOptions = persistence.TxOptions{IsolationLevel: postgresql.Serializable, MaxRetries: 3}
+
+// ICategoryService, kategori ağacı ve ürün kategorileriyle ilgili servis işlemleri için bir arayüzdür.
+type ICategoryService interface {
+	GetCategoryTree(ctx context.Context) ([]domain.CategoryNode, error)
+	GetById(ctx context.Context, categoryId int64) (domain.Category, error)
+	Add(ctx context.Context, categoryCreate model.CategoryCreate) (domain.Category, error)
+	Update(ctx context.Context, categoryId int64, categoryUpdate model.CategoryUpdate) error
+	DeleteById(ctx context.Context, categoryId int64) error
+	GetCategoriesOfProduct(ctx context.Context, productId int64) ([]domain.Category, error)
+	SetCategoriesOfProduct(ctx context.Context, productId int64, categoryIds []int64) error
+}
+
+// CategoryService, ICategoryService arayüzünü uygulayan yapıdır.
+type CategoryService struct {
+	categoryRepository persistence.ICategoryRepository
+	productRepository  persistence.IProductRepository // Kategorileri değiştirilen ürünün varlığını denetlemek için kullanılır.
+	unitOfWork         persistence.IUnitOfWork        // Döngü kontrolü ile güncellemeyi tek transaction içinde çalıştırır.
+}
+
+// Yeni bir CategoryService oluşturur ve gerekli repository'leri ve unit of work'ü alır.
+func NewCategoryService(categoryRepository persistence.ICategoryRepository, productRepository persistence.IProductRepository,
+	unitOfWork persistence.IUnitOfWork) ICategoryService {
+	return &CategoryService{
+		categoryRepository: categoryRepository,
+		productRepository:  productRepository,
+		unitOfWork:         unitOfWork,
+	}
+}
+
+// Tüm kategorileri, kök kategorilerden başlayan bir ağaç olarak getirir.
+// Her seviyedeki kategoriler ada göre sıralıdır.
+func (categoryService *CategoryService) GetCategoryTree(ctx context.Context) ([]domain.CategoryNode, error) {
+	categories, err := categoryService.categoryRepository.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	childrenOf := map[int64][]domain.Category{}
+	for _, category := range categories {
+		var parentId int64
+		if category.ParentId != nil {
+			parentId = *category.ParentId
+		}
+		childrenOf[parentId] = append(childrenOf[parentId], category)
+	}
+	return buildCategoryNodes(childrenOf, 0), nil
+}
+
+// Belirli bir ID'ye sahip kategoriyi getirir.
+func (categoryService *CategoryService) GetById(ctx context.Context, categoryId int64) (domain.Category, error) {
+	return categoryService.categoryRepository.GetById(ctx, categoryId)
+}
+
+// Yeni bir kategori ekler ve ID'si atanmış kategoriyi döner.
+// Üst kategori verilmişse var olması gerekir.
+func (categoryService *CategoryService) Add(ctx context.Context, categoryCreate model.CategoryCreate) (domain.Category, error) {
+	category := domain.Category{
+		Name:     strings.TrimSpace(categoryCreate.Name),
+		ParentId: categoryCreate.ParentId,
+	}
+	validator := validation.New()
+	addCategoryNameRules(validator, category.Name)
+	if category.ParentId != nil {
+		_, parentErr := categoryService.categoryRepository.GetById(ctx, *category.ParentId)
+		if errors.Is(parentErr, domain.ErrNotFound) {
+			validator.Check(false, "parentId", fmt.Sprintf("Parent category with id %d does not exist", *category.ParentId))
+		} else if parentErr != nil {
+			return domain.Category{}, parentErr
+		}
+	}
+	if validateErr := validator.Err(); validateErr != nil {
+		return domain.Category{}, validateErr
+	}
+	return categoryService.categoryRepository.AddCategory(ctx, category)
+}
+
+// Kategorinin adını ve üst kategorisini değiştirir.
+// Bir kategori kendisinin veya alt kategorilerinden birinin altına taşınamaz; kontrol ve güncelleme
+// aynı serializable transaction içinde yapılır.
+func (categoryService *CategoryService) Update(ctx context.Context, categoryId int64, categoryUpdate model.CategoryUpdate) error {
+	return categoryService.unitOfWork.WithTxOptions(ctx, categoryMoveTxOptions, func(repositories persistence.Repositories) error {
+		return updateCategory(ctx, repositories.Categories, categoryId, categoryUpdate)
+	})
+}
+
+// Kategoriyi döngü oluşturmayacağı doğrulandıktan sonra verilen repository ile günceller.
+func updateCategory(ctx context.Context, categoryRepository persistence.ICategoryRepository, categoryId int64, categoryUpdate model.CategoryUpdate) error {
+	if _, getErr := categoryRepository.GetById(ctx, categoryId); getErr != nil {
+		return getErr
+	}
+	category := domain.Category{
+		Id:       categoryId,
+		Name:     strings.TrimSpace(categoryUpdate.Name),
+		ParentId: categoryUpdate.ParentId,
+	}
+	validator := validation.New()
+	addCategoryNameRules(validator, category.Name)
+	if category.ParentId != nil {
+		categories, err := categoryRepository.GetAllCategories(ctx)
+		if err != nil {
+			return err
+		}
+		parentOf := map[int64]*int64{}
+		for _, existing := range categories {
+			parentOf[existing.Id] = existing.ParentId
+		}
+		if _, found := parentOf[*category.ParentId]; !found {
+			validator.Check(false, "parentId", fmt.Sprintf("Parent category with id %d does not exist", *category.ParentId))
+		} else {
+			validator.Check(!isInSubtree(parentOf, *category.ParentId, categoryId),
+				"parentId", "Category can not be moved under itself or one of its subcategories")
+		}
+	}
+	if validateErr := validator.Err(); validateErr != nil {
+		return validateErr
+	}
+	return categoryRepository.UpdateCategory(ctx, category)
+}
+
+// Alt kategorisi ve ürünü olmayan bir kategoriyi siler.
+func (categoryService *CategoryService) DeleteById(ctx context.Context, categoryId int64) error {
+	return categoryService.categoryRepository.DeleteById(ctx, categoryId)
+}
+
+// Ürünün doğrudan bağlı olduğu kategorileri getirir. Ürün yoksa domain.ErrNotFound türünde hata döner.
+func (categoryService *CategoryService) GetCategoriesOfProduct(ctx context.Context, productId int64) ([]domain.Category, error) {
+	if _, getErr := categoryService.productRepository.GetById(ctx, productId); getErr != nil {
+		return nil, getErr
+	}
+	return categoryService.categoryRepository.GetCategoriesOfProduct(ctx, productId)
+}
+
+// Ürünün kategorilerini verilen kategorilerle değiştirir; boş liste ürünün tüm kategorilerini kaldırır.
+// Tekrarlanan ID'ler bir kez kaydedilir, olmayan kategoriler için doğrulama hatası döner.
+func (categoryService *CategoryService) SetCategoriesOfProduct(ctx context.Context, productId int64, categoryIds []int64) error {
+	if _, getErr := categoryService.productRepository.GetById(ctx, productId); getErr != nil {
+		return getErr
+	}
+	categories, err := categoryService.categoryRepository.GetAllCategories(ctx)
+	if err != nil {
+		return err
+	}
+	existingIds := map[int64]bool{}
+	for _, category := range categories {
+		existingIds[category.Id] = true
+	}
+	validator := validation.New()
+	uniqueIds := []int64{}
+	seenIds := map[int64]bool{}
+	for _, categoryId := range categoryIds {
+		if seenIds[categoryId] {
+			continue
+		}
+		seenIds[categoryId] = true
+		validator.Check(existingIds[categoryId], "categoryIds", fmt.Sprintf("Category with id %d does not exist", categoryId))
+		uniqueIds = append(uniqueIds, categoryId)
+	}
+	if validateErr := validator.Err(); validateErr != nil {
+		return validateErr
+	}
+	return categoryService.categoryRepository.SetCategoriesOfProduct(ctx, productId, uniqueIds)
+}
+
+// Kategori adı için doğrulama kuralları eklenir.
+func addCategoryNameRules(validator *validation.Validator, name string) {
+	validator.Required("name", name, "Name is required")
+	validator.MaxLength("name", name, MaxCategoryNameLength, fmt.Sprintf("Name can not be longer than %d characters", MaxCategoryNameLength))
+}
+
+// Verilen üst kategoriye ait alt kategorilerden ağaç düğümleri oluşturulur.
+func buildCategoryNodes(childrenOf map[int64][]domain.Category, parentId int64) []domain.CategoryNode {
+	nodes := []domain.CategoryNode{}
+	for _, category := range childrenOf[parentId] {
+		nodes = append(nodes, domain.CategoryNode{
+			Category: category,
+			Children: buildCategoryNodes(childrenOf, category.Id),
+		})
+	}
+	return nodes
+}
+
+// categoryId kategorisinin, rootId kategorisinin kendisi veya alt kategorisi olup olmadığını döner.
+func isInSubtree(parentOf map[int64]*int64, categoryId int64, rootId int64) bool {
+	for visited := 0; visited <= len(parentOf); visited++ {
+		if categoryId == rootId {
+			return true
+		}
+		parentId := parentOf[categoryId]
+		if parentId == nil {
+			return false
+		}
+		categoryId = *parentId
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"product-app/domain"
+	"product-app/persistence"
+	"time"
+)
+
+// IIdempotencyService, Idempotency-Key başlığıyla gelen isteklerin tekrarlarını yöneten servis arayüzüdür.
+type IIdempotencyService interface {
+	Begin(ctx context.Context, key string, requestHash string) (*domain.IdempotentResponse, error)
+	Complete(ctx context.Context, key string, response domain.IdempotentResponse) error
+	Abandon(ctx context.Context, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// IdempotencyService, IIdempotencyService arayüzünü uygulayan yapıdır.
+type IdempotencyService struct {
+	idempotencyRepository persistence.IIdempotencyRepository
+	ttl                   time.Duration // Bir anahtarın ve yanıtının saklandığı süre.
+	lease                 time.Duration // Yanıtı henüz kaydedilmemiş bir anahtarın kilitli kaldığı süre.
+}
+
+// Yeni bir IdempotencyService oluşturur; anahtarlar ttl süresince saklanır, işlenmekte olan
+// istekler anahtarı en fazla lease süresince kilitler.
+func NewIdempotencyService(idempotencyRepository persistence.IIdempotencyRepository, ttl time.Duration, lease time.Duration) IIdempotencyService {
+	return &IdempotencyService{
+		idempotencyRepository: idempotencyRepository,
+		ttl:                   ttl,
+		lease:                 lease,
+	}
+}
+
+// Begin, anahtarı istek için ayırır. Anahtar yeni ise nil döner ve istek işlenmelidir;
+// işlendikten sonra Complete veya Abandon çağrılmalıdır.
+// Anahtar aynı istekle daha önce tamamlandıysa kaydedilen yanıt döner.
+// Anahtar farklı bir istekle kullanıldıysa doğrulama hatası, ilk istek hâlâ işleniyorsa çakışma hatası döner.
+// İlk isteğin kilidi yanıt kaydedilmeden dolduysa anahtar bu istek için yeniden ayrılır.
+func (idempotencyService *IdempotencyService) Begin(ctx context.Context, key string, requestHash string) (*domain.IdempotentResponse, error) {
+	record, reserved, err := idempotencyService.idempotencyRepository.Reserve(ctx, key, requestHash, idempotencyService.ttl, idempotencyService.lease)
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+	if record.RequestHash != requestHash {
+		return nil, domain.NewValidationError("Idempotency-Key was already used with a different request")
+	}
+	if record.Response == nil {
+		return nil, domain.NewConflictError("A request with this Idempotency-Key is still being processed", nil)
+	}
+	return record.Response, nil
+}
+
+// Complete, ayrılmış anahtara verilen yanıtı saklar.
+func (idempotencyService *IdempotencyService) Complete(ctx context.Context, key string, response domain.IdempotentResponse) error {
+	return idempotencyService.idempotencyRepository.SaveResponse(ctx, key, response)
+}
+
+// Abandon, yanıtı saklanmayacak bir isteğin anahtarını bırakır; istek aynı anahtarla yeniden denenebilir.
+func (idempotencyService *IdempotencyService) Abandon(ctx context.Context, key string) error {
+	return idempotencyService.idempotencyRepository.Release(ctx, key)
+}
+
+// Süresi dolan anahtarları ve yanıtlarını siler ve silinen anahtar sayısını döner.
+func (idempotencyService *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return idempotencyService.idempotencyRepository.DeleteExpired(ctx)
+}
@@ -1,8 +1,121 @@
 package model
 
+import (
+	"product-app/common/money"
+	"product-app/domain"
+	"time"
+)
+
+// ProductCreate, yeni bir ürün eklemek için kullanılan modeldir.
+// Currency boşsa fiyat varsayılan para biriminde kabul edilir. StoreId var olan bir mağazayı göstermelidir.
 type ProductCreate struct {
 	Name     string
-	Price    float32
-	Discount float32
-	Store    string
+	Price    money.Decimal
+	Currency money.Currency
+	Discount money.Decimal
+	StoreId  int64
+}
+
+// ProductUpdate, bir ürünün tüm alanlarını değiştirmek için kullanılan modeldir.
+type ProductUpdate struct {
+	Name     string
+	Price    money.Decimal
+	Currency money.Currency
+	Discount money.Decimal
+	StoreId  int64
+}
+
+// ProductPatch, bir ürüne kısmi güncelleme uygulamak için kullanılan modeldir.
+// Nil olan alanlar değiştirilmez.
+type ProductPatch struct {
+	Name     *string
+	Price    *money.Decimal
+	Currency *money.Currency
+	Discount *money.Decimal
+	StoreId  *int64
+}
+
+// StoreCreate, yeni bir mağaza eklemek için kullanılan modeldir.
+type StoreCreate struct {
+	Name string
+}
+
+// StoreUpdate, bir mağazanın adını değiştirmek için kullanılan modeldir.
+type StoreUpdate struct {
+	Name string
+}
+
+// CategoryCreate, yeni bir kategori eklemek için kullanılan modeldir.
+// ParentId nil ise kategori kök olarak eklenir.
+type CategoryCreate struct {
+	Name     string
+	ParentId *int64
+}
+
+// CategoryUpdate, bir kategorinin adını ve ağaçtaki yerini değiştirmek için kullanılan modeldir.
+type CategoryUpdate struct {
+	Name     string
+	ParentId *int64
+}
+
+// StockChange, bir mağazadaki stoktan miktar ayırmak veya ayrılmış miktarı bırakmak için kullanılan modeldir.
+type StockChange struct {
+	StoreId  int64
+	Quantity int
+}
+
+// StockAdjustment, bir mağazadaki toplam stok miktarını değiştirmek için kullanılan modeldir.
+// LowStockThreshold nil ise mevcut eşik korunur.
+type StockAdjustment struct {
+	StoreId           int64
+	Delta             int
+	LowStockThreshold *int
+}
+
+// CampaignCreate, yeni bir indirim kampanyası eklemek için kullanılan modeldir.
+// Kampanya en az bir ürünü veya mağazayı hedeflemelidir.
+type CampaignCreate struct {
+	Name       string
+	Discount   money.Decimal
+	StartsAt   time.Time
+	EndsAt     time.Time
+	Priority   int
+	ProductIds []int64
+	StoreIds   []int64
+}
+
+// CampaignUpdate, bir kampanyanın tüm alanlarını ve hedeflerini değiştirmek için kullanılan modeldir.
+type CampaignUpdate struct {
+	Name       string
+	Discount   money.Decimal
+	StartsAt   time.Time
+	EndsAt     time.Time
+	Priority   int
+	ProductIds []int64
+	StoreIds   []int64
+}
+
+// ProductImportRow, toplu içe aktarılan dosyadaki tek bir ürün satırıdır.
+// ParseErrors, dosya okunurken ayrıştırılamayan alanları taşır; bu alanlar ayrıca doğrulanmaz.
+type ProductImportRow struct {
+	Line        int
+	Product     ProductCreate
+	ParseErrors []domain.FieldError
+}
+
+// ProductBatch, tek transaction içinde uygulanacak toplu ürün işlemleridir.
+// Mode boşsa işlemler atomik olarak uygulanır.
+type ProductBatch struct {
+	Mode       string
+	Operations []ProductBatchOperation
+}
+
+// ProductBatchOperation, toplu işlemdeki tek bir ekleme, fiyat güncelleme veya silme işlemidir.
+// Type'a göre yalnızca ilgili alanlar kullanılır: create için Product, updatePrice için ProductId ve NewPrice,
+// delete için ProductId.
+type ProductBatchOperation struct {
+	Type      string
+	ProductId int64
+	Product   ProductCreate
+	NewPrice  money.Decimal
 }
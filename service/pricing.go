@@ -0,0 +1,60 @@
+package service
+
+import (
+	"product-app/common/money"
+	"product-app/domain"
+)
+
+// CalculatePrice, ürünün indirimli fiyatını hesaplayan tek noktadır; istemcilere dönen tüm
+// fiyatlar bu fonksiyonla hesaplanmalıdır. Yuvarlama kuralları:
+//   - Liste fiyatı kuruşa yuvarlanır.
+//   - İndirim tutarı, liste fiyatının indirim oranı kadarı olarak hesaplanır ve kuruşa,
+//     yarımlar sıfırdan uzağa olacak şekilde yuvarlanır.
+//   - Son fiyat, liste fiyatından indirim tutarı düşülerek bulunur; böylece
+//     indirim tutarı ile son fiyatın toplamı her zaman liste fiyatına eşittir.
+func CalculatePrice(product domain.Product) domain.PriceBreakdown {
+	price := money.New(product.Price.Amount.Round(money.Places), product.Price.Currency)
+	return domain.PriceBreakdown{
+		Price:          price,
+		DiscountAmount: price.Percent(product.Discount),
+		FinalPrice:     price.ApplyDiscount(product.Discount),
+	}
+}
+
+// ApplyCampaigns, ürünlerin okuma anındaki geçerli indirimini belirler. Ürüne uyan kampanyalar
+// arasından domain.Campaign.Outranks kuralına göre seçilen kampanyanın oranı, ürünün kendi
+// indiriminin yerine geçer. Kampanyalar MaxDiscount'u aşan bir oranla kaydedilemez; sınırdan önce
+// kaydedilmiş kampanyalar için sonuç yine de MaxDiscount ile sınırlandırılır.
+// Ürünlerin fiyat dökümü de bu indirime göre CalculatePrice ile doldurulur.
+// Kampanyaların verilen anda geçerli olduğu varsayılır.
+func ApplyCampaigns(products []domain.Product, campaigns []domain.Campaign) []domain.Product {
+	maxDiscount := money.NewFromInt(MaxDiscount)
+	resolved := make([]domain.Product, 0, len(products))
+	for _, product := range products {
+		var winner *domain.Campaign
+		for index := range campaigns {
+			if campaigns[index].AppliesTo(product) && (winner == nil || campaigns[index].Outranks(*winner)) {
+				winner = &campaigns[index]
+			}
+		}
+		if winner != nil {
+			campaignId := winner.Id
+			product.Discount = winner.Discount
+			product.CampaignId = &campaignId
+		}
+		if product.Discount.Cmp(maxDiscount) > 0 {
+			product.Discount = maxDiscount
+		}
+		product.Pricing = CalculatePrice(product)
+		resolved = append(resolved, product)
+	}
+	return resolved
+}
+
+// withPricing, kampanya uygulanmadan dönen ürünlerin fiyat dökümünü kendi indirimlerine göre doldurur.
+func withPricing(products []domain.Product) []domain.Product {
+	for index := range products {
+		products[index].Pricing = CalculatePrice(products[index])
+	}
+	return products
+}
@@ -1,82 +1,623 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"product-app/common/money"
+	"product-app/common/text"
 	"product-app/domain"
 	"product-app/persistence"
 	"product-app/service/model"
+	"product-app/service/validation"
+	"time"
 )
 
 // IProductService, ürünlerle ilgili servis işlemleri için bir arayüzdür.
 type IProductService interface {
-	Add(productCreate model.ProductCreate) error
-	DeleteById(productId int64) error
-	GetById(productId int64) (domain.Product, error)
-	UpdatePrice(productId int64, newPrice float32) error
-	GetAllProducts() []domain.Product
-	GetAllProductsByStore(storeName string) []domain.Product
+	Add(ctx context.Context, productCreate model.ProductCreate) (domain.Product, error)
+	DeleteById(ctx context.Context, productId int64, version int64) error
+	GetTrash(ctx context.Context) ([]domain.Product, error)
+	Restore(ctx context.Context, productId int64) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	GetById(ctx context.Context, productId int64) (domain.Product, error)
+	GetByIdInCurrency(ctx context.Context, productId int64, currency money.Currency) (domain.Product, error)
+	UpdatePrice(ctx context.Context, productId int64, newPrice money.Decimal, version int64) error
+	Update(ctx context.Context, productId int64, productUpdate model.ProductUpdate, version int64) error
+	Patch(ctx context.Context, productId int64, productPatch model.ProductPatch, version int64) error
+	GetAllProducts(ctx context.Context) ([]domain.Product, error)
+	GetAllProductsByStore(ctx context.Context, storeId int64, query domain.ProductQuery) (domain.ProductPage, error)
+	GetProducts(ctx context.Context, query domain.ProductQuery) (domain.ProductPage, error)
+	Search(ctx context.Context, searchText string, limit int) ([]domain.Product, error)
+	GetPriceHistory(ctx context.Context, productId int64, query domain.PriceHistoryQuery) ([]domain.PriceChange, error)
+	Import(ctx context.Context, rows []model.ProductImportRow, dryRun bool) (domain.ProductImportReport, error)
+	Export(ctx context.Context, query domain.ProductQuery, handle func(product domain.Product) error) error
+	ExecuteBatch(ctx context.Context, batch model.ProductBatch) (domain.ProductBatchResult, error)
 }
 
+// Listeleme için varsayılan ve izin verilen en büyük sayfa boyutu.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// MaxImportRows, tek bir toplu içe aktarma isteğinde izin verilen en fazla ürün satırı sayısıdır.
+const MaxImportRows = 10000
+
+// MaxBatchOperations, tek bir toplu işlem isteğinde izin verilen en fazla işlem sayısıdır.
+const MaxBatchOperations = 500
+
 // ProductService, IProductService arayüzünü uygulayan yapı olup,
 // ürünlerin eklenmesi, silinmesi ve alınması gibi işlemleri gerçekleştirir.
 type ProductService struct {
-	productRepository persistence.IProductRepository
+	productRepository  persistence.IProductRepository
+	storeRepository    persistence.IStoreRepository    // Ürünlerin bağlı olduğu mağazaları doğrulamak için kullanılır.
+	rateProvider       persistence.RateProvider        // Fiyatları başka para birimine çevirmek için kullanılan kur kaynağı.
+	campaignRepository persistence.ICampaignRepository // Okuma anında geçerli indirimi belirleyen kampanyaların kaynağı.
+	unitOfWork         persistence.IUnitOfWork         // Birden fazla repository çağrısını tek transaction içinde çalıştırır.
 }
 
-// Yeni bir ProductService oluşturur ve gerekli repository'i alır.
-func NewProductService(productRepository persistence.IProductRepository) IProductService {
+// Yeni bir ProductService oluşturur ve gerekli repository'leri, kur kaynağını ve unit of work'ü alır.
+func NewProductService(productRepository persistence.IProductRepository, storeRepository persistence.IStoreRepository,
+	rateProvider persistence.RateProvider, campaignRepository persistence.ICampaignRepository,
+	unitOfWork persistence.IUnitOfWork) IProductService {
 	return &ProductService{
-		productRepository: productRepository,
+		productRepository:  productRepository,
+		storeRepository:    storeRepository,
+		rateProvider:       rateProvider,
+		campaignRepository: campaignRepository,
+		unitOfWork:         unitOfWork,
 	}
 }
 
-// Yeni bir ürün ekler.
+// Yeni bir ürün ekler ve eklenen ürünü, GetById ile aynı şekilde geçerli kampanya indirimi uygulanmış olarak döner.
 // Ürün eklemeden önce doğrulama yapılır.
-func (productService *ProductService) Add(productCreate model.ProductCreate) error {
+func (productService *ProductService) Add(ctx context.Context, productCreate model.ProductCreate) (domain.Product, error) {
 	validateErr := validateProductCreate(productCreate)
 	if validateErr != nil {
 		// Eğer doğrulama hatası varsa, hata döndürülür.
-		return validateErr
+		return domain.Product{}, validateErr
+	}
+	store, storeErr := productService.getStoreOfProduct(ctx, productCreate.StoreId)
+	if storeErr != nil {
+		return domain.Product{}, storeErr
 	}
 	// Ürün veritabanına eklenir.
-	return productService.productRepository.AddProduct(domain.Product{
+	product, addErr := productService.productRepository.AddProduct(ctx, domain.Product{
 		Name:     productCreate.Name,
-		Price:    productCreate.Price,
+		Price:    newPrice(productCreate.Price, productCreate.Currency),
 		Discount: productCreate.Discount,
-		Store:    productCreate.Store,
+		StoreId:  store.Id,
+		Store:    store.Name,
 	})
+	if addErr != nil {
+		return domain.Product{}, addErr
+	}
+	resolved, campaignErr := productService.applyCampaigns(ctx, []domain.Product{product})
+	if campaignErr != nil {
+		return domain.Product{}, campaignErr
+	}
+	return resolved[0], nil
 }
 
-// Belirli bir ID'ye sahip ürünü siler.
-func (productService *ProductService) DeleteById(productId int64) error {
-	return productService.productRepository.DeleteById(productId)
+// Belirli bir ID'ye sahip ürünü çöp kutusuna taşır.
+// version domain.AnyVersion değilse ürün yalnızca o sürümdeyse silinir.
+func (productService *ProductService) DeleteById(ctx context.Context, productId int64, version int64) error {
+	return productService.productRepository.DeleteById(ctx, productId, version)
 }
 
-// Belirli bir ID'ye sahip ürünü getirir.
-func (productService *ProductService) GetById(productId int64) (domain.Product, error) {
-	return productService.productRepository.GetById(productId)
+// Çöp kutusundaki ürünleri en son silinenden başlayarak getirir.
+func (productService *ProductService) GetTrash(ctx context.Context) ([]domain.Product, error) {
+	products, err := productService.productRepository.GetDeletedProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return withPricing(products), nil
+}
+
+// Çöp kutusundaki bir ürünü geri yükler.
+func (productService *ProductService) Restore(ctx context.Context, productId int64) error {
+	return productService.productRepository.RestoreById(ctx, productId)
+}
+
+// Çöp kutusunda retention süresinden uzun kalmış ürünleri kalıcı olarak siler ve silinen ürün sayısını döner.
+func (productService *ProductService) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, domain.NewValidationError("Retention must be greater than 0")
+	}
+	return productService.productRepository.PurgeDeleted(ctx, time.Now().Add(-retention))
+}
+
+// Belirli bir ID'ye sahip ürünü, geçerli kampanya indirimi uygulanmış olarak getirir.
+func (productService *ProductService) GetById(ctx context.Context, productId int64) (domain.Product, error) {
+	product, getErr := productService.productRepository.GetById(ctx, productId)
+	if getErr != nil {
+		return domain.Product{}, getErr
+	}
+	resolved, campaignErr := productService.applyCampaigns(ctx, []domain.Product{product})
+	if campaignErr != nil {
+		return domain.Product{}, campaignErr
+	}
+	return resolved[0], nil
+}
+
+// Belirli bir ID'ye sahip ürünü, fiyatı verilen para birimine çevrilmiş olarak getirir.
+func (productService *ProductService) GetByIdInCurrency(ctx context.Context, productId int64, currency money.Currency) (domain.Product, error) {
+	validator := validation.New()
+	validator.Check(currency.IsSupported(), "currency", supportedCurrenciesMessage())
+	if validateErr := validator.Err(); validateErr != nil {
+		return domain.Product{}, validateErr
+	}
+	product, getErr := productService.GetById(ctx, productId)
+	if getErr != nil {
+		return domain.Product{}, getErr
+	}
+	converted, convertErr := productService.convertPrices(ctx, []domain.Product{product}, currency, map[money.Currency]money.Decimal{})
+	if convertErr != nil {
+		return domain.Product{}, convertErr
+	}
+	return converted[0], nil
 }
 
 // Ürünün fiyatını günceller.
-func (productService *ProductService) UpdatePrice(productId int64, newPrice float32) error {
-	return productService.productRepository.UpdatePrice(productId, newPrice)
+// version domain.AnyVersion değilse ürün yalnızca o sürümdeyse güncellenir.
+func (productService *ProductService) UpdatePrice(ctx context.Context, productId int64, newPrice money.Decimal, version int64) error {
+	validateErr := validatePrice(newPrice)
+	if validateErr != nil {
+		return validateErr
+	}
+	return productService.productRepository.UpdatePrice(ctx, productId, newPrice, version)
 }
 
-// Tüm ürünleri getirir.
-func (productService *ProductService) GetAllProducts() []domain.Product {
-	return productService.productRepository.GetAllProducts()
+// Ürünün tüm alanlarını verilen değerlerle değiştirir.
+// Güncellemeden önce ekleme ile aynı doğrulama yapılır. version domain.AnyVersion değilse sürüm denetlenir.
+// Mağaza kontrolü ve güncelleme aynı transaction içinde yapılır.
+func (productService *ProductService) Update(ctx context.Context, productId int64, productUpdate model.ProductUpdate, version int64) error {
+	validateErr := validateProductUpdate(productUpdate)
+	if validateErr != nil {
+		return validateErr
+	}
+	return productService.unitOfWork.WithTx(ctx, func(repositories persistence.Repositories) error {
+		store, storeErr := getStore(ctx, repositories.Stores, productUpdate.StoreId)
+		if storeErr != nil {
+			return storeErr
+		}
+		return repositories.Products.UpdateProduct(ctx, domain.Product{
+			Id:       productId,
+			Name:     productUpdate.Name,
+			Price:    newPrice(productUpdate.Price, productUpdate.Currency),
+			Discount: productUpdate.Discount,
+			StoreId:  store.Id,
+			Store:    store.Name,
+			Version:  version,
+		})
+	})
 }
 
-// Belirli bir mağazaya ait tüm ürünleri getirir.
-func (productService *ProductService) GetAllProductsByStore(storeName string) []domain.Product {
-	return productService.productRepository.GetAllProductsByStore(storeName)
+// Ürüne kısmi güncelleme uygular.
+// Mevcut ürün alınır, yalnızca gönderilen alanlar değiştirilir ve sonuç doğrulanarak kaydedilir.
+// Okuma ve kaydetme aynı transaction içinde yapılır. Ürün okunduktan sonra başka bir istekle
+// değiştirilmişse güncelleme uygulanmaz; version domain.AnyVersion değilse ürünün o sürümde olması da beklenir.
+func (productService *ProductService) Patch(ctx context.Context, productId int64, productPatch model.ProductPatch, version int64) error {
+	return productService.unitOfWork.WithTx(ctx, func(repositories persistence.Repositories) error {
+		return patchProduct(ctx, repositories, productId, productPatch, version)
+	})
+}
+
+// Ürüne kısmi güncellemeyi verilen repository'lerle uygular.
+func patchProduct(ctx context.Context, repositories persistence.Repositories, productId int64, productPatch model.ProductPatch, version int64) error {
+	product, getErr := repositories.Products.GetById(ctx, productId)
+	if getErr != nil {
+		return getErr
+	}
+	if version != domain.AnyVersion && version != product.Version {
+		return domain.NewPreconditionFailedError(fmt.Sprintf("ID'si %d olan ürün, beklenen sürümden sonra değiştirilmiş", productId))
+	}
+	if productPatch.Name != nil {
+		product.Name = *productPatch.Name
+	}
+	if productPatch.Price != nil {
+		product.Price.Amount = *productPatch.Price
+	}
+	if productPatch.Currency != nil {
+		product.Price.Currency = *productPatch.Currency
+	}
+	if productPatch.Discount != nil {
+		product.Discount = *productPatch.Discount
+	}
+	if productPatch.StoreId != nil {
+		product.StoreId = *productPatch.StoreId
+	}
+	validateErr := validateProduct(product)
+	if validateErr != nil {
+		return validateErr
+	}
+	if productPatch.StoreId != nil {
+		store, storeErr := getStore(ctx, repositories.Stores, product.StoreId)
+		if storeErr != nil {
+			return storeErr
+		}
+		product.Store = store.Name
+	}
+	return repositories.Products.UpdateProduct(ctx, product)
+}
+
+// Tüm ürünleri geçerli kampanya indirimleri uygulanmış olarak getirir.
+func (productService *ProductService) GetAllProducts(ctx context.Context) ([]domain.Product, error) {
+	products, err := productService.productRepository.GetAllProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return productService.applyCampaigns(ctx, products)
+}
+
+// Belirli bir mağazaya ait ürünleri, listeleme ile aynı filtre, sıralama ve sayfalama kurallarıyla getirir.
+// Mağaza yoksa domain.ErrNotFound türünde hata döner.
+func (productService *ProductService) GetAllProductsByStore(ctx context.Context, storeId int64, query domain.ProductQuery) (domain.ProductPage, error) {
+	if _, storeErr := productService.storeRepository.GetById(ctx, storeId); storeErr != nil {
+		return domain.ProductPage{}, storeErr
+	}
+	query.StoreId = storeId
+	return productService.GetProducts(ctx, query)
+}
+
+// Ürünleri filtreleyerek, sıralayarak ve sayfalayarak getirir.
+// Sorgu doğrulanır, eksik sayfa boyutu varsayılan değerle doldurulur ve sıralamanın
+// sonuna tekil bir anahtar olarak id eklenir. İndirim filtresi ve sıralaması ürünün kendi
+// indirimine göre yapılır; dönen ürünlere ise geçerli kampanya indirimi uygulanır.
+func (productService *ProductService) GetProducts(ctx context.Context, query domain.ProductQuery) (domain.ProductPage, error) {
+	validateErr := validateProductQuery(query)
+	if validateErr != nil {
+		return domain.ProductPage{}, validateErr
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageSize
+	}
+	query.Sort = withIdTieBreaker(query.Sort)
+	page, err := productService.productRepository.FindProducts(ctx, query)
+	if err != nil {
+		return domain.ProductPage{}, err
+	}
+	page.Products, err = productService.applyCampaigns(ctx, page.Products)
+	if err != nil || len(query.Currency) == 0 {
+		return page, err
+	}
+	page.Products, err = productService.convertPrices(ctx, page.Products, query.Currency, map[money.Currency]money.Decimal{})
+	if err != nil {
+		return domain.ProductPage{}, err
+	}
+	return page, nil
+}
+
+// Ürün adlarında arama yapar ve sonuçları ilgiye göre sıralı döner.
+func (productService *ProductService) Search(ctx context.Context, searchText string, limit int) ([]domain.Product, error) {
+	if len(text.Tokenize(searchText)) == 0 {
+		return nil, domain.NewValidationError("Search text is required")
+	}
+	if limit < 0 || limit > MaxPageSize {
+		return nil, domain.NewValidationError(fmt.Sprintf("Limit must be between 1 and %d", MaxPageSize))
+	}
+	if limit == 0 {
+		limit = DefaultPageSize
+	}
+	products, err := productService.productRepository.Search(ctx, searchText, limit)
+	if err != nil {
+		return nil, err
+	}
+	return productService.applyCampaigns(ctx, products)
+}
+
+// Ürünün fiyat değişikliklerini verilen tarih aralığında en yeniden eskiye doğru getirir.
+// Ürün yoksa domain.ErrNotFound türünde hata döner.
+func (productService *ProductService) GetPriceHistory(ctx context.Context, productId int64, query domain.PriceHistoryQuery) ([]domain.PriceChange, error) {
+	validator := validation.New()
+	validator.Check(query.From == nil || query.To == nil || query.From.Before(*query.To), "from", "From must be before to")
+	if validateErr := validator.Err(); validateErr != nil {
+		return nil, validateErr
+	}
+	if _, getErr := productService.productRepository.GetById(ctx, productId); getErr != nil {
+		return nil, getErr
+	}
+	return productService.productRepository.GetPriceHistory(ctx, productId, query)
+}
+
+// Dosyadan okunan ürün satırlarını ürün ekleme kurallarıyla doğrular ve hepsi geçerliyse tek seferde ekler.
+// Hatalı satır varsa veya dryRun true ise hiçbir ürün eklenmez; hatalar satır bazında raporlanır.
+func (productService *ProductService) Import(ctx context.Context, rows []model.ProductImportRow, dryRun bool) (domain.ProductImportReport, error) {
+	if len(rows) == 0 {
+		return domain.ProductImportReport{}, domain.NewValidationError("Import file contains no products")
+	}
+	if len(rows) > MaxImportRows {
+		return domain.ProductImportReport{}, domain.NewValidationError(fmt.Sprintf("Import file can not contain more than %d products", MaxImportRows))
+	}
+	report := domain.ProductImportReport{DryRun: dryRun, TotalRows: len(rows), RowErrors: []domain.ImportRowError{}}
+	stores := map[int64]*domain.Store{}
+	products := make([]domain.Product, 0, len(rows))
+	for _, row := range rows {
+		fieldErrors, err := productService.validateImportRow(ctx, row, stores)
+		if err != nil {
+			return domain.ProductImportReport{}, err
+		}
+		if len(fieldErrors) > 0 {
+			report.RowErrors = append(report.RowErrors, domain.ImportRowError{Line: row.Line, FieldErrors: fieldErrors})
+			continue
+		}
+		store := stores[row.Product.StoreId]
+		products = append(products, domain.Product{
+			Name:     row.Product.Name,
+			Price:    newPrice(row.Product.Price, row.Product.Currency),
+			Discount: row.Product.Discount,
+			StoreId:  store.Id,
+			Store:    store.Name,
+		})
+	}
+	if len(report.RowErrors) > 0 || dryRun {
+		return report, nil
+	}
+	importedRows, err := productService.productRepository.ImportProducts(ctx, products)
+	if err != nil {
+		return domain.ProductImportReport{}, err
+	}
+	report.ImportedRows = int(importedRows)
+	return report, nil
+}
+
+// İçe aktarılan satırın ayrıştırma hatalarını, ürün ekleme doğrulamasını ve mağazanın varlığını denetler.
+// Ayrıştırılamayan alanlar için doğrulama hataları tekrar eklenmez. Mağazalar stores içinde önbelleğe alınır;
+// olmayan mağazalar nil olarak tutulur.
+func (productService *ProductService) validateImportRow(ctx context.Context, row model.ProductImportRow, stores map[int64]*domain.Store) ([]domain.FieldError, error) {
+	fieldErrors := append([]domain.FieldError{}, row.ParseErrors...)
+	unparsedFields := map[string]bool{}
+	for _, parseError := range row.ParseErrors {
+		unparsedFields[parseError.Field] = true
+	}
+	var validationErr *domain.ValidationError
+	if errors.As(validateProductCreate(row.Product), &validationErr) {
+		for _, fieldError := range validationErr.FieldErrors {
+			if !unparsedFields[fieldError.Field] {
+				fieldErrors = append(fieldErrors, fieldError)
+			}
+		}
+	}
+	storeId := row.Product.StoreId
+	if storeId <= 0 || unparsedFields["storeId"] {
+		return fieldErrors, nil
+	}
+	store, storeErr := productService.findStoreCached(ctx, storeId, stores)
+	if storeErr != nil {
+		return nil, storeErr
+	}
+	if store == nil {
+		fieldErrors = append(fieldErrors, domain.FieldError{Field: "storeId", Message: fmt.Sprintf("Store with id %d does not exist", storeId)})
+	}
+	return fieldErrors, nil
+}
+
+// Mağazayı stores önbelleğinden, yoksa repository'den getirir. Olmayan mağazalar için nil döner
+// ve önbellekte nil olarak tutulur.
+func (productService *ProductService) findStoreCached(ctx context.Context, storeId int64, stores map[int64]*domain.Store) (*domain.Store, error) {
+	store, cached := stores[storeId]
+	if cached {
+		return store, nil
+	}
+	found, storeErr := productService.storeRepository.GetById(ctx, storeId)
+	if storeErr != nil && !errors.Is(storeErr, domain.ErrNotFound) {
+		return nil, storeErr
+	}
+	if storeErr == nil {
+		store = &found
+	}
+	stores[storeId] = store
+	return store, nil
+}
+
+// Toplu ürün işlemlerini doğrular ve geçerli olanları tek transaction içinde sırayla uygular.
+// Atomik modda bir işlem doğrulanamazsa veritabanına hiç gidilmez ve diğer işlemler geri alındı olarak raporlanır;
+// bestEffort modda geçersiz işlemler başarısız olarak raporlanır, diğerleri uygulanır.
+func (productService *ProductService) ExecuteBatch(ctx context.Context, batch model.ProductBatch) (domain.ProductBatchResult, error) {
+	mode := batch.Mode
+	if len(mode) == 0 {
+		mode = domain.BatchModeAtomic
+	}
+	validator := validation.New()
+	validator.Check(mode == domain.BatchModeAtomic || mode == domain.BatchModeBestEffort, "mode",
+		fmt.Sprintf("Mode must be one of %s, %s", domain.BatchModeAtomic, domain.BatchModeBestEffort))
+	validator.Check(len(batch.Operations) > 0, "operations", "Batch contains no operations")
+	validator.Check(len(batch.Operations) <= MaxBatchOperations, "operations",
+		fmt.Sprintf("Batch can not contain more than %d operations", MaxBatchOperations))
+	if validateErr := validator.Err(); validateErr != nil {
+		return domain.ProductBatchResult{}, validateErr
+	}
+
+	atomic := mode == domain.BatchModeAtomic
+	results := make([]domain.ProductOperationResult, len(batch.Operations))
+	stores := map[int64]*domain.Store{}
+	operations := make([]domain.ProductOperation, 0, len(batch.Operations))
+	positions := make([]int, 0, len(batch.Operations))
+	invalid := false
+	for index, batchOperation := range batch.Operations {
+		operation, operationErr := productService.toProductOperation(ctx, batchOperation, stores)
+		if errors.Is(operationErr, domain.ErrValidation) {
+			results[index] = domain.ProductOperationResult{Status: domain.BatchStatusFailed, ProductId: batchOperation.ProductId, Err: operationErr}
+			invalid = true
+			continue
+		}
+		if operationErr != nil {
+			return domain.ProductBatchResult{}, operationErr
+		}
+		operations = append(operations, operation)
+		positions = append(positions, index)
+	}
+
+	if invalid && atomic {
+		for index := range results {
+			if results[index].Status != domain.BatchStatusFailed {
+				results[index] = domain.ProductOperationResult{Status: domain.BatchStatusRolledBack, ProductId: batch.Operations[index].ProductId}
+			}
+		}
+		return domain.ProductBatchResult{Mode: mode, Results: results}, nil
+	}
+	if len(operations) > 0 {
+		operationResults, err := productService.productRepository.ExecuteBatch(ctx, operations, atomic)
+		if err != nil {
+			return domain.ProductBatchResult{}, err
+		}
+		for index, operationResult := range operationResults {
+			results[positions[index]] = operationResult
+		}
+	}
+	return domain.ProductBatchResult{Mode: mode, Results: results}, nil
+}
+
+// Toplu işlemdeki tek bir işlemi türüne göre doğrular ve repository'ye verilecek işleme dönüştürür.
+// Eklenecek ürünlerin mağazaları stores içinde önbelleğe alınır.
+func (productService *ProductService) toProductOperation(ctx context.Context, batchOperation model.ProductBatchOperation, stores map[int64]*domain.Store) (domain.ProductOperation, error) {
+	switch batchOperation.Type {
+	case domain.BatchOperationCreate:
+		productCreate := batchOperation.Product
+		if validateErr := validateProductCreate(productCreate); validateErr != nil {
+			return domain.ProductOperation{}, validateErr
+		}
+		store, storeErr := productService.findStoreCached(ctx, productCreate.StoreId, stores)
+		if storeErr != nil {
+			return domain.ProductOperation{}, storeErr
+		}
+		if store == nil {
+			validator := validation.New()
+			validator.Check(false, "storeId", fmt.Sprintf("Store with id %d does not exist", productCreate.StoreId))
+			return domain.ProductOperation{}, validator.Err()
+		}
+		return domain.ProductOperation{
+			Type: domain.BatchOperationCreate,
+			Product: domain.Product{
+				Name:     productCreate.Name,
+				Price:    newPrice(productCreate.Price, productCreate.Currency),
+				Discount: productCreate.Discount,
+				StoreId:  store.Id,
+				Store:    store.Name,
+			},
+		}, nil
+	case domain.BatchOperationUpdatePrice, domain.BatchOperationDelete:
+		validator := validation.New()
+		validator.Check(batchOperation.ProductId > 0, "id", "Id is required")
+		if batchOperation.Type == domain.BatchOperationUpdatePrice {
+			addPriceRules(validator, batchOperation.NewPrice)
+		}
+		if validateErr := validator.Err(); validateErr != nil {
+			return domain.ProductOperation{}, validateErr
+		}
+		return domain.ProductOperation{
+			Type:      batchOperation.Type,
+			ProductId: batchOperation.ProductId,
+			NewPrice:  batchOperation.NewPrice,
+		}, nil
+	}
+	validator := validation.New()
+	validator.Check(false, "op", fmt.Sprintf("Op must be one of %s, %s, %s",
+		domain.BatchOperationCreate, domain.BatchOperationUpdatePrice, domain.BatchOperationDelete))
+	return domain.ProductOperation{}, validator.Err()
+}
+
+// Listeleme filtrelerine uyan tüm ürünleri, listelemedeki gibi kampanya indirimi uygulanmış ve
+// istenmişse fiyatı çevrilmiş olarak sırayla handle fonksiyonuna verir. Sayfalama alanları dikkate alınmaz.
+// Sorgu, ilk ürün verilmeden önce doğrulanır; handle hata dönerse dışa aktarma durur.
+func (productService *ProductService) Export(ctx context.Context, query domain.ProductQuery, handle func(product domain.Product) error) error {
+	query.Limit, query.Offset, query.Cursor = 0, 0, ""
+	validateErr := validateProductQuery(query)
+	if validateErr != nil {
+		return validateErr
+	}
+	query.Sort = withIdTieBreaker(query.Sort)
+	campaigns, err := productService.campaignRepository.GetActiveCampaigns(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	rates := map[money.Currency]money.Decimal{}
+	return productService.productRepository.ExportProducts(ctx, query, func(product domain.Product) error {
+		resolved := ApplyCampaigns([]domain.Product{product}, campaigns)
+		if len(query.Currency) > 0 {
+			var convertErr error
+			if resolved, convertErr = productService.convertPrices(ctx, resolved, query.Currency, rates); convertErr != nil {
+				return convertErr
+			}
+		}
+		return handle(resolved[0])
+	})
+}
+
+// Şu anda geçerli olan kampanyaları bir kez okuyup ürünlerin indirimini ApplyCampaigns ile belirler.
+func (productService *ProductService) applyCampaigns(ctx context.Context, products []domain.Product) ([]domain.Product, error) {
+	if len(products) == 0 {
+		return products, nil
+	}
+	campaigns, err := productService.campaignRepository.GetActiveCampaigns(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return ApplyCampaigns(products, campaigns), nil
+}
+
+// Ürün fiyatlarını hedef para birimine çevirir ve fiyat dökümünü yeniden hesaplar. Alınan kurlar rates içinde saklanır; böylece
+// her para birimi çifti için kur bir kez alınır. Tanımlı olmayan bir kur istenirse doğrulama hatası döner.
+func (productService *ProductService) convertPrices(ctx context.Context, products []domain.Product, target money.Currency, rates map[money.Currency]money.Decimal) ([]domain.Product, error) {
+	converted := make([]domain.Product, 0, len(products))
+	for _, product := range products {
+		source := product.Price.Currency
+		if source == target {
+			converted = append(converted, product)
+			continue
+		}
+		rate, found := rates[source]
+		if !found {
+			var rateErr error
+			rate, rateErr = productService.rateProvider.GetRate(ctx, source, target)
+			if errors.Is(rateErr, domain.ErrNotFound) {
+				validator := validation.New()
+				validator.Check(false, "currency", fmt.Sprintf("Exchange rate from %s to %s is not available", source, target))
+				return nil, validator.Err()
+			}
+			if rateErr != nil {
+				return nil, rateErr
+			}
+			rates[source] = rate
+		}
+		product.Price = product.Price.Convert(rate, target)
+		product.Pricing = CalculatePrice(product)
+		converted = append(converted, product)
+	}
+	return converted, nil
+}
+
+// Ürünün bağlanacağı mağaza getirilir. Mağaza yoksa storeId alanı için doğrulama hatası döner.
+func (productService *ProductService) getStoreOfProduct(ctx context.Context, storeId int64) (domain.Store, error) {
+	return getStore(ctx, productService.storeRepository, storeId)
+}
+
+// Mağaza verilen repository'den getirilir. Mağaza yoksa storeId alanı için doğrulama hatası döner.
+func getStore(ctx context.Context, storeRepository persistence.IStoreRepository, storeId int64) (domain.Store, error) {
+	store, storeErr := storeRepository.GetById(ctx, storeId)
+	if errors.Is(storeErr, domain.ErrNotFound) {
+		validator := validation.New()
+		validator.Check(false, "storeId", fmt.Sprintf("Store with id %d does not exist", storeId))
+		return domain.Store{}, validator.Err()
+	}
+	return store, storeErr
+}
+
+// Para birimi verilmemişse varsayılan para birimi kullanılarak fiyat oluşturulur.
+func newPrice(amount money.Decimal, currency money.Currency) money.Money {
+	if len(currency) == 0 {
+		currency = money.DefaultCurrency
+	}
+	return money.New(amount, currency)
 }
 
-// Ürün ekleme işlemi için doğrulama yapılır.
-// İndirim oranının %70'ten fazla olmasına izin verilmez.
-func validateProductCreate(productCreate model.ProductCreate) error {
-	if productCreate.Discount > 70.0 {
-		return errors.New("Discount can not be greater than 70")
+// Sayfalamanın kararlı olması için sıralamada id yoksa sona eklenir.
+func withIdTieBreaker(sort []domain.SortField) []domain.SortField {
+	for _, sortField := range sort {
+		if sortField.Field == domain.SortFieldId {
+			return sort
+		}
 	}
-	return nil
+	return append(append([]domain.SortField{}, sort...), domain.SortField{Field: domain.SortFieldId})
 }
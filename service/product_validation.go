@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"product-app/service/validation"
+)
+
+// Ürün alanları için doğrulama sınırları.
+const (
+	MaxNameLength    = 255 // Ürün adının en fazla karakter sayısı.
+	MaxStoreLength   = 255 // Mağaza adının en fazla karakter sayısı.
+	MaxDiscount      = 70  // İzin verilen en yüksek indirim oranı.
+	MaxDecimalPlaces = 2   // Fiyat ve indirimde izin verilen ondalık basamak sayısı.
+	MaxPriceDigits   = 12  // Fiyatın tam sayı kısmında izin verilen basamak sayısı; price sütunu numeric(14, 2)'dir.
+)
+
+// priceUpperBound, MaxPriceDigits basamağa sığmayan en küçük fiyattır (10^12).
+var priceUpperBound = money.NewFromInt(1_000_000_000_000)
+
+// Ürün ekleme işlemi için doğrulama yapılır.
+func validateProductCreate(productCreate model.ProductCreate) error {
+	return validateProduct(domain.Product{
+		Name:     productCreate.Name,
+		Price:    newPrice(productCreate.Price, productCreate.Currency),
+		Discount: productCreate.Discount,
+		StoreId:  productCreate.StoreId,
+	})
+}
+
+// Ürün güncelleme işlemi için ekleme ile aynı doğrulama yapılır.
+func validateProductUpdate(productUpdate model.ProductUpdate) error {
+	return validateProduct(domain.Product{
+		Name:     productUpdate.Name,
+		Price:    newPrice(productUpdate.Price, productUpdate.Currency),
+		Discount: productUpdate.Discount,
+		StoreId:  productUpdate.StoreId,
+	})
+}
+
+// Kaydedilecek ürünün tüm alanları doğrulanır ve bulunan bütün hatalar birlikte döner.
+// İndirim oranının %70'ten fazla olmasına izin verilmez. Mağazanın var olup olmadığı servis tarafından denetlenir.
+func validateProduct(product domain.Product) error {
+	validator := validation.New()
+
+	validator.Required("name", product.Name, "Name is required")
+	validator.MaxLength("name", product.Name, MaxNameLength, fmt.Sprintf("Name can not be longer than %d characters", MaxNameLength))
+
+	addPriceRules(validator, product.Price.Amount)
+	validator.Check(product.Price.Currency.IsSupported(), "currency", supportedCurrenciesMessage())
+
+	validator.Check(product.Discount.Sign() >= 0, "discount", "Discount can not be negative")
+	validator.Check(product.Discount.Cmp(money.NewFromInt(MaxDiscount)) <= 0, "discount", fmt.Sprintf("Discount can not be greater than %d", MaxDiscount))
+	validator.MaxDecimalPlaces("discount", product.Discount, MaxDecimalPlaces, fmt.Sprintf("Discount can have at most %d decimal places", MaxDecimalPlaces))
+
+	validator.Check(product.StoreId > 0, "storeId", "StoreId is required")
+
+	return validator.Err()
+}
+
+// Fiyat güncelleme işlemi için yalnızca fiyat kuralları uygulanır.
+func validatePrice(price money.Decimal) error {
+	validator := validation.New()
+	addPriceRules(validator, price)
+	return validator.Err()
+}
+
+func addPriceRules(validator *validation.Validator, price money.Decimal) {
+	validator.Check(price.Sign() > 0, "price", "Price must be greater than 0")
+	validator.MaxDecimalPlaces("price", price, MaxDecimalPlaces, fmt.Sprintf("Price can have at most %d decimal places", MaxDecimalPlaces))
+	validator.Check(price.Cmp(priceUpperBound) < 0, "price", fmt.Sprintf("Price can have at most %d integer digits", MaxPriceDigits))
+}
+
+// supportedCurrenciesMessage, desteklenmeyen para birimi için doğrulama mesajını oluşturur.
+func supportedCurrenciesMessage() string {
+	return "Currency must be one of " + money.SupportedCurrencyCodes()
+}
+
+// Listeleme sorgusunun sınırlarını ve sıralama alanlarını doğrular.
+func validateProductQuery(query domain.ProductQuery) error {
+	validator := validation.New()
+
+	validator.Check(query.Limit >= 0 && query.Limit <= MaxPageSize, "limit", fmt.Sprintf("Limit must be between 1 and %d", MaxPageSize))
+	validator.Check(query.Offset >= 0, "offset", "Offset can not be negative")
+	validator.Check(len(query.Currency) == 0 || query.Currency.IsSupported(), "currency", supportedCurrenciesMessage())
+	validator.Check(query.MinPrice == nil || query.MaxPrice == nil || query.MinPrice.Cmp(*query.MaxPrice) <= 0,
+		"minPrice", "MinPrice can not be greater than maxPrice")
+
+	seenFields := map[string]bool{}
+	for _, sortField := range query.Sort {
+		switch sortField.Field {
+		case domain.SortFieldId, domain.SortFieldName, domain.SortFieldPrice, domain.SortFieldDiscount, domain.SortFieldStore:
+		default:
+			validator.Check(false, "sort", fmt.Sprintf("Unsupported sort field: %s", sortField.Field))
+		}
+		validator.Check(!seenFields[sortField.Field], "sort", fmt.Sprintf("Sort field %s is given more than once", sortField.Field))
+		seenFields[sortField.Field] = true
+	}
+	return validator.Err()
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"product-app/domain"
+	"product-app/persistence"
+	"product-app/service/model"
+	"product-app/service/validation"
+)
+
+// IStockService, ürün stoklarıyla ilgili servis işlemleri için bir arayüzdür.
+type IStockService interface {
+	GetStocksOfProduct(ctx context.Context, productId int64) ([]domain.Stock, error)
+	GetLowStocks(ctx context.Context) ([]domain.Stock, error)
+	Reserve(ctx context.Context, productId int64, stockChange model.StockChange) (domain.Stock, error)
+	Release(ctx context.Context, productId int64, stockChange model.StockChange) (domain.Stock, error)
+	Adjust(ctx context.Context, productId int64, stockAdjustment model.StockAdjustment) (domain.Stock, error)
+}
+
+// StockService, IStockService arayüzünü uygulayan yapıdır.
+type StockService struct {
+	stockRepository   persistence.IStockRepository
+	productRepository persistence.IProductRepository // Stoğu değiştirilen ürünün varlığını denetlemek için kullanılır.
+	storeRepository   persistence.IStoreRepository   // Stoğun tutulduğu mağazanın varlığını denetlemek için kullanılır.
+}
+
+// Yeni bir StockService oluşturur ve gerekli repository'leri alır.
+func NewStockService(stockRepository persistence.IStockRepository, productRepository persistence.IProductRepository,
+	storeRepository persistence.IStoreRepository) IStockService {
+	return &StockService{
+		stockRepository:   stockRepository,
+		productRepository: productRepository,
+		storeRepository:   storeRepository,
+	}
+}
+
+// Ürünün tüm mağazalardaki stoklarını getirir. Ürün yoksa domain.ErrNotFound türünde hata döner.
+func (stockService *StockService) GetStocksOfProduct(ctx context.Context, productId int64) ([]domain.Stock, error) {
+	if _, getErr := stockService.productRepository.GetById(ctx, productId); getErr != nil {
+		return nil, getErr
+	}
+	return stockService.stockRepository.GetStocksOfProduct(ctx, productId)
+}
+
+// Satılabilir miktarı düşük stok eşiğine inmiş stokları getirir.
+func (stockService *StockService) GetLowStocks(ctx context.Context) ([]domain.Stock, error) {
+	return stockService.stockRepository.GetLowStocks(ctx)
+}
+
+// Verilen mağazadaki stoktan miktar ayırır. Yeterli stok yoksa domain.ErrConflict türünde hata döner.
+func (stockService *StockService) Reserve(ctx context.Context, productId int64, stockChange model.StockChange) (domain.Stock, error) {
+	if err := stockService.validateStockChange(ctx, productId, stockChange); err != nil {
+		return domain.Stock{}, err
+	}
+	return stockService.stockRepository.ModifyStock(ctx, productId, stockChange.StoreId, func(stock *domain.Stock) error {
+		return stock.Reserve(stockChange.Quantity)
+	})
+}
+
+// Daha önce ayrılmış miktarı satılabilir stoğa geri bırakır.
+func (stockService *StockService) Release(ctx context.Context, productId int64, stockChange model.StockChange) (domain.Stock, error) {
+	if err := stockService.validateStockChange(ctx, productId, stockChange); err != nil {
+		return domain.Stock{}, err
+	}
+	return stockService.stockRepository.ModifyStock(ctx, productId, stockChange.StoreId, func(stock *domain.Stock) error {
+		return stock.Release(stockChange.Quantity)
+	})
+}
+
+// Toplam stok miktarını artırır veya azaltır ve istenirse düşük stok eşiğini değiştirir.
+// Mağazada henüz stok kaydı yoksa oluşturulur.
+func (stockService *StockService) Adjust(ctx context.Context, productId int64, stockAdjustment model.StockAdjustment) (domain.Stock, error) {
+	validator := validation.New()
+	validator.Check(stockAdjustment.StoreId > 0, "storeId", "StoreId is required")
+	validator.Check(stockAdjustment.Delta != 0 || stockAdjustment.LowStockThreshold != nil,
+		"delta", "Delta or lowStockThreshold is required")
+	if stockAdjustment.LowStockThreshold != nil {
+		validator.Check(*stockAdjustment.LowStockThreshold >= 0, "lowStockThreshold", "LowStockThreshold can not be negative")
+	}
+	if validateErr := validator.Err(); validateErr != nil {
+		return domain.Stock{}, validateErr
+	}
+	if err := stockService.checkProductAndStore(ctx, productId, stockAdjustment.StoreId); err != nil {
+		return domain.Stock{}, err
+	}
+	return stockService.stockRepository.ModifyStock(ctx, productId, stockAdjustment.StoreId, func(stock *domain.Stock) error {
+		if stockAdjustment.LowStockThreshold != nil {
+			stock.LowStockThreshold = *stockAdjustment.LowStockThreshold
+		}
+		return stock.Adjust(stockAdjustment.Delta)
+	})
+}
+
+// Ayırma ve bırakma isteklerinin alanları ile ürün ve mağazanın varlığı doğrulanır.
+func (stockService *StockService) validateStockChange(ctx context.Context, productId int64, stockChange model.StockChange) error {
+	validator := validation.New()
+	validator.Check(stockChange.StoreId > 0, "storeId", "StoreId is required")
+	validator.Check(stockChange.Quantity > 0, "quantity", "Quantity must be greater than 0")
+	if validateErr := validator.Err(); validateErr != nil {
+		return validateErr
+	}
+	return stockService.checkProductAndStore(ctx, productId, stockChange.StoreId)
+}
+
+// Ürün yoksa domain.ErrNotFound, mağaza yoksa storeId alanı için doğrulama hatası döner.
+func (stockService *StockService) checkProductAndStore(ctx context.Context, productId int64, storeId int64) error {
+	if _, getErr := stockService.productRepository.GetById(ctx, productId); getErr != nil {
+		return getErr
+	}
+	_, storeErr := stockService.storeRepository.GetById(ctx, storeId)
+	if errors.Is(storeErr, domain.ErrNotFound) {
+		validator := validation.New()
+		validator.Check(false, "storeId", fmt.Sprintf("Store with id %d does not exist", storeId))
+		return validator.Err()
+	}
+	return storeErr
+}
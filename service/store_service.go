@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"product-app/domain"
+	"product-app/persistence"
+	"product-app/service/model"
+	"product-app/service/validation"
+	"strings"
+)
+
+// IStoreService, mağazalarla ilgili servis işlemleri için bir arayüzdür.
+type IStoreService interface {
+	Add(ctx context.Context, storeCreate model.StoreCreate) (domain.Store, error)
+	GetById(ctx context.Context, storeId int64) (domain.Store, error)
+	GetAllStores(ctx context.Context) ([]domain.Store, error)
+	Update(ctx context.Context, storeId int64, storeUpdate model.StoreUpdate) error
+	DeleteById(ctx context.Context, storeId int64) error
+}
+
+// StoreService, IStoreService arayüzünü uygulayan yapıdır.
+type StoreService struct {
+	storeRepository persistence.IStoreRepository
+}
+
+// Yeni bir StoreService oluşturur ve gerekli repository'i alır.
+func NewStoreService(storeRepository persistence.IStoreRepository) IStoreService {
+	return &StoreService{
+		storeRepository: storeRepository,
+	}
+}
+
+// Yeni bir mağaza ekler ve ID'si atanmış mağazayı döner.
+// Aynı adda (harf büyüklüğü gözetilmeden) bir mağaza varsa çakışma hatası döner.
+func (storeService *StoreService) Add(ctx context.Context, storeCreate model.StoreCreate) (domain.Store, error) {
+	name := strings.TrimSpace(storeCreate.Name)
+	if validateErr := validateStoreName(name); validateErr != nil {
+		return domain.Store{}, validateErr
+	}
+	return storeService.storeRepository.AddStore(ctx, domain.Store{Name: name})
+}
+
+// Belirli bir ID'ye sahip mağazayı getirir.
+func (storeService *StoreService) GetById(ctx context.Context, storeId int64) (domain.Store, error) {
+	return storeService.storeRepository.GetById(ctx, storeId)
+}
+
+// Tüm mağazaları getirir.
+func (storeService *StoreService) GetAllStores(ctx context.Context) ([]domain.Store, error) {
+	return storeService.storeRepository.GetAllStores(ctx)
+}
+
+// Mağazanın adını değiştirir.
+func (storeService *StoreService) Update(ctx context.Context, storeId int64, storeUpdate model.StoreUpdate) error {
+	name := strings.TrimSpace(storeUpdate.Name)
+	if validateErr := validateStoreName(name); validateErr != nil {
+		return validateErr
+	}
+	return storeService.storeRepository.UpdateStore(ctx, domain.Store{Id: storeId, Name: name})
+}
+
+// Ürünü olmayan bir mağazayı siler.
+func (storeService *StoreService) DeleteById(ctx context.Context, storeId int64) error {
+	return storeService.storeRepository.DeleteById(ctx, storeId)
+}
+
+// Mağaza adı doğrulanır.
+func validateStoreName(name string) error {
+	validator := validation.New()
+	validator.Required("name", name, "Name is required")
+	validator.MaxLength("name", name, MaxStoreLength, fmt.Sprintf("Name can not be longer than %d characters", MaxStoreLength))
+	return validator.Err()
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"product-app/common/money"
+	"product-app/domain"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator, doğrulama kurallarını sırayla çalıştırır ve ilk hatada durmadan
+// tüm alan hatalarını toplar.
+type Validator struct {
+	fieldErrors []domain.FieldError
+}
+
+// New, yeni bir Validator oluşturur.
+func New() *Validator {
+	return &Validator{}
+}
+
+// Check, koşul sağlanmıyorsa verilen alan için hata ekler.
+func (validator *Validator) Check(ok bool, field string, message string) {
+	if !ok {
+		validator.fieldErrors = append(validator.fieldErrors, domain.FieldError{Field: field, Message: message})
+	}
+}
+
+// Required, metnin boş ya da yalnızca boşluktan oluşmadığını kontrol eder.
+func (validator *Validator) Required(field string, value string, message string) {
+	validator.Check(len(strings.TrimSpace(value)) > 0, field, message)
+}
+
+// MaxLength, metnin karakter (rune) sayısının sınırı aşmadığını kontrol eder.
+func (validator *Validator) MaxLength(field string, value string, max int, message string) {
+	validator.Check(utf8.RuneCountInString(value) <= max, field, message)
+}
+
+// MaxDecimalPlaces, sayının en fazla verilen sayıda ondalık basamağı olduğunu kontrol eder.
+func (validator *Validator) MaxDecimalPlaces(field string, value money.Decimal, places int, message string) {
+	validator.Check(value.DecimalPlaces() <= places, field, message)
+}
+
+// Err, hata toplanmışsa *domain.ValidationError, aksi halde nil döner.
+func (validator *Validator) Err() error {
+	if len(validator.fieldErrors) == 0 {
+		return nil
+	}
+	return &domain.ValidationError{FieldErrors: validator.fieldErrors}
+}
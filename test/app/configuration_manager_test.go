@@ -0,0 +1,117 @@
+package app
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"product-app/common/app"
+	"product-app/common/postgresql"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_WhenConfigFileIsYaml_ShouldLoadTypedValues(t *testing.T) {
+	t.Setenv(app.ConfigFileEnvironmentVariable, writeConfigFile(t, "application.yaml", `
+postgresql:
+  host: db.internal
+  port: 5432
+  maxConnections: 25
+  maxConnectionIdleTime: 1m
+server:
+  address: 0.0.0.0:9090
+  readTimeout: 5s
+`))
+	t.Run("WhenConfigFileIsYaml_ShouldLoadTypedValues", func(t *testing.T) {
+		configurationManager, err := app.NewConfigurationManager()
+		assert.Nil(t, err)
+		assert.Equal(t, "db.internal", configurationManager.PostgreSqlConfig.Host)
+		assert.Equal(t, 5432, configurationManager.PostgreSqlConfig.Port)
+		assert.Equal(t, 25, configurationManager.PostgreSqlConfig.MaxConnections)
+		assert.Equal(t, time.Minute, configurationManager.PostgreSqlConfig.MaxConnectionIdleTime)
+		assert.Equal(t, "productapp", configurationManager.PostgreSqlConfig.DbName)
+		assert.Equal(t, "0.0.0.0:9090", configurationManager.ServerConfig.Address)
+		assert.Equal(t, 5*time.Second, configurationManager.ServerConfig.ReadTimeout)
+	})
+}
+
+func Test_WhenConfigFileIsJson_ShouldLoadValues(t *testing.T) {
+	t.Setenv(app.ConfigFileEnvironmentVariable, writeConfigFile(t, "application.json", `{
+  "postgresql": {"host": "json-db", "maxConnectionIdleTime": "45s"},
+  "server": {"address": ":8081"}
+}`))
+	t.Run("WhenConfigFileIsJson_ShouldLoadValues", func(t *testing.T) {
+		configurationManager, err := app.NewConfigurationManager()
+		assert.Nil(t, err)
+		assert.Equal(t, "json-db", configurationManager.PostgreSqlConfig.Host)
+		assert.Equal(t, 45*time.Second, configurationManager.PostgreSqlConfig.MaxConnectionIdleTime)
+		assert.Equal(t, ":8081", configurationManager.ServerConfig.Address)
+	})
+}
+
+func Test_WhenEnvironmentVariableIsSet_ShouldOverrideConfigFile(t *testing.T) {
+	t.Setenv(app.ConfigFileEnvironmentVariable, writeConfigFile(t, "application.yaml", `
+postgresql:
+  host: db.internal
+  maxConnections: 25
+`))
+	t.Setenv("PRODUCTAPP_POSTGRESQL_HOST", "override-db")
+	t.Setenv("PRODUCTAPP_POSTGRESQL_MAX_CONNECTIONS", "50")
+	t.Setenv("PRODUCTAPP_SERVER_WRITE_TIMEOUT", "1m30s")
+	t.Setenv("PRODUCTAPP_TRASH_RETENTION", "168h")
+	t.Setenv("PRODUCTAPP_POSTGRESQL_TX_ISOLATION_LEVEL", "serializable")
+	t.Run("WhenEnvironmentVariableIsSet_ShouldOverrideConfigFile", func(t *testing.T) {
+		configurationManager, err := app.NewConfigurationManager()
+		assert.Nil(t, err)
+		assert.Equal(t, "override-db", configurationManager.PostgreSqlConfig.Host)
+		assert.Equal(t, 50, configurationManager.PostgreSqlConfig.MaxConnections)
+		assert.Equal(t, 90*time.Second, configurationManager.ServerConfig.WriteTimeout)
+		assert.Equal(t, 7*24*time.Hour, configurationManager.TrashConfig.Retention)
+		assert.Equal(t, time.Hour, configurationManager.TrashConfig.PurgeInterval)
+		assert.Equal(t, 24*time.Hour, configurationManager.IdempotencyConfig.TTL)
+		assert.Equal(t, postgresql.Serializable, configurationManager.PostgreSqlConfig.TxIsolationLevel)
+		assert.Equal(t, 3, configurationManager.PostgreSqlConfig.TxMaxRetries)
+	})
+}
+
+func Test_WhenEnvironmentVariableIsMalformed_ShouldReturnError(t *testing.T) {
+	t.Setenv(app.ConfigFileEnvironmentVariable, writeConfigFile(t, "application.yaml", ""))
+	t.Setenv("PRODUCTAPP_POSTGRESQL_MAX_CONNECTION_IDLE_TIME", "thirty seconds")
+	t.Run("WhenEnvironmentVariableIsMalformed_ShouldReturnError", func(t *testing.T) {
+		_, err := app.NewConfigurationManager()
+		assert.ErrorContains(t, err, "PRODUCTAPP_POSTGRESQL_MAX_CONNECTION_IDLE_TIME")
+	})
+}
+
+func Test_WhenValuesAreInvalid_ShouldReturnValidationError(t *testing.T) {
+	t.Setenv(app.ConfigFileEnvironmentVariable, writeConfigFile(t, "application.yaml", `
+postgresql:
+  port: 70000
+  maxConnections: 0
+  txIsolationLevel: snapshot
+trash:
+  retention: 0s
+`))
+	t.Run("WhenValuesAreInvalid_ShouldReturnValidationError", func(t *testing.T) {
+		_, err := app.NewConfigurationManager()
+		assert.ErrorContains(t, err, "postgresql.port")
+		assert.ErrorContains(t, err, "postgresql.maxConnections")
+		assert.ErrorContains(t, err, "postgresql.txIsolationLevel")
+		assert.ErrorContains(t, err, "trash.retention")
+	})
+}
+
+func Test_WhenExplicitConfigFileDoesNotExist_ShouldReturnError(t *testing.T) {
+	t.Setenv(app.ConfigFileEnvironmentVariable, filepath.Join(t.TempDir(), "missing.yaml"))
+	t.Run("WhenExplicitConfigFileDoesNotExist_ShouldReturnError", func(t *testing.T) {
+		_, err := app.NewConfigurationManager()
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"product-app/common/app"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_WhenContextIsCancelled_ShouldDrainRequestsAndCloseResources(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	lifecycle := app.NewLifecycle(parent, 5*time.Second)
+
+	requestStarted := make(chan struct{})
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	e.GET("/slow", func(c echo.Context) error {
+		close(requestStarted)
+		time.Sleep(200 * time.Millisecond)
+		return c.String(http.StatusOK, "done")
+	})
+
+	var closedResources []string
+	lifecycle.OnShutdown("first", func(ctx context.Context) error {
+		closedResources = append(closedResources, "first")
+		return nil
+	})
+	lifecycle.OnShutdown("second", func(ctx context.Context) error {
+		closedResources = append(closedResources, "second")
+		return nil
+	})
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- lifecycle.Serve(e, "127.0.0.1:0")
+	}()
+
+	t.Run("WhenContextIsCancelled_ShouldDrainRequestsAndCloseResources", func(t *testing.T) {
+		var address string
+		for i := 0; i < 100 && address == ""; i++ {
+			if listenerAddr := e.ListenerAddr(); listenerAddr != nil {
+				address = listenerAddr.String()
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.NotEmpty(t, address)
+
+		responseStatus := make(chan int, 1)
+		go func() {
+			response, err := http.Get("http://" + address + "/slow")
+			if err != nil {
+				responseStatus <- 0
+				return
+			}
+			response.Body.Close()
+			responseStatus <- response.StatusCode
+		}()
+
+		<-requestStarted
+		cancel()
+
+		assert.Equal(t, http.StatusOK, <-responseStatus)
+		assert.Nil(t, <-serveErr)
+		assert.Equal(t, []string{"second", "first"}, closedResources)
+	})
+}
+
+func Test_WhenContextIsCancelled_ShouldStopPeriodicJobsBeforeClosingResources(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	lifecycle := app.NewLifecycle(parent, 5*time.Second)
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	var mutex sync.Mutex
+	var events []string
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+	jobRuns := make(chan struct{}, 100)
+	lifecycle.Every("job", 10*time.Millisecond, func(ctx context.Context) error {
+		record("job")
+		jobRuns <- struct{}{}
+		select {
+		case <-ctx.Done():
+		case <-time.After(50 * time.Millisecond):
+		}
+		record("job done")
+		return nil
+	})
+	lifecycle.OnShutdown("resource", func(ctx context.Context) error {
+		record("resource")
+		return nil
+	})
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- lifecycle.Serve(e, "127.0.0.1:0")
+	}()
+
+	t.Run("WhenContextIsCancelled_ShouldStopPeriodicJobsBeforeClosingResources", func(t *testing.T) {
+		// İş ilk aralığı beklemeden çalışır ve ardından tekrarlanır.
+		<-jobRuns
+		<-jobRuns
+		cancel()
+
+		assert.Nil(t, <-serveErr)
+		time.Sleep(30 * time.Millisecond)
+		mutex.Lock()
+		defer mutex.Unlock()
+		assert.Equal(t, []string{"job", "job done", "job", "job done", "resource"}, events)
+	})
+}
@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"product-app/controller"
+	"product-app/domain"
+	"testing"
+)
+
+// actorOfRequest, verilen X-Actor başlığıyla gelen isteğin bağlamındaki aktörü döner.
+func actorOfRequest(actorHeader string) string {
+	e := echo.New()
+	request := httptest.NewRequest(http.MethodPut, "/api/v1/products/1/price", nil)
+	if len(actorHeader) > 0 {
+		request.Header.Set(controller.ActorHeader, actorHeader)
+	}
+	c := e.NewContext(request, httptest.NewRecorder())
+	var actor string
+	controller.ActorMiddleware(func(c echo.Context) error {
+		actor = domain.ActorFromContext(c.Request().Context())
+		return nil
+	})(c)
+	return actor
+}
+
+func Test_ShouldPutActorHeaderIntoRequestContext(t *testing.T) {
+	t.Run("ShouldPutActorHeaderIntoRequestContext", func(t *testing.T) {
+		assert.Equal(t, "ayse.yilmaz", actorOfRequest(" ayse.yilmaz "))
+		assert.Equal(t, domain.AnonymousActor, actorOfRequest(""))
+	})
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"product-app/controller"
+	"product-app/controller/response"
+	"product-app/domain"
+	"testing"
+)
+
+// handleError, verilen hatayı merkezi hata işleyiciden geçirir ve yazılan yanıtı döner.
+func handleError(err error) (int, response.ErrorResponse) {
+	e := echo.New()
+	recorder := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/products/1", nil), recorder)
+	controller.HTTPErrorHandler(err, c)
+
+	var errorResponse response.ErrorResponse
+	json.Unmarshal(recorder.Body.Bytes(), &errorResponse)
+	return recorder.Code, errorResponse
+}
+
+func Test_ShouldMapDomainErrorsToStatusCodes(t *testing.T) {
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedText   string
+	}{
+		{"NotFound", domain.NewNotFoundError("ID'si 1 olan ürün bulunamadı"), http.StatusNotFound, response.ErrorCodeNotFound, "ID'si 1 olan ürün bulunamadı"},
+		{"Validation", domain.NewValidationError("Discount can not be greater than 70"), http.StatusUnprocessableEntity, response.ErrorCodeValidationFailed, "Discount can not be greater than 70"},
+		{"Conflict", domain.NewConflictError("Ürün zaten var", nil), http.StatusConflict, response.ErrorCodeConflict, "Ürün zaten var"},
+		{"Unavailable", domain.NewUnavailableError("Veritabanına ulaşılamıyor", errors.New("dial tcp: connection refused")), http.StatusServiceUnavailable, response.ErrorCodeServiceUnavailable, "Veritabanına ulaşılamıyor"},
+		{"PreconditionFailed", domain.NewPreconditionFailedError("ID'si 1 olan ürün, beklenen sürümden sonra değiştirilmiş"), http.StatusPreconditionFailed, response.ErrorCodePreconditionFailed, "ID'si 1 olan ürün, beklenen sürümden sonra değiştirilmiş"},
+		{"EchoHTTPError", echo.NewHTTPError(http.StatusBadRequest, "Parameter newPrice is required!"), http.StatusBadRequest, response.ErrorCodeBadRequest, "Parameter newPrice is required!"},
+		{"PreconditionRequired", echo.NewHTTPError(http.StatusPreconditionRequired, "Header If-Match is required"), http.StatusPreconditionRequired, response.ErrorCodePreconditionRequired, "Header If-Match is required"},
+		{"ClientClosedRequest", fmt.Errorf("Tüm ürünler alınırken hata oluştu: %w", context.Canceled), controller.StatusClientClosedRequest, response.ErrorCodeClientClosedRequest, "Client closed request"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			status, errorResponse := handleError(testCase.err)
+			assert.Equal(t, testCase.expectedStatus, status)
+			assert.Equal(t, testCase.expectedCode, errorResponse.ErrorCode)
+			assert.Equal(t, testCase.expectedText, errorResponse.ErrorDescription)
+		})
+	}
+}
+
+func Test_WhenErrorIsUnknown_ShouldHideDetailsAndReturn500(t *testing.T) {
+	t.Run("WhenErrorIsUnknown_ShouldHideDetailsAndReturn500", func(t *testing.T) {
+		status, errorResponse := handleError(errors.New("pq: relation \"products\" does not exist"))
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.Equal(t, response.ErrorCodeInternalError, errorResponse.ErrorCode)
+		assert.Equal(t, "Internal Server Error", errorResponse.ErrorDescription)
+	})
+}
+
+func Test_WhenDomainErrorIsWrapped_ShouldStillBeMapped(t *testing.T) {
+	t.Run("WhenDomainErrorIsWrapped_ShouldStillBeMapped", func(t *testing.T) {
+		status, errorResponse := handleError(fmt.Errorf("silme başarısız: %w", domain.NewNotFoundError("Ürün bulunamadı")))
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, response.ErrorCodeNotFound, errorResponse.ErrorCode)
+	})
+}
+
+func Test_WhenValidationFails_ShouldReturnFieldErrors(t *testing.T) {
+	t.Run("WhenValidationFails_ShouldReturnFieldErrors", func(t *testing.T) {
+		status, errorResponse := handleError(&domain.ValidationError{FieldErrors: []domain.FieldError{
+			{Field: "price", Message: "Price must be greater than 0"},
+			{Field: "storeId", Message: "StoreId is required"},
+		}})
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, response.ErrorCodeValidationFailed, errorResponse.ErrorCode)
+		assert.Equal(t, []response.FieldErrorResponse{
+			{Field: "price", Message: "Price must be greater than 0"},
+			{Field: "storeId", Message: "StoreId is required"},
+		}, errorResponse.FieldErrors)
+	})
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"product-app/controller"
+	"product-app/domain"
+	"product-app/persistence"
+	"product-app/service"
+	fakes "product-app/test/service"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingSaveRepository, yanıtları kaydedemeyen bir idempotency repository'sidir.
+type failingSaveRepository struct {
+	persistence.IIdempotencyRepository
+}
+
+func (repository failingSaveRepository) SaveResponse(ctx context.Context, key string, response domain.IdempotentResponse) error {
+	return domain.NewUnavailableError("Veritabanına ulaşılamıyor", nil)
+}
+
+// newIdempotentServer, her eklemede yeni bir ürün ID'si veren ve Idempotency-Key ara katmanından geçen bir sunucu oluşturur.
+// Adı "fail" olan ürünler veritabanı hatası, adı boş olanlar doğrulama hatası alır, adı "panic" olanlarda kontrolcü panik yapar.
+func newIdempotentServer(handlerCalls *int) *echo.Echo {
+	return newIdempotentServerWithRepository(handlerCalls, fakes.NewFakeIdempotencyRepository())
+}
+
+// newIdempotentServerWithRepository, newIdempotentServer gibi çalışır ancak anahtarları verilen repository'de saklar.
+func newIdempotentServerWithRepository(handlerCalls *int, idempotencyRepository persistence.IIdempotencyRepository) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = controller.HTTPErrorHandler
+	e.Use(middleware.Recover())
+	idempotencyService := service.NewIdempotencyService(idempotencyRepository, time.Hour, time.Minute)
+	e.POST("/api/v1/products", func(c echo.Context) error {
+		*handlerCalls++
+		var product struct {
+			Name string `json:"name"`
+		}
+		if err := c.Bind(&product); err != nil {
+			return err
+		}
+		switch product.Name {
+		case "fail":
+			return domain.NewUnavailableError("Veritabanına ulaşılamıyor", nil)
+		case "":
+			return domain.NewValidationError("Name is required")
+		case "panic":
+			panic("beklenmeyen hata")
+		}
+		c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/api/v1/products/%d", *handlerCalls))
+		return c.JSON(http.StatusCreated, map[string]interface{}{"id": *handlerCalls, "name": product.Name})
+	}, controller.NewIdempotencyMiddleware(idempotencyService, 512))
+	return e
+}
+
+// postProduct, verilen anahtar ve gövdeyle ürün ekleme isteği gönderir ve yanıtı döner.
+func postProduct(e *echo.Echo, key string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if len(key) > 0 {
+		request.Header.Set(controller.IdempotencyKeyHeader, key)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func Test_WhenIdempotencyKeyIsRepeated_ShouldReplayOriginalResponse(t *testing.T) {
+	handlerCalls := 0
+	e := newIdempotentServer(&handlerCalls)
+	t.Run("WhenIdempotencyKeyIsRepeated_ShouldReplayOriginalResponse", func(t *testing.T) {
+		first := postProduct(e, "key-1", `{"name":"AirFryer"}`)
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, "/api/v1/products/1", first.Header().Get(echo.HeaderLocation))
+
+		replayed := postProduct(e, "key-1", `{"name":"AirFryer"}`)
+		assert.Equal(t, 1, handlerCalls)
+		assert.Equal(t, http.StatusCreated, replayed.Code)
+		assert.Equal(t, first.Body.String(), replayed.Body.String())
+		assert.Equal(t, "/api/v1/products/1", replayed.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, echo.MIMEApplicationJSON, replayed.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "true", replayed.Header().Get(controller.IdempotentReplayedHeader))
+
+		// Anahtarsız istekler her seferinde işlenir
+		postProduct(e, "", `{"name":"AirFryer"}`)
+		postProduct(e, "", `{"name":"AirFryer"}`)
+		assert.Equal(t, 3, handlerCalls)
+	})
+}
+
+func Test_WhenIdempotencyKeyIsReusedWithDifferentBody_ShouldReturn422(t *testing.T) {
+	handlerCalls := 0
+	e := newIdempotentServer(&handlerCalls)
+	t.Run("WhenIdempotencyKeyIsReusedWithDifferentBody_ShouldReturn422", func(t *testing.T) {
+		postProduct(e, "key-1", `{"name":"AirFryer"}`)
+		reused := postProduct(e, "key-1", `{"name":"Ütü"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+		assert.Contains(t, reused.Body.String(), "Idempotency-Key was already used with a different request")
+		assert.Equal(t, 1, handlerCalls)
+	})
+}
+
+func Test_WhenResponseIsServerError_ShouldNotSaveIt(t *testing.T) {
+	handlerCalls := 0
+	e := newIdempotentServer(&handlerCalls)
+	t.Run("WhenResponseIsServerError_ShouldNotSaveIt", func(t *testing.T) {
+		assert.Equal(t, http.StatusServiceUnavailable, postProduct(e, "key-1", `{"name":"fail"}`).Code)
+		assert.Equal(t, http.StatusServiceUnavailable, postProduct(e, "key-1", `{"name":"fail"}`).Code)
+		assert.Equal(t, 2, handlerCalls)
+
+		// İstemci hataları da saklanır ve aynen döndürülür
+		assert.Equal(t, http.StatusUnprocessableEntity, postProduct(e, "key-2", `{"name":""}`).Code)
+		replayed := postProduct(e, "key-2", `{"name":""}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, replayed.Code)
+		assert.Contains(t, replayed.Body.String(), "Name is required")
+		assert.Equal(t, 3, handlerCalls)
+	})
+}
+
+func Test_WhenHandlerPanics_ShouldReleaseKey(t *testing.T) {
+	handlerCalls := 0
+	e := newIdempotentServer(&handlerCalls)
+	t.Run("WhenHandlerPanics_ShouldReleaseKey", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, postProduct(e, "key-1", `{"name":"panic"}`).Code)
+		assert.Equal(t, http.StatusInternalServerError, postProduct(e, "key-1", `{"name":"panic"}`).Code)
+		assert.Equal(t, 2, handlerCalls)
+	})
+}
+
+func Test_WhenResponseCanNotBeSaved_ShouldKeepKeyLocked(t *testing.T) {
+	handlerCalls := 0
+	e := newIdempotentServerWithRepository(&handlerCalls, failingSaveRepository{fakes.NewFakeIdempotencyRepository()})
+	t.Run("WhenResponseCanNotBeSaved_ShouldKeepKeyLocked", func(t *testing.T) {
+		assert.Equal(t, http.StatusCreated, postProduct(e, "key-1", `{"name":"AirFryer"}`).Code)
+		assert.Equal(t, http.StatusConflict, postProduct(e, "key-1", `{"name":"AirFryer"}`).Code)
+		assert.Equal(t, 1, handlerCalls)
+	})
+}
+
+func Test_WhenBodyIsTooLarge_ShouldReturn413(t *testing.T) {
+	handlerCalls := 0
+	e := newIdempotentServer(&handlerCalls)
+	t.Run("WhenBodyIsTooLarge_ShouldReturn413", func(t *testing.T) {
+		body := fmt.Sprintf(`{"name":"%s"}`, strings.Repeat("a", 1024))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, postProduct(e, "key-1", body).Code)
+		assert.Equal(t, 0, handlerCalls)
+	})
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"product-app/controller"
+	"product-app/controller/response"
+	"product-app/domain"
+	"product-app/persistence"
+	"product-app/service"
+	fakes "product-app/test/service"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newProductServer, bellekteki repository'lerle çalışan ürün kontrolcüsünün rotalarını kaydeder.
+func newProductServer() *echo.Echo {
+	stores := []domain.Store{{Id: 1, Name: "ABC TECH"}}
+	productRepository := fakes.NewFakeProductRepository([]domain.Product{})
+	storeRepository := fakes.NewFakeStoreRepository(stores, []domain.Product{})
+	campaignRepository := fakes.NewFakeCampaignRepository([]domain.Campaign{})
+	unitOfWork := fakes.NewFakeUnitOfWork(persistence.Repositories{
+		Products: productRepository, Stores: storeRepository, Campaigns: campaignRepository,
+	})
+	productService := service.NewProductService(productRepository, storeRepository, nil, campaignRepository, unitOfWork)
+	idempotencyService := service.NewIdempotencyService(fakes.NewFakeIdempotencyRepository(), time.Hour, time.Minute)
+
+	e := echo.New()
+	e.HTTPErrorHandler = controller.HTTPErrorHandler
+	controller.NewProductController(productService, idempotencyService, 1<<20).RegisterRoutes(e)
+	return e
+}
+
+func Test_WhenProductIsAdded_ShouldReturnCreatedProductWithLocation(t *testing.T) {
+	e := newProductServer()
+	t.Run("WhenProductIsAdded_ShouldReturnCreatedProductWithLocation", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/products",
+			strings.NewReader(`{"name":"Kettle","price":"750","discount":"10","storeId":1}`))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, "/api/v1/products/1", recorder.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, `"1"`, recorder.Header().Get("ETag"))
+		var productResponse response.ProductResponse
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &productResponse))
+		assert.Equal(t, int64(1), productResponse.Id)
+		assert.Equal(t, "Kettle", productResponse.Name)
+		assert.Equal(t, "750.00", productResponse.Price)
+		assert.Equal(t, "675.00", productResponse.FinalPrice)
+		assert.Equal(t, "ABC TECH", productResponse.Store)
+
+		getRequest := httptest.NewRequest(http.MethodGet, recorder.Header().Get(echo.HeaderLocation), nil)
+		getRecorder := httptest.NewRecorder()
+		e.ServeHTTP(getRecorder, getRequest)
+		assert.Equal(t, http.StatusOK, getRecorder.Code)
+		assert.JSONEq(t, recorder.Body.String(), getRecorder.Body.String())
+	})
+}
+
+// serve, isteği sunucuya gönderir ve yanıtı döner.
+func serve(e *echo.Echo, method string, target string, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set("If-Match", "*")
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func Test_WhenPutHasNewPriceParameter_ShouldOnlyUpdatePrice(t *testing.T) {
+	e := newProductServer()
+	serve(e, http.MethodPost, "/api/v1/products", `{"name":"Kettle","price":"750","storeId":1}`)
+	t.Run("WhenPutHasNewPriceParameter_ShouldOnlyUpdatePrice", func(t *testing.T) {
+		recorder := serve(e, http.MethodPut, "/api/v1/products/1?newPrice=900", "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "true", recorder.Header().Get("Deprecation"))
+
+		var product response.ProductResponse
+		assert.Nil(t, json.Unmarshal(serve(e, http.MethodGet, "/api/v1/products/1", "").Body.Bytes(), &product))
+		assert.Equal(t, "Kettle", product.Name)
+		assert.Equal(t, "900.00", product.Price)
+	})
+}
+
+func Test_ShouldGetPriceHistoryFilteredByDate(t *testing.T) {
+	e := newProductServer()
+	serve(e, http.MethodPost, "/api/v1/products", `{"name":"Kettle","price":"750","storeId":1}`)
+	serve(e, http.MethodPut, "/api/v1/products/1/price?newPrice=900", "")
+	t.Run("ShouldGetPriceHistoryFilteredByDate", func(t *testing.T) {
+		today := time.Now().Format(time.DateOnly)
+		recorder := serve(e, http.MethodGet, "/api/v1/products/1/price-history?from="+today+"&to="+today, "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		var priceHistory []response.PriceChangeResponse
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &priceHistory))
+		assert.Equal(t, 1, len(priceHistory))
+		assert.Equal(t, "750.00", priceHistory[0].OldPrice)
+		assert.Equal(t, "900.00", priceHistory[0].NewPrice)
+
+		yesterday := time.Now().AddDate(0, 0, -1).Format(time.DateOnly)
+		recorder = serve(e, http.MethodGet, "/api/v1/products/1/price-history?to="+yesterday, "")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.JSONEq(t, "[]", recorder.Body.String())
+
+		recorder = serve(e, http.MethodGet, "/api/v1/products/1/price-history?from=01-05-2024", "")
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, http.StatusNotFound, serve(e, http.MethodGet, "/api/v1/products/99/price-history", "").Code)
+	})
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"product-app/common/money"
+	"product-app/controller/export"
+	"product-app/controller/response"
+	"product-app/domain"
+	"product-app/service"
+	"strings"
+	"testing"
+)
+
+// exportProducts, ürünleri verilen biçimde yazar ve çıktıyı döner.
+func exportProducts(format export.Format, products ...domain.Product) []byte {
+	var output bytes.Buffer
+	productWriter := format.NewWriter(&output)
+	for _, product := range products {
+		if err := productWriter.Write(response.ToResponse(product)); err != nil {
+			panic(err)
+		}
+	}
+	if err := productWriter.Close(); err != nil {
+		panic(err)
+	}
+	return output.Bytes()
+}
+
+func exportedKettle() domain.Product {
+	campaignId := int64(3)
+	product := domain.Product{
+		Id:         7,
+		Name:       `Kettle "Mini", 1L`,
+		Price:      money.New(money.MustParse("19.99"), money.TRY),
+		Discount:   money.MustParse("15"),
+		StoreId:    1,
+		Store:      "ABC & TECH",
+		CampaignId: &campaignId,
+	}
+	product.Pricing = service.CalculatePrice(product)
+	return product
+}
+
+func Test_ShouldExportProductsAsCsvWithHeader(t *testing.T) {
+	t.Run("ShouldExportProductsAsCsvWithHeader", func(t *testing.T) {
+		assert.Equal(t, "id,name,price,currency,discount,discountAmount,finalPrice,storeId,store,campaignId\n"+
+			"7,\"Kettle \"\"Mini\"\", 1L\",19.99,TRY,15,3.00,16.99,1,ABC & TECH,3\n",
+			string(exportProducts(export.FormatCsv, exportedKettle())))
+
+		assert.Equal(t, "id,name,price,currency,discount,discountAmount,finalPrice,storeId,store,campaignId\n",
+			string(exportProducts(export.FormatCsv)))
+	})
+}
+
+func Test_WhenTextLooksLikeFormula_ShouldEscapeItInCsv(t *testing.T) {
+	t.Run("WhenTextLooksLikeFormula_ShouldEscapeItInCsv", func(t *testing.T) {
+		product := exportedKettle()
+		product.Name = `=HYPERLINK("http://evil.example","Tıkla")`
+		product.Store = "@SUM(A1)"
+		product.CampaignId = nil
+		assert.Equal(t, "id,name,price,currency,discount,discountAmount,finalPrice,storeId,store,campaignId\n"+
+			"7,\"'=HYPERLINK(\"\"http://evil.example\"\",\"\"Tıkla\"\")\",19.99,TRY,15,3.00,16.99,1,'@SUM(A1),\n",
+			string(exportProducts(export.FormatCsv, product)))
+	})
+}
+
+func Test_ShouldExportProductsAsNdjson(t *testing.T) {
+	t.Run("ShouldExportProductsAsNdjson", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(string(exportProducts(export.FormatNdjson, exportedKettle(), exportedKettle()))), "\n")
+		assert.Equal(t, 2, len(lines))
+		assert.JSONEq(t, `{
+			"id": 7, "name": "Kettle \"Mini\", 1L", "price": "19.99", "currency": "TRY", "discount": "15",
+			"discountAmount": "3.00", "finalPrice": "16.99", "storeId": 1, "store": "ABC & TECH", "campaignId": 3
+		}`, lines[0])
+	})
+}
+
+func Test_ShouldExportProductsAsExcelWorkbook(t *testing.T) {
+	t.Run("ShouldExportProductsAsExcelWorkbook", func(t *testing.T) {
+		workbook := exportProducts(export.FormatXlsx, exportedKettle())
+		archive, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+		assert.Nil(t, err)
+		var partNames []string
+		var sheet []byte
+		for _, part := range archive.File {
+			partNames = append(partNames, part.Name)
+			if part.Name == "xl/worksheets/sheet1.xml" {
+				partReader, _ := part.Open()
+				sheet, _ = io.ReadAll(partReader)
+				partReader.Close()
+			}
+		}
+		assert.ElementsMatch(t, []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"}, partNames)
+
+		var worksheet struct {
+			Rows []struct {
+				Cells []struct {
+					Reference  string `xml:"r,attr"`
+					Type       string `xml:"t,attr"`
+					Value      string `xml:"v"`
+					InlineText string `xml:"is>t"`
+				} `xml:"c"`
+			} `xml:"sheetData>row"`
+		}
+		assert.Nil(t, xml.Unmarshal(sheet, &worksheet))
+		assert.Equal(t, 2, len(worksheet.Rows))
+		assert.Equal(t, "id", worksheet.Rows[0].Cells[0].InlineText)
+		productCells := worksheet.Rows[1].Cells
+		assert.Equal(t, "7", productCells[0].Value)
+		assert.Equal(t, "B2", productCells[1].Reference)
+		assert.Equal(t, "inlineStr", productCells[1].Type)
+		assert.Equal(t, `Kettle "Mini", 1L`, productCells[1].InlineText)
+		assert.Equal(t, "19.99", productCells[2].Value)
+		assert.Equal(t, "ABC & TECH", productCells[8].InlineText)
+	})
+}
+
+func Test_WhenExportFormatIsUnsupported_ShouldReturnError(t *testing.T) {
+	t.Run("WhenExportFormatIsUnsupported_ShouldReturnError", func(t *testing.T) {
+		format, err := export.ParseFormat("")
+		assert.Nil(t, err)
+		assert.Equal(t, export.FormatCsv.Name, format.Name)
+
+		_, err = export.ParseFormat("pdf")
+		assert.EqualError(t, err, "Parameter format must be one of csv, ndjson, xlsx")
+	})
+}
@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/controller/request"
+	"product-app/domain"
+	"strings"
+	"testing"
+)
+
+func Test_ShouldParseCsvImportWithLineNumbersAndParseErrors(t *testing.T) {
+	t.Run("ShouldParseCsvImportWithLineNumbersAndParseErrors", func(t *testing.T) {
+		csvFile := "\ufeffName,Price,Currency,Discount,StoreId\n" +
+			"AirFryer,1999.90,try,10,1\n" +
+			"\n" +
+			"\"Ütü, buharlı\",abc,,,x\n"
+		rows, err := request.ParseProductImport(strings.NewReader(csvFile), request.ImportFormatCsv, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(rows))
+		assert.Equal(t, 2, rows[0].Line)
+		assert.Equal(t, "AirFryer", rows[0].Product.Name)
+		assert.Equal(t, money.MustParse("1999.9"), rows[0].Product.Price)
+		assert.Equal(t, money.TRY, rows[0].Product.Currency)
+		assert.Equal(t, int64(1), rows[0].Product.StoreId)
+		assert.Empty(t, rows[0].ParseErrors)
+
+		assert.Equal(t, 4, rows[1].Line)
+		assert.Equal(t, "Ütü, buharlı", rows[1].Product.Name)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "price", Message: "Price must be a decimal number"},
+			{Field: "storeId", Message: "StoreId must be an integer"},
+		}, rows[1].ParseErrors)
+	})
+}
+
+func Test_WhenCsvStructureIsInvalid_ShouldReturnError(t *testing.T) {
+	t.Run("WhenCsvStructureIsInvalid_ShouldReturnError", func(t *testing.T) {
+		_, err := request.ParseProductImport(strings.NewReader("name,price\nAirFryer,1000\n"), request.ImportFormatCsv, 10)
+		assert.EqualError(t, err, "CSV header must contain name, price and storeId columns")
+
+		_, err = request.ParseProductImport(strings.NewReader("name,price,storeId,color\n"), request.ImportFormatCsv, 10)
+		assert.EqualError(t, err, `Unknown CSV column "color"`)
+
+		_, err = request.ParseProductImport(strings.NewReader("name,price,storeId\nAirFryer,1000\n"), request.ImportFormatCsv, 10)
+		assert.NotNil(t, err)
+
+		_, err = request.ParseProductImport(strings.NewReader("name,price,storeId\nA,1,1\nB,1,1\n"), request.ImportFormatCsv, 1)
+		assert.EqualError(t, err, "Import file can not contain more than 1 products")
+	})
+}
+
+func Test_ShouldParseNdjsonImportWithNumbersOrStrings(t *testing.T) {
+	t.Run("ShouldParseNdjsonImportWithNumbersOrStrings", func(t *testing.T) {
+		ndjsonFile := `{"name": "AirFryer", "price": 1999.90, "discount": "15", "storeId": 1}` + "\n\n" +
+			`{"name": "Ütü", "price": "1,5", "storeId": "2", "currency": "eur"}` + "\n"
+		rows, err := request.ParseProductImport(strings.NewReader(ndjsonFile), request.ImportFormatNdjson, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(rows))
+		assert.Equal(t, money.MustParse("1999.9"), rows[0].Product.Price)
+		assert.Equal(t, money.MustParse("15"), rows[0].Product.Discount)
+		assert.Equal(t, 3, rows[1].Line)
+		assert.Equal(t, money.EUR, rows[1].Product.Currency)
+		assert.Equal(t, int64(2), rows[1].Product.StoreId)
+		assert.Equal(t, []domain.FieldError{{Field: "price", Message: "Price must be a decimal number"}}, rows[1].ParseErrors)
+
+		_, err = request.ParseProductImport(strings.NewReader("{\"name\": \"A\"}\n[1, 2]\n"), request.ImportFormatNdjson, 10)
+		assert.EqualError(t, err, "Line 2 is not a valid product JSON object")
+	})
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/controller/response"
+	"product-app/domain"
+	"product-app/service"
+	"testing"
+)
+
+func Test_ShouldIncludeIdAndPriceBreakdownInProductResponse(t *testing.T) {
+	t.Run("ShouldIncludeIdAndPriceBreakdownInProductResponse", func(t *testing.T) {
+		product := domain.Product{
+			Id:       7,
+			Name:     "Kettle",
+			Price:    money.New(money.MustParse("19.99"), money.TRY),
+			Discount: money.MustParse("15"),
+			StoreId:  1,
+			Store:    "ABC TECH",
+		}
+		product.Pricing = service.CalculatePrice(product)
+		productResponse := response.ToResponse(product)
+
+		encoded, err := json.Marshal(productResponse)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"id": 7,
+			"name": "Kettle",
+			"price": "19.99",
+			"currency": "TRY",
+			"discount": "15",
+			"discountAmount": "3.00",
+			"finalPrice": "16.99",
+			"storeId": 1,
+			"store": "ABC TECH",
+			"campaignId": null
+		}`, string(encoded))
+	})
+}
+
+func Test_ShouldIncludeCountsAndErrorsInProductBatchResponse(t *testing.T) {
+	t.Run("ShouldIncludeCountsAndErrorsInProductBatchResponse", func(t *testing.T) {
+		batchResponse := response.ToProductBatchResponse(domain.ProductBatchResult{
+			Mode: domain.BatchModeBestEffort,
+			Results: []domain.ProductOperationResult{
+				{Status: domain.BatchStatusSucceeded, ProductId: 5},
+				{Status: domain.BatchStatusFailed, ProductId: 99, Err: domain.NewNotFoundError("ID'si 99 olan ürün bulunamadı")},
+			},
+		}, func(err error) response.ErrorResponse {
+			return response.ErrorResponse{ErrorCode: response.ErrorCodeNotFound, ErrorDescription: err.Error()}
+		})
+
+		encoded, err := json.Marshal(batchResponse)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"mode": "bestEffort",
+			"succeeded": 1,
+			"failed": 1,
+			"rolledBack": 0,
+			"results": [
+				{"index": 0, "status": "succeeded", "id": 5},
+				{"index": 1, "status": "failed", "id": 99, "error": {"errorCode": "NOT_FOUND", "errorDescription": "ID'si 99 olan ürün bulunamadı"}}
+			]
+		}`, string(encoded))
+	})
+}
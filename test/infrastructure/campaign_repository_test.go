@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/persistence"
+	"testing"
+	"time"
+)
+
+func TestGetActiveCampaigns_ShouldReturnCampaignsWithTargetsInPeriod(t *testing.T) {
+	setup(ctx, dbPool)
+	campaignRepository := persistence.NewCampaignRepository(dbPool, 5*time.Second)
+	now := time.Now().UTC().Truncate(time.Second)
+	running, _ := campaignRepository.AddCampaign(ctx, domain.Campaign{
+		Name:       "Mağaza indirimi",
+		Discount:   money.MustParse("20"),
+		StartsAt:   now.Add(-time.Hour),
+		EndsAt:     now.Add(time.Hour),
+		ProductIds: []int64{2, 1},
+		StoreIds:   []int64{2},
+	})
+	campaignRepository.AddCampaign(ctx, domain.Campaign{
+		Name:       "Yılbaşı",
+		Discount:   money.MustParse("50"),
+		StartsAt:   now.Add(time.Hour),
+		EndsAt:     now.Add(2 * time.Hour),
+		ProductIds: []int64{1},
+	})
+	t.Run("GetActiveCampaigns_ShouldReturnCampaignsWithTargetsInPeriod", func(t *testing.T) {
+		activeCampaigns, err := campaignRepository.GetActiveCampaigns(ctx, now)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(activeCampaigns))
+		assert.Equal(t, running.Id, activeCampaigns[0].Id)
+		assert.Equal(t, []int64{1, 2}, activeCampaigns[0].ProductIds)
+		assert.Equal(t, []int64{2}, activeCampaigns[0].StoreIds)
+		assert.True(t, activeCampaigns[0].StartsAt.Equal(running.StartsAt))
+
+		allCampaigns, _ := campaignRepository.GetAllCampaigns(ctx)
+		assert.Equal(t, 2, len(allCampaigns))
+
+		running.ProductIds = []int64{}
+		assert.Nil(t, campaignRepository.UpdateCampaign(ctx, running))
+		updated, _ := campaignRepository.GetById(ctx, running.Id)
+		assert.Empty(t, updated.ProductIds)
+
+		err = campaignRepository.UpdateCampaign(ctx, domain.Campaign{Id: 99, Name: "Yok", Discount: money.MustParse("5"), StartsAt: now, EndsAt: now.Add(time.Hour)})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+	clear(ctx, dbPool)
+}
@@ -0,0 +1,50 @@
+package infrastructure
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"product-app/persistence"
+	"testing"
+	"time"
+)
+
+func TestFindProducts_ShouldIncludeDescendantCategories(t *testing.T) {
+	setup(ctx, dbPool)
+	categoryRepository := persistence.NewCategoryRepository(dbPool, 5*time.Second)
+	smallAppliances, _ := categoryRepository.AddCategory(ctx, domain.Category{Name: "Küçük Ev Aletleri"})
+	irons, _ := categoryRepository.AddCategory(ctx, domain.Category{Name: "Ütüler", ParentId: &smallAppliances.Id})
+	lighting, _ := categoryRepository.AddCategory(ctx, domain.Category{Name: "Aydınlatma"})
+	categoryRepository.SetCategoriesOfProduct(ctx, 1, []int64{smallAppliances.Id})
+	categoryRepository.SetCategoriesOfProduct(ctx, 2, []int64{irons.Id})
+	categoryRepository.SetCategoriesOfProduct(ctx, 4, []int64{lighting.Id})
+	t.Run("FindProducts_ShouldIncludeDescendantCategories", func(t *testing.T) {
+		sort := []domain.SortField{{Field: "id"}}
+		rootPage, err := productRepository.FindProducts(ctx, domain.ProductQuery{Limit: 10, Sort: sort, CategoryId: smallAppliances.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), rootPage.TotalCount)
+		assert.Equal(t, []int64{1, 2}, productIds(rootPage.Products))
+
+		leafPage, _ := productRepository.FindProducts(ctx, domain.ProductQuery{Limit: 10, Sort: sort, CategoryId: irons.Id})
+		assert.Equal(t, []int64{2}, productIds(leafPage.Products))
+
+		err = categoryRepository.DeleteById(ctx, smallAppliances.Id)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestFindProducts_WhenCategoriesFormACycle_ShouldTerminate(t *testing.T) {
+	setup(ctx, dbPool)
+	categoryRepository := persistence.NewCategoryRepository(dbPool, 5*time.Second)
+	first, _ := categoryRepository.AddCategory(ctx, domain.Category{Name: "Birinci"})
+	second, _ := categoryRepository.AddCategory(ctx, domain.Category{Name: "İkinci", ParentId: &first.Id})
+	// Repository döngü denetlemez; hatalı veri doğrudan oluşturulur.
+	categoryRepository.UpdateCategory(ctx, domain.Category{Id: first.Id, Name: first.Name, ParentId: &second.Id})
+	categoryRepository.SetCategoriesOfProduct(ctx, 1, []int64{second.Id})
+	t.Run("FindProducts_WhenCategoriesFormACycle_ShouldTerminate", func(t *testing.T) {
+		page, err := productRepository.FindProducts(ctx, domain.ProductQuery{Limit: 10, CategoryId: first.Id})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1}, productIds(page.Products))
+	})
+	clear(ctx, dbPool)
+}
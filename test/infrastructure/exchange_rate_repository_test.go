@@ -0,0 +1,42 @@
+package infrastructure
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/persistence"
+	"testing"
+	"time"
+)
+
+func TestGetRate(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("GetRate", func(t *testing.T) {
+		rateProvider := persistence.NewExchangeRateRepository(dbPool, 5*time.Second)
+
+		rate, err := rateProvider.GetRate(ctx, money.EUR, money.TRY)
+		assert.Nil(t, err)
+		assert.Equal(t, money.MustParse("35.25"), rate)
+
+		_, err = rateProvider.GetRate(ctx, money.USD, money.TRY)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestAddProduct_ShouldKeepCurrency(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("AddProduct_ShouldKeepCurrency", func(t *testing.T) {
+		productRepository.AddProduct(ctx, domain.Product{
+			Name:     "Kettle",
+			Price:    money.New(money.MustParse("49.90"), money.EUR),
+			Discount: money.MustParse("5"),
+			StoreId:  1,
+			Store:    "ABC TECH",
+		})
+		addedProduct, err := productRepository.GetById(ctx, 5)
+		assert.Nil(t, err)
+		assert.Equal(t, money.New(money.MustParse("49.9"), money.EUR), addedProduct.Price)
+	})
+	clear(ctx, dbPool)
+}
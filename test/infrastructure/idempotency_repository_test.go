@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"product-app/domain"
+	"product-app/persistence"
+	"testing"
+	"time"
+)
+
+func TestReserve_ShouldKeepKeyUntilItExpires(t *testing.T) {
+	idempotencyRepository := persistence.NewIdempotencyRepository(dbPool, 5*time.Second)
+	t.Run("Reserve_ShouldKeepKeyUntilItExpires", func(t *testing.T) {
+		_, reserved, err := idempotencyRepository.Reserve(ctx, "key-1", "hash-1", time.Hour, time.Minute)
+		assert.Nil(t, err)
+		assert.True(t, reserved)
+
+		record, reserved, _ := idempotencyRepository.Reserve(ctx, "key-1", "hash-2", time.Hour, time.Minute)
+		assert.False(t, reserved)
+		assert.Equal(t, "hash-1", record.RequestHash)
+		assert.Nil(t, record.Response)
+
+		response := domain.IdempotentResponse{
+			StatusCode: http.StatusCreated,
+			Header:     map[string][]string{"Location": {"/api/v1/products/5"}},
+			Body:       []byte(`{"id":5}`),
+		}
+		assert.Nil(t, idempotencyRepository.SaveResponse(ctx, "key-1", response))
+		assert.Nil(t, idempotencyRepository.Release(ctx, "key-1"))
+		record, reserved, _ = idempotencyRepository.Reserve(ctx, "key-1", "hash-1", time.Hour, time.Minute)
+		assert.False(t, reserved)
+		assert.Equal(t, &response, record.Response)
+
+		_, reserved, _ = idempotencyRepository.Reserve(ctx, "key-2", "hash-1", time.Millisecond, time.Minute)
+		assert.True(t, reserved)
+		time.Sleep(10 * time.Millisecond)
+		deleted, _ := idempotencyRepository.DeleteExpired(ctx)
+		assert.Equal(t, int64(1), deleted)
+		_, reserved, _ = idempotencyRepository.Reserve(ctx, "key-2", "hash-2", time.Hour, time.Minute)
+		assert.True(t, reserved)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestReserve_WhenLeaseExpiresWithoutResponse_ShouldAllowTakeOver(t *testing.T) {
+	idempotencyRepository := persistence.NewIdempotencyRepository(dbPool, 5*time.Second)
+	t.Run("Reserve_WhenLeaseExpiresWithoutResponse_ShouldAllowTakeOver", func(t *testing.T) {
+		_, reserved, _ := idempotencyRepository.Reserve(ctx, "key-1", "hash-1", time.Hour, time.Millisecond)
+		assert.True(t, reserved)
+		_, reserved, _ = idempotencyRepository.Reserve(ctx, "key-2", "hash-1", time.Hour, time.Millisecond)
+		assert.True(t, reserved)
+		assert.Nil(t, idempotencyRepository.SaveResponse(ctx, "key-2", domain.IdempotentResponse{StatusCode: http.StatusCreated}))
+		time.Sleep(10 * time.Millisecond)
+
+		// Yanıtı kaydedilmemiş anahtarın kilidi dolduğu için devralınır, yanıtı olan anahtar korunur.
+		_, reserved, _ = idempotencyRepository.Reserve(ctx, "key-1", "hash-1", time.Hour, time.Minute)
+		assert.True(t, reserved)
+		record, reserved, _ := idempotencyRepository.Reserve(ctx, "key-2", "hash-1", time.Hour, time.Minute)
+		assert.False(t, reserved)
+		assert.Equal(t, http.StatusCreated, record.Response.StatusCode)
+	})
+	clear(ctx, dbPool)
+}
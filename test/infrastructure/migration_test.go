@@ -0,0 +1,34 @@
+package infrastructure
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"product-app/persistence/migration"
+	"testing"
+	"time"
+)
+
+func TestMigrationStatus(t *testing.T) {
+	t.Run("MigrationStatus", func(t *testing.T) {
+		statuses, err := migration.NewMigrator(dbPool).Status(ctx)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, statuses)
+		for _, status := range statuses {
+			assert.True(t, status.Applied, "%04d_%s should be applied", status.Version, status.Name)
+		}
+	})
+}
+
+func TestMigrationStatus_WhenMigrationIsRunning_ShouldNotWaitForLock(t *testing.T) {
+	conn, _ := dbPool.Acquire(ctx)
+	defer conn.Release()
+	conn.Exec(ctx, "Select pg_advisory_lock(73546271)")
+	defer conn.Exec(ctx, "Select pg_advisory_unlock(73546271)")
+	t.Run("MigrationStatus_WhenMigrationIsRunning_ShouldNotWaitForLock", func(t *testing.T) {
+		statusCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+		statuses, err := migration.NewMigrator(dbPool).Status(statusCtx)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, statuses)
+	})
+}
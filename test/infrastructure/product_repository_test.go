@@ -2,33 +2,47 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"product-app/common/money"
 	"product-app/common/postgresql"
 	"product-app/domain"
 	"product-app/persistence"
+	"product-app/persistence/migration"
 	"testing"
+	"time"
 )
 
 var productRepository persistence.IProductRepository
+var storeRepository persistence.IStoreRepository
 var dbPool *pgxpool.Pool
 var ctx context.Context
 
 func TestMain(m *testing.M) {
 	ctx = context.Background()
 
-	dbPool = postgresql.GetConnectionPool(ctx, postgresql.Config{
+	var err error
+	dbPool, err = postgresql.GetConnectionPool(ctx, postgresql.Config{
 		Host:                  "localhost",
-		Port:                  "6432",
+		Port:                  6432,
 		DbName:                "productapp",
 		UserName:              "postgres",
 		Password:              "postgres",
-		MaxConnections:        "10",
-		MaxConnectionIdleTime: "30s",
+		MaxConnections:        10,
+		MaxConnectionIdleTime: 30 * time.Second,
 	})
-	productRepository = persistence.NewProductRepository(dbPool)
+	if err != nil {
+		panic(err)
+	}
+	// Testlerin ihtiyaç duyduğu şema migration'larla oluşturulur.
+	if err := migration.NewMigrator(dbPool).Up(ctx); err != nil {
+		panic(err)
+	}
+	productRepository = persistence.NewProductRepository(dbPool, 5*time.Second, time.Minute)
+	storeRepository = persistence.NewStoreRepository(dbPool, 5*time.Second)
 	fmt.Println("Before all tests")
 	exitCode := m.Run()
 	fmt.Println("After all tests")
@@ -49,34 +63,42 @@ func TestGetAllProducts(t *testing.T) {
 		{
 			Id:       1,
 			Name:     "AirFryer",
-			Price:    3000.0,
-			Discount: 22.0,
+			Price:    price("3000"),
+			Discount: money.MustParse("22"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		},
 		{
 			Id:       2,
 			Name:     "Ütü",
-			Price:    1500.0,
-			Discount: 10.0,
+			Price:    price("1500"),
+			Discount: money.MustParse("10"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		},
 		{
 			Id:       3,
 			Name:     "Çamaşır Makinesi",
-			Price:    10000.0,
-			Discount: 15.0,
+			Price:    price("10000"),
+			Discount: money.MustParse("15"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		},
 		{
 			Id:       4,
 			Name:     "Lambader",
-			Price:    2000.0,
-			Discount: 0.0,
+			Price:    price("2000"),
+			Discount: money.MustParse("0"),
+			StoreId:  2,
 			Store:    "Dekorasyon Sarayı",
+			Version:  1,
 		},
 	}
 	t.Run("GetAllProducts", func(t *testing.T) {
-		actualProducts := productRepository.GetAllProducts()
+		actualProducts, _ := productRepository.GetAllProducts(ctx)
 		assert.Equal(t, 4, len(actualProducts))
 		assert.Equal(t, expectedProducts, actualProducts)
 	})
@@ -91,27 +113,33 @@ func TestGetAllProductsByStore(t *testing.T) {
 		{
 			Id:       1,
 			Name:     "AirFryer",
-			Price:    3000.0,
-			Discount: 22.0,
+			Price:    price("3000"),
+			Discount: money.MustParse("22"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		},
 		{
 			Id:       2,
 			Name:     "Ütü",
-			Price:    1500.0,
-			Discount: 10.0,
+			Price:    price("1500"),
+			Discount: money.MustParse("10"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		},
 		{
 			Id:       3,
 			Name:     "Çamaşır Makinesi",
-			Price:    10000.0,
-			Discount: 15.0,
+			Price:    price("10000"),
+			Discount: money.MustParse("15"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		},
 	}
 	t.Run("GetAllProductsByStore", func(t *testing.T) {
-		actualProducts := productRepository.GetAllProductsByStore("ABC TECH")
+		actualProducts, _ := productRepository.GetAllProductsByStore(ctx, 1)
 		assert.Equal(t, 3, len(actualProducts))
 		assert.Equal(t, expectedProducts, actualProducts)
 	})
@@ -124,20 +152,26 @@ func TestAddProduct(t *testing.T) {
 		{
 			Id:       1,
 			Name:     "Kupa",
-			Price:    100.0,
-			Discount: 0.0,
+			Price:    price("100"),
+			Discount: money.MustParse("0"),
+			StoreId:  1,
 			Store:    "Kırtasiye Merkezi",
+			Version:  1,
 		},
 	}
+	storeRepository.AddStore(ctx, domain.Store{Name: "Kırtasiye Merkezi"})
 	newProduct := domain.Product{
 		Name:     "Kupa",
-		Price:    100.0,
-		Discount: 0.0,
+		Price:    price("100"),
+		Discount: money.MustParse("0"),
+		StoreId:  1,
 		Store:    "Kırtasiye Merkezi",
 	}
 	t.Run("AddProduct", func(t *testing.T) {
-		productRepository.AddProduct(newProduct)
-		actualProducts := productRepository.GetAllProducts()
+		addedProduct, err := productRepository.AddProduct(ctx, newProduct)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedProducts[0], addedProduct)
+		actualProducts, _ := productRepository.GetAllProducts(ctx)
 		assert.Equal(t, 1, len(actualProducts))
 		assert.Equal(t, expectedProducts, actualProducts)
 	})
@@ -148,14 +182,16 @@ func TestAddProduct(t *testing.T) {
 func TestGetProductById(t *testing.T) {
 	setup(ctx, dbPool)
 	t.Run("GetProductById", func(t *testing.T) {
-		actualProduct, _ := productRepository.GetById(1)
-		_, err := productRepository.GetById(5)
+		actualProduct, _ := productRepository.GetById(ctx, 1)
+		_, err := productRepository.GetById(ctx, 5)
 		assert.Equal(t, domain.Product{
 			Id:       1,
 			Name:     "AirFryer",
-			Price:    3000.0,
-			Discount: 22.0,
+			Price:    price("3000"),
+			Discount: money.MustParse("22"),
+			StoreId:  1,
 			Store:    "ABC TECH",
+			Version:  1,
 		}, actualProduct)
 		assert.Equal(t, "Product not found with id 5", err.Error())
 	})
@@ -165,8 +201,8 @@ func TestGetProductById(t *testing.T) {
 func TestDeleteById(t *testing.T) {
 	setup(ctx, dbPool)
 	t.Run("DeleteById", func(t *testing.T) {
-		productRepository.DeleteById(1)
-		_, err := productRepository.GetById(1)
+		productRepository.DeleteById(ctx, 1, domain.AnyVersion)
+		_, err := productRepository.GetById(ctx, 1)
 		assert.Equal(t, "Product not found with id 1", err.Error())
 	})
 	clear(ctx, dbPool)
@@ -175,11 +211,318 @@ func TestDeleteById(t *testing.T) {
 func TestUpdatePrice(t *testing.T) {
 	setup(ctx, dbPool)
 	t.Run("UpdatePrice", func(t *testing.T) {
-		productBeforeUpdate, _ := productRepository.GetById(1)
-		assert.Equal(t, float32(3000.0), productBeforeUpdate.Price)
-		productRepository.UpdatePrice(1, 4000.0)
-		productAfterUpdate, _ := productRepository.GetById(1)
-		assert.Equal(t, float32(4000.0), productAfterUpdate.Price)
+		productBeforeUpdate, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, price("3000"), productBeforeUpdate.Price)
+		productRepository.UpdatePrice(ctx, 1, money.MustParse("4000"), domain.AnyVersion)
+		productAfterUpdate, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, price("4000"), productAfterUpdate.Price)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestUpdateProduct(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("UpdateProduct", func(t *testing.T) {
+		productRepository.UpdateProduct(ctx, domain.Product{
+			Id:       1,
+			Name:     "AirFryer XL",
+			Price:    price("3500"),
+			Discount: money.MustParse("5"),
+			StoreId:  3,
+			Store:    "Mutfak Dünyası",
+		})
+		productAfterUpdate, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, domain.Product{
+			Id:       1,
+			Name:     "AirFryer XL",
+			Price:    price("3500"),
+			Discount: money.MustParse("5"),
+			StoreId:  3,
+			Store:    "Mutfak Dünyası",
+			Version:  2,
+		}, productAfterUpdate)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestFindProducts(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("FindProducts", func(t *testing.T) {
+		maxPrice := money.MustParse("5000")
+		sort := []domain.SortField{{Field: "price", Descending: true}, {Field: "id"}}
+		firstPage, err := productRepository.FindProducts(ctx, domain.ProductQuery{
+			Limit:    2,
+			Sort:     sort,
+			MaxPrice: &maxPrice,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), firstPage.TotalCount)
+		assert.Equal(t, []int64{1, 4}, productIds(firstPage.Products))
+
+		secondPage, err := productRepository.FindProducts(ctx, domain.ProductQuery{
+			Limit:    2,
+			Sort:     sort,
+			MaxPrice: &maxPrice,
+			Cursor:   firstPage.NextCursor,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{2}, productIds(secondPage.Products))
+		assert.Empty(t, secondPage.NextCursor)
+	})
+	clear(ctx, dbPool)
+}
+
+// price, testlerde kullanılan varsayılan para birimindeki fiyatı oluşturur.
+func price(amount string) money.Money {
+	return money.New(money.MustParse(amount), money.DefaultCurrency)
+}
+
+func productIds(products []domain.Product) []int64 {
+	var ids []int64
+	for _, product := range products {
+		ids = append(ids, product.Id)
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("Search", func(t *testing.T) {
+		washingMachines, err := productRepository.Search(ctx, "ÇAMAŞIR makine", 10)
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{3}, productIds(washingMachines))
+
+		irons, _ := productRepository.Search(ctx, "ütü", 10)
+		assert.Equal(t, []int64{2}, productIds(irons))
+	})
+	clear(ctx, dbPool)
+}
+
+func TestWhenContextIsCancelled_ShouldNotQuery(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("WhenContextIsCancelled_ShouldNotQuery", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := productRepository.GetAllProducts(cancelledCtx)
+		assert.NotNil(t, err)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestUpdatePrice_ShouldKeepExactDecimalValue(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("UpdatePrice_ShouldKeepExactDecimalValue", func(t *testing.T) {
+		productRepository.UpdatePrice(ctx, 1, money.MustParse("1999.99"), domain.AnyVersion)
+		productAfterUpdate, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, "1999.99", productAfterUpdate.Price.Amount.String())
+
+		minPrice := money.MustParse("1999.99")
+		page, err := productRepository.FindProducts(ctx, domain.ProductQuery{
+			Limit:    10,
+			MinPrice: &minPrice,
+			MaxPrice: &minPrice,
+			Sort:     []domain.SortField{{Field: domain.SortFieldId}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1}, productIds(page.Products))
+	})
+	clear(ctx, dbPool)
+}
+
+func TestUpdatePrice_ShouldRecordPriceHistory(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("UpdatePrice_ShouldRecordPriceHistory", func(t *testing.T) {
+		productRepository.UpdatePrice(domain.WithActor(ctx, "ayse.yilmaz"), 1, money.MustParse("3500"), domain.AnyVersion)
+		productRepository.UpdatePrice(ctx, 1, money.MustParse("3500"), domain.AnyVersion)
+
+		priceHistory, err := productRepository.GetPriceHistory(ctx, 1, domain.PriceHistoryQuery{})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(priceHistory))
+		assert.Equal(t, price("3000"), priceHistory[0].OldPrice)
+		assert.Equal(t, price("3500"), priceHistory[0].NewPrice)
+		assert.Equal(t, "ayse.yilmaz", priceHistory[0].Actor)
+
+		err = productRepository.UpdatePrice(ctx, 9, money.MustParse("3500"), domain.AnyVersion)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestImportProducts_ShouldCopyAllRowsOrNone(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("ImportProducts_ShouldCopyAllRowsOrNone", func(t *testing.T) {
+		importedRows, err := productRepository.ImportProducts(ctx, []domain.Product{
+			{Name: "Kettle", Price: price("749.99"), Discount: money.MustParse("12.5"), StoreId: 1},
+			{Name: "Tost Makinesi", Price: money.New(money.MustParse("45.5"), money.EUR), StoreId: 2},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), importedRows)
+		kettle, _ := productRepository.GetById(ctx, 5)
+		assert.Equal(t, price("749.99"), kettle.Price)
+		assert.Equal(t, money.MustParse("12.5"), kettle.Discount)
+
+		_, err = productRepository.ImportProducts(ctx, []domain.Product{
+			{Name: "Blender", Price: price("900"), StoreId: 1},
+			{Name: "Mikser", Price: price("800"), StoreId: 99},
+		})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		actualProducts, _ := productRepository.GetAllProducts(ctx)
+		assert.Equal(t, 6, len(actualProducts))
+	})
+	clear(ctx, dbPool)
+}
+
+func TestExportProducts_ShouldStreamAllMatchingRowsInBatches(t *testing.T) {
+	setup(ctx, dbPool)
+	var manyProducts []domain.Product
+	for index := 0; index < 1200; index++ {
+		manyProducts = append(manyProducts, domain.Product{Name: fmt.Sprintf("Kalem %d", index), Price: price("10"), StoreId: 2})
+	}
+	productRepository.ImportProducts(ctx, manyProducts)
+	t.Run("ExportProducts_ShouldStreamAllMatchingRowsInBatches", func(t *testing.T) {
+		minPrice := money.MustParse("1000")
+		sort := []domain.SortField{{Field: domain.SortFieldId}}
+		var exportedIds []int64
+		err := productRepository.ExportProducts(ctx, domain.ProductQuery{StoreId: 1, MinPrice: &minPrice, Sort: sort}, func(product domain.Product) error {
+			exportedIds = append(exportedIds, product.Id)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, exportedIds)
+
+		exportedCount := 0
+		err = productRepository.ExportProducts(ctx, domain.ProductQuery{StoreId: 2, Sort: sort}, func(product domain.Product) error {
+			exportedCount++
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1201, exportedCount)
+
+		stopErr := errors.New("yazılamadı")
+		err = productRepository.ExportProducts(ctx, domain.ProductQuery{Sort: sort}, func(product domain.Product) error {
+			return stopErr
+		})
+		assert.Equal(t, stopErr, err)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestExportProducts_WhenCancelledOrTimedOut_ShouldReleaseConnection(t *testing.T) {
+	setup(ctx, dbPool)
+	var manyProducts []domain.Product
+	for index := 0; index < 1200; index++ {
+		manyProducts = append(manyProducts, domain.Product{Name: fmt.Sprintf("Kalem %d", index), Price: price("10"), StoreId: 2})
+	}
+	productRepository.ImportProducts(ctx, manyProducts)
+	t.Run("ExportProducts_WhenCancelledOrTimedOut_ShouldReleaseConnection", func(t *testing.T) {
+		sort := []domain.SortField{{Field: domain.SortFieldId}}
+		requestCtx, cancel := context.WithCancel(ctx)
+		err := productRepository.ExportProducts(requestCtx, domain.ProductQuery{Sort: sort}, func(product domain.Product) error {
+			cancel()
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(0), dbPool.Stat().AcquiredConns())
+
+		shortRepository := persistence.NewProductRepository(dbPool, 5*time.Second, 50*time.Millisecond)
+		err = shortRepository.ExportProducts(ctx, domain.ProductQuery{Sort: sort}, func(product domain.Product) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+		assert.ErrorIs(t, err, domain.ErrUnavailable)
+		assert.Equal(t, int32(0), dbPool.Stat().AcquiredConns())
+	})
+	clear(ctx, dbPool)
+}
+
+func TestExecuteBatch_ShouldRollBackFailedOperationsBySavepointOrAll(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("ExecuteBatch_ShouldRollBackFailedOperationsBySavepointOrAll", func(t *testing.T) {
+		operations := []domain.ProductOperation{
+			{Type: domain.BatchOperationCreate, Product: domain.Product{Name: "Kettle", Price: price("750"), StoreId: 2}},
+			{Type: domain.BatchOperationUpdatePrice, ProductId: 1, NewPrice: money.MustParse("1234.5")},
+			{Type: domain.BatchOperationDelete, ProductId: 99},
+			{Type: domain.BatchOperationCreate, Product: domain.Product{Name: "Mikser", Price: price("800"), StoreId: 99}},
+			{Type: domain.BatchOperationDelete, ProductId: 2},
+		}
+
+		results, err := productRepository.ExecuteBatch(ctx, operations, true)
+		assert.Nil(t, err)
+		assert.Equal(t, domain.BatchStatusFailed, results[2].Status)
+		assert.ErrorIs(t, results[2].Err, domain.ErrNotFound)
+		for _, index := range []int{0, 1, 3, 4} {
+			assert.Equal(t, domain.BatchStatusRolledBack, results[index].Status)
+		}
+		actualProducts, _ := productRepository.GetAllProducts(ctx)
+		assert.Equal(t, 4, len(actualProducts))
+
+		results, err = productRepository.ExecuteBatch(ctx, operations, false)
+		assert.Nil(t, err)
+		assert.Equal(t, domain.BatchStatusSucceeded, results[0].Status)
+		assert.Equal(t, domain.BatchStatusSucceeded, results[1].Status)
+		assert.ErrorIs(t, results[2].Err, domain.ErrNotFound)
+		assert.ErrorIs(t, results[3].Err, domain.ErrValidation)
+		assert.Equal(t, domain.BatchStatusSucceeded, results[4].Status)
+
+		kettle, _ := productRepository.GetById(ctx, results[0].ProductId)
+		assert.Equal(t, "Kettle", kettle.Name)
+		updated, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("1234.5"), updated.Price.Amount)
+		_, getErr := productRepository.GetById(ctx, 2)
+		assert.ErrorIs(t, getErr, domain.ErrNotFound)
+		history, _ := productRepository.GetPriceHistory(ctx, 1, domain.PriceHistoryQuery{})
+		assert.Equal(t, 1, len(history))
+	})
+	clear(ctx, dbPool)
+}
+
+func TestUpdatePrice_ShouldRequireMatchingVersion(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("UpdatePrice_ShouldRequireMatchingVersion", func(t *testing.T) {
+		assert.Nil(t, productRepository.UpdatePrice(ctx, 1, money.MustParse("3200"), 1))
+		err := productRepository.UpdatePrice(ctx, 1, money.MustParse("3300"), 1)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+
+		product, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, int64(2), product.Version)
+		assert.Equal(t, price("3200"), product.Price)
+
+		product.Version = 1
+		assert.ErrorIs(t, productRepository.UpdateProduct(ctx, product), domain.ErrPreconditionFailed)
+		assert.ErrorIs(t, productRepository.DeleteById(ctx, 1, 1), domain.ErrPreconditionFailed)
+		assert.Nil(t, productRepository.DeleteById(ctx, 1, 2))
+	})
+	clear(ctx, dbPool)
+}
+
+func TestDeleteById_ShouldKeepProductInTrashUntilPurged(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("DeleteById_ShouldKeepProductInTrashUntilPurged", func(t *testing.T) {
+		assert.Nil(t, productRepository.DeleteById(ctx, 1, domain.AnyVersion))
+		assert.Nil(t, productRepository.DeleteById(ctx, 2, domain.AnyVersion))
+		assert.ErrorIs(t, productRepository.DeleteById(ctx, 1, domain.AnyVersion), domain.ErrNotFound)
+
+		actualProducts, _ := productRepository.GetAllProducts(ctx)
+		assert.Equal(t, []int64{3, 4}, productIds(actualProducts))
+		page, _ := productRepository.FindProducts(ctx, domain.ProductQuery{Limit: 10, Sort: []domain.SortField{{Field: domain.SortFieldId}}})
+		assert.Equal(t, int64(2), page.TotalCount)
+
+		trash, _ := productRepository.GetDeletedProducts(ctx)
+		assert.Equal(t, []int64{2, 1}, productIds(trash))
+		assert.NotNil(t, trash[0].DeletedAt)
+
+		assert.Nil(t, productRepository.RestoreById(ctx, 2))
+		assert.ErrorIs(t, productRepository.RestoreById(ctx, 2), domain.ErrNotFound)
+		restored, _ := productRepository.GetById(ctx, 2)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, int64(3), restored.Version)
+
+		purged, _ := productRepository.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+		assert.Equal(t, int64(0), purged)
+		purged, _ = productRepository.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+		assert.Equal(t, int64(1), purged)
+		trash, _ = productRepository.GetDeletedProducts(ctx)
+		assert.Equal(t, 0, len(trash))
 	})
 	clear(ctx, dbPool)
 }
@@ -0,0 +1,85 @@
+package infrastructure
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"product-app/persistence"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestModifyStock_WhenReservedConcurrently_ShouldNotOversell(t *testing.T) {
+	setup(ctx, dbPool)
+	stockRepository := persistence.NewStockRepository(dbPool, 5*time.Second)
+	stockRepository.ModifyStock(ctx, 1, 1, func(stock *domain.Stock) error {
+		return stock.Adjust(5)
+	})
+	t.Run("ModifyStock_WhenReservedConcurrently_ShouldNotOversell", func(t *testing.T) {
+		var waitGroup sync.WaitGroup
+		var mutex sync.Mutex
+		reservedCount := 0
+		for i := 0; i < 8; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				_, err := stockRepository.ModifyStock(ctx, 1, 1, func(stock *domain.Stock) error {
+					return stock.Reserve(1)
+				})
+				if err == nil {
+					mutex.Lock()
+					reservedCount++
+					mutex.Unlock()
+				}
+			}()
+		}
+		waitGroup.Wait()
+		assert.Equal(t, 5, reservedCount)
+
+		stocks, _ := stockRepository.GetStocksOfProduct(ctx, 1)
+		assert.Equal(t, []domain.Stock{{ProductId: 1, StoreId: 1, Quantity: 5, Reserved: 5}}, stocks)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestModifyStock_WhenFirstAdjustedConcurrently_ShouldKeepAllChanges(t *testing.T) {
+	setup(ctx, dbPool)
+	stockRepository := persistence.NewStockRepository(dbPool, 5*time.Second)
+	t.Run("ModifyStock_WhenFirstAdjustedConcurrently_ShouldKeepAllChanges", func(t *testing.T) {
+		var waitGroup sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				_, err := stockRepository.ModifyStock(ctx, 1, 1, func(stock *domain.Stock) error {
+					return stock.Adjust(1)
+				})
+				assert.Nil(t, err)
+			}()
+		}
+		waitGroup.Wait()
+
+		stocks, _ := stockRepository.GetStocksOfProduct(ctx, 1)
+		assert.Equal(t, []domain.Stock{{ProductId: 1, StoreId: 1, Quantity: 8}}, stocks)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestFindProducts_ShouldFilterByStock(t *testing.T) {
+	setup(ctx, dbPool)
+	stockRepository := persistence.NewStockRepository(dbPool, 5*time.Second)
+	stockRepository.ModifyStock(ctx, 2, 1, func(stock *domain.Stock) error {
+		return stock.Adjust(3)
+	})
+	t.Run("FindProducts_ShouldFilterByStock", func(t *testing.T) {
+		inStock, outOfStock := true, false
+		sort := []domain.SortField{{Field: "id"}}
+		inStockPage, err := productRepository.FindProducts(ctx, domain.ProductQuery{Limit: 10, Sort: sort, InStock: &inStock})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{2}, productIds(inStockPage.Products))
+
+		outOfStockPage, _ := productRepository.FindProducts(ctx, domain.ProductQuery{Limit: 10, Sort: sort, InStock: &outOfStock})
+		assert.Equal(t, []int64{1, 3, 4}, productIds(outOfStockPage.Products))
+	})
+	clear(ctx, dbPool)
+}
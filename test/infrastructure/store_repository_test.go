@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"testing"
+)
+
+func TestAddStore_WhenNameDiffersOnlyInCase_ShouldReturnConflict(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("AddStore_WhenNameDiffersOnlyInCase_ShouldReturnConflict", func(t *testing.T) {
+		_, err := storeRepository.AddStore(ctx, domain.Store{Name: "abc tech"})
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		addedStore, err := storeRepository.AddStore(ctx, domain.Store{Name: "Kırtasiye Merkezi"})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.Store{Id: 5, Name: "Kırtasiye Merkezi"}, addedStore)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestDeleteStore_WhenStoreHasProducts_ShouldReturnConflict(t *testing.T) {
+	setup(ctx, dbPool)
+	t.Run("DeleteStore_WhenStoreHasProducts_ShouldReturnConflict", func(t *testing.T) {
+		err := storeRepository.DeleteById(ctx, 1)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		assert.Nil(t, storeRepository.DeleteById(ctx, 3))
+		_, err = storeRepository.GetById(ctx, 3)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+	clear(ctx, dbPool)
+}
@@ -7,13 +7,13 @@ import (
 )
 
 func TruncateTestData(ctx context.Context, dbPool *pgxpool.Pool) {
-	// 'products' tablosunu sıfırlamak için truncate işlemi gerçekleştirilir.
-	_, truncateResultErr := dbPool.Exec(ctx, "TRUNCATE products RESTART IDENTITY")
+	// Ürün, mağaza, kategori, stok, fiyat geçmişi, kampanya, kur ve idempotency anahtarı tablolarını sıfırlamak için truncate işlemi gerçekleştirilir.
+	_, truncateResultErr := dbPool.Exec(ctx, "TRUNCATE products, stores, categories, product_categories, stocks, price_history, campaigns, campaign_products, campaign_stores, exchange_rates, idempotency_keys RESTART IDENTITY")
 	if truncateResultErr != nil {
 		// Hata oluşursa loglanır.
 		log.Error(truncateResultErr)
 	} else {
 		// İşlem başarılıysa bilgi logu yazdırılır.
-		log.Info("Products table truncated")
+		log.Info("Products, stores, categories, stocks, price history, campaigns, exchange rates and idempotency keys tables truncated")
 	}
 }
@@ -7,18 +7,41 @@ import (
 	"github.com/labstack/gommon/log"
 )
 
-var INSERT_PRODUCTS = `INSERT INTO products (name, price, discount,store) 
-VALUES('AirFryer',3000.0, 22.0, 'ABC TECH'),
-('Ütü',1500.0, 10.0, 'ABC TECH'),
-('Çamaşır Makinesi',10000.0, 15.0, 'ABC TECH'),
-('Lambader',2000.0, 0.0, 'Dekorasyon Sarayı');
+var INSERT_STORES = `INSERT INTO stores (name)
+VALUES('ABC TECH'),
+('Dekorasyon Sarayı'),
+('Mutfak Dünyası');
+`
+
+var INSERT_PRODUCTS = `INSERT INTO products (name, price, discount, store_id)
+VALUES('AirFryer',3000.0, 22.0, 1),
+('Ütü',1500.0, 10.0, 1),
+('Çamaşır Makinesi',10000.0, 15.0, 1),
+('Lambader',2000.0, 0.0, 2);
+`
+
+var INSERT_EXCHANGE_RATES = `INSERT INTO exchange_rates (source_currency, target_currency, rate)
+VALUES('EUR', 'TRY', 35.25),
+('TRY', 'EUR', 0.02837);
 `
 
 func TestDataInitialize(ctx context.Context, dbPool *pgxpool.Pool) {
+	insertStoresResult, insertStoresErr := dbPool.Exec(ctx, INSERT_STORES)
+	if insertStoresErr != nil {
+		log.Error(insertStoresErr)
+	} else {
+		log.Info(fmt.Sprintf("Stores data created with %d rows", insertStoresResult.RowsAffected()))
+	}
 	insertProductsResult, insertProductsErr := dbPool.Exec(ctx, INSERT_PRODUCTS)
 	if insertProductsErr != nil {
 		log.Error(insertProductsErr)
 	} else {
 		log.Info(fmt.Sprintf("Products data created with %d rows", insertProductsResult.RowsAffected()))
 	}
+	insertRatesResult, insertRatesErr := dbPool.Exec(ctx, INSERT_EXCHANGE_RATES)
+	if insertRatesErr != nil {
+		log.Error(insertRatesErr)
+	} else {
+		log.Info(fmt.Sprintf("Exchange rates data created with %d rows", insertRatesResult.RowsAffected()))
+	}
 }
@@ -0,0 +1,80 @@
+package infrastructure
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/common/postgresql"
+	"product-app/domain"
+	"product-app/persistence"
+	"testing"
+	"time"
+)
+
+func TestWithTx_ShouldRollBackAllRepositoryCallsOnError(t *testing.T) {
+	setup(ctx, dbPool)
+	unitOfWork := persistence.NewUnitOfWork(dbPool, 5*time.Second, persistence.TxOptions{})
+	t.Run("WithTx_ShouldRollBackAllRepositoryCallsOnError", func(t *testing.T) {
+		errAbort := errors.New("iptal")
+		err := unitOfWork.WithTx(ctx, func(repositories persistence.Repositories) error {
+			if updateErr := repositories.Products.UpdatePrice(ctx, 1, money.MustParse("999"), domain.AnyVersion); updateErr != nil {
+				return updateErr
+			}
+			if deleteErr := repositories.Products.DeleteById(ctx, 2, domain.AnyVersion); deleteErr != nil {
+				return deleteErr
+			}
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		unchanged, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("3000"), unchanged.Price.Amount)
+		_, getErr := productRepository.GetById(ctx, 2)
+		assert.Nil(t, getErr)
+
+		err = unitOfWork.WithTx(ctx, func(repositories persistence.Repositories) error {
+			return repositories.Products.DeleteById(ctx, 99, domain.AnyVersion)
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+	clear(ctx, dbPool)
+}
+
+func TestWithTxOptions_ShouldRetrySerializationFailures(t *testing.T) {
+	setup(ctx, dbPool)
+	unitOfWork := persistence.NewUnitOfWork(dbPool, 5*time.Second, persistence.TxOptions{})
+	t.Run("WithTxOptions_ShouldRetrySerializationFailures", func(t *testing.T) {
+		// İlk denemede ürün okunduktan sonra transaction dışından güncellenir; aynı ürünü
+		// güncellemeye çalışan serializable transaction serileştirme hatasıyla geri alınır.
+		updateConcurrently := func(attempt *int, concurrentPrice string) func(repositories persistence.Repositories) error {
+			return func(repositories persistence.Repositories) error {
+				*attempt++
+				if _, getErr := repositories.Products.GetById(ctx, 1); getErr != nil {
+					return getErr
+				}
+				if *attempt == 1 {
+					if err := productRepository.UpdatePrice(ctx, 1, money.MustParse(concurrentPrice), domain.AnyVersion); err != nil {
+						return err
+					}
+				}
+				return repositories.Products.UpdatePrice(ctx, 1, money.MustParse("2500"), domain.AnyVersion)
+			}
+		}
+
+		attempt := 0
+		options := persistence.TxOptions{IsolationLevel: postgresql.Serializable, MaxRetries: 2}
+		assert.Nil(t, unitOfWork.WithTxOptions(ctx, options, updateConcurrently(&attempt, "2800")))
+		assert.Equal(t, 2, attempt)
+		updated, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("2500"), updated.Price.Amount)
+
+		attempt = 0
+		options.MaxRetries = 0
+		err := unitOfWork.WithTxOptions(ctx, options, updateConcurrently(&attempt, "2700"))
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.Equal(t, 1, attempt)
+		concurrent, _ := productRepository.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("2700"), concurrent.Price.Amount)
+	})
+	clear(ctx, dbPool)
+}
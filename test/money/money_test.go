@@ -0,0 +1,95 @@
+package money
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"testing"
+)
+
+func Test_ShouldParseDecimalsWithoutPrecisionLoss(t *testing.T) {
+	t.Run("ShouldParseDecimalsWithoutPrecisionLoss", func(t *testing.T) {
+		assert.Equal(t, "1999.99", money.MustParse("1999.99").String())
+		assert.Equal(t, "1500", money.MustParse("1500.00").String())
+		assert.Equal(t, "-0.5", money.MustParse("-.50").String())
+		assert.Equal(t, "3000", money.MustParse("300000e-2").String())
+		assert.Equal(t, money.MustParse("70"), money.MustParse("70.0"))
+		assert.Equal(t, 3, money.MustParse("19.999").DecimalPlaces())
+	})
+}
+
+func Test_WhenDecimalIsInvalid_ShouldReturnError(t *testing.T) {
+	t.Run("WhenDecimalIsInvalid_ShouldReturnError", func(t *testing.T) {
+		for _, value := range []string{"", ".", "-", "1,5", "abc", "1.2.3", "NaN"} {
+			_, err := money.Parse(value)
+			assert.ErrorIs(t, err, money.ErrInvalidDecimal, value)
+		}
+		_, err := money.Parse("12345678901234567890")
+		assert.ErrorIs(t, err, money.ErrOutOfRange)
+	})
+}
+
+func Test_ShouldRoundHalfAwayFromZero(t *testing.T) {
+	t.Run("ShouldRoundHalfAwayFromZero", func(t *testing.T) {
+		assert.Equal(t, "2.35", money.MustParse("2.345").Round(2).String())
+		assert.Equal(t, "-2.35", money.MustParse("-2.345").Round(2).String())
+		assert.Equal(t, "2.34", money.MustParse("2.3449").Round(2).String())
+		assert.Equal(t, "1500.00", money.MustParse("1500").StringFixed(2))
+		assert.Equal(t, "0.05", money.MustParse("0.046").StringFixed(2))
+	})
+}
+
+func Test_ShouldApplyDiscountWithRoundedDiscountAmount(t *testing.T) {
+	t.Run("ShouldApplyDiscountWithRoundedDiscountAmount", func(t *testing.T) {
+		price := money.New(money.MustParse("19.99"), money.TRY)
+		rate := money.MustParse("15")
+
+		discountAmount := price.Percent(rate)
+		finalPrice := price.ApplyDiscount(rate)
+
+		assert.Equal(t, "3.00 TRY", discountAmount.String())
+		assert.Equal(t, "16.99 TRY", finalPrice.String())
+		assert.Equal(t, price.Amount, finalPrice.Amount.Add(discountAmount.Amount))
+	})
+}
+
+func Test_ShouldEncodeDecimalAsJsonStringAndAcceptNumbers(t *testing.T) {
+	t.Run("ShouldEncodeDecimalAsJsonStringAndAcceptNumbers", func(t *testing.T) {
+		encoded, err := json.Marshal(money.MustParse("0.10"))
+		assert.Nil(t, err)
+		assert.Equal(t, `"0.1"`, string(encoded))
+
+		var fromString, fromNumber money.Decimal
+		assert.Nil(t, json.Unmarshal([]byte(`"1999.99"`), &fromString))
+		assert.Nil(t, json.Unmarshal([]byte(`1999.99`), &fromNumber))
+		assert.Equal(t, money.MustParse("1999.99"), fromString)
+		assert.Equal(t, fromString, fromNumber)
+	})
+}
+
+func Test_ShouldCompareAndScanDecimals(t *testing.T) {
+	t.Run("ShouldCompareAndScanDecimals", func(t *testing.T) {
+		assert.Equal(t, -1, money.MustParse("9.99").Cmp(money.MustParse("10")))
+		assert.Equal(t, 0, money.MustParse("10.00").Cmp(money.NewFromInt(10)))
+		assert.Equal(t, 1, money.MustParse("0.1").Cmp(money.MustParse("0.09")))
+
+		var scanned money.Decimal
+		assert.Nil(t, scanned.Scan("150050e-2"))
+		assert.Equal(t, money.MustParse("1500.5"), scanned)
+		assert.NotNil(t, scanned.Scan(nil))
+	})
+}
+
+func Test_ShouldParseSupportedCurrenciesAndConvert(t *testing.T) {
+	t.Run("ShouldParseSupportedCurrenciesAndConvert", func(t *testing.T) {
+		currency, err := money.ParseCurrency(" eur ")
+		assert.Nil(t, err)
+		assert.Equal(t, money.EUR, currency)
+
+		_, err = money.ParseCurrency("GBP")
+		assert.ErrorIs(t, err, money.ErrUnsupportedCurrency)
+
+		converted := money.New(money.MustParse("1000"), money.TRY).Convert(money.MustParse("0.028375"), money.EUR)
+		assert.Equal(t, "28.38 EUR", converted.String())
+	})
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/puddle"
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"testing"
+)
+
+func Test_WhenContextIsCancelled_ShouldNotTranslateToUnavailable(t *testing.T) {
+	t.Run("WhenContextIsCancelled_ShouldNotTranslateToUnavailable", func(t *testing.T) {
+		err := common.TranslateError(fmt.Errorf("sorgu kesildi: %w", context.Canceled), "Tüm ürünler alınırken hata oluştu")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, errors.Is(err, domain.ErrUnavailable))
+
+		err = common.TranslateError(fmt.Errorf("sorgu kesildi: %w", context.DeadlineExceeded), "Tüm ürünler alınırken hata oluştu")
+		assert.ErrorIs(t, err, domain.ErrUnavailable)
+	})
+}
+
+func Test_WhenPoolIsClosed_ShouldTranslateToUnavailable(t *testing.T) {
+	t.Run("WhenPoolIsClosed_ShouldTranslateToUnavailable", func(t *testing.T) {
+		err := common.TranslateError(puddle.ErrClosedPool, "Tüm ürünler alınırken hata oluştu")
+		assert.ErrorIs(t, err, domain.ErrUnavailable)
+
+		err = common.TranslateError(errors.New("closed pool"), "Tüm ürünler alınırken hata oluştu")
+		assert.False(t, errors.Is(err, domain.ErrUnavailable))
+	})
+}
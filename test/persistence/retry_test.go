@@ -0,0 +1,69 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"product-app/persistence/common"
+	"testing"
+	"time"
+)
+
+var serializationFailure = &pgconn.PgError{Code: common.SerializationFailureCode}
+
+func Test_WhenTransactionHasSerializationFailure_ShouldRetry(t *testing.T) {
+	t.Run("WhenTransactionHasSerializationFailure_ShouldRetry", func(t *testing.T) {
+		attempts := 0
+		err := common.RetryTx(context.Background(), 3, time.Millisecond, func() error {
+			attempts++
+			if attempts < 3 {
+				return domain.NewConflictError("çakışma", serializationFailure)
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+}
+
+func Test_WhenRetriesAreExhausted_ShouldReturnConflictError(t *testing.T) {
+	t.Run("WhenRetriesAreExhausted_ShouldReturnConflictError", func(t *testing.T) {
+		attempts := 0
+		err := common.RetryTx(context.Background(), 2, time.Millisecond, func() error {
+			attempts++
+			return &pgconn.PgError{Code: common.DeadlockDetectedCode}
+		})
+		assert.Equal(t, 3, attempts)
+		assert.ErrorIs(t, common.TranslateError(err, "Transaction tamamlanamadı"), domain.ErrConflict)
+	})
+}
+
+func Test_WhenErrorIsNotRetryable_ShouldNotRetry(t *testing.T) {
+	t.Run("WhenErrorIsNotRetryable_ShouldNotRetry", func(t *testing.T) {
+		attempts := 0
+		notFound := domain.NewNotFoundError("bulunamadı")
+		err := common.RetryTx(context.Background(), 3, time.Millisecond, func() error {
+			attempts++
+			return notFound
+		})
+		assert.Equal(t, notFound, err)
+		assert.Equal(t, 1, attempts)
+		assert.False(t, common.IsRetryable(errors.New("bağlantı koptu")))
+	})
+}
+
+func Test_WhenContextIsCancelled_ShouldStopRetrying(t *testing.T) {
+	t.Run("WhenContextIsCancelled_ShouldStopRetrying", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(context.Background())
+		cancel()
+		attempts := 0
+		err := common.RetryTx(cancelledCtx, 100, time.Hour, func() error {
+			attempts++
+			return serializationFailure
+		})
+		assert.ErrorIs(t, err, serializationFailure)
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -0,0 +1,61 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"product-app/common/postgresql"
+	"testing"
+	"time"
+)
+
+// unreachableConfig, dinlenmeyen bir porta bağlanmaya çalışan ayarları döner.
+func unreachableConfig(retries int) postgresql.Config {
+	return postgresql.Config{
+		Host:                  "127.0.0.1",
+		Port:                  1,
+		DbName:                "productapp",
+		UserName:              "postgres",
+		Password:              "postgres",
+		MaxConnections:        1,
+		MaxConnectionIdleTime: time.Second,
+		ConnectRetries:        retries,
+		ConnectRetryBackoff:   10 * time.Millisecond,
+	}
+}
+
+func Test_WhenDatabaseIsUnreachable_ShouldReturnErrorAfterRetries(t *testing.T) {
+	t.Run("WhenDatabaseIsUnreachable_ShouldReturnErrorAfterRetries", func(t *testing.T) {
+		dbPool, err := postgresql.GetConnectionPool(context.Background(), unreachableConfig(2))
+		assert.Nil(t, dbPool)
+		assert.ErrorContains(t, err, "3 denemede")
+	})
+}
+
+func Test_WhenContextIsCancelled_ShouldStopRetrying(t *testing.T) {
+	t.Run("WhenContextIsCancelled_ShouldStopRetrying", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		config := unreachableConfig(100)
+		config.ConnectRetryBackoff = time.Hour
+		dbPool, err := postgresql.GetConnectionPool(ctx, config)
+		assert.Nil(t, dbPool)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_WhenCredentialsHaveSpecialCharacters_ShouldKeepThemAsIs(t *testing.T) {
+	t.Run("WhenCredentialsHaveSpecialCharacters_ShouldKeepThemAsIs", func(t *testing.T) {
+		config := unreachableConfig(0)
+		config.UserName = "app user"
+		config.Password = `p@ss 'wo\rd' dbname=other`
+		config.DbName = "product app"
+		poolConfig, err := postgresql.NewPoolConfig(config)
+		assert.Nil(t, err)
+		assert.Equal(t, "127.0.0.1", poolConfig.ConnConfig.Host)
+		assert.Equal(t, uint16(1), poolConfig.ConnConfig.Port)
+		assert.Equal(t, "app user", poolConfig.ConnConfig.User)
+		assert.Equal(t, `p@ss 'wo\rd' dbname=other`, poolConfig.ConnConfig.Password)
+		assert.Equal(t, "product app", poolConfig.ConnConfig.Database)
+		assert.Equal(t, int32(1), poolConfig.MaxConns)
+	})
+}
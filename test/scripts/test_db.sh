@@ -20,21 +20,5 @@ sleep 3
 # Kullanıcıya veritabanının oluşturulduğunu bildirir.
 echo "Database productapp created"
 
-# PostgreSQL container'ında 'productapp' veritabanına bağlanır ve 'products' adında bir tablo oluşturur.
-# Tablo sütunları: id (bigserial, birincil anahtar), name (varchar, zorunlu), price (double precision, zorunlu),
-# discount (double precision, opsiyonel) ve store (varchar, zorunlu).
-winpty docker exec -it postgres-test psql -U postgres -d productapp -c "
-create table if not exists products
-(
-  id bigserial not null primary key,
-  name varchar(255) not null,
-  price double precision not null,
-  discount double precision,
-  store varchar(255) not null
-);
-"
-
-# Tablo oluşturulduktan sonra kısa bir bekleme süresi.
-sleep 3
-# Kullanıcıya tablonun oluşturulduğunu bildirir.
-echo "Table products created"
+# Tablolar bu script tarafından oluşturulmaz; şema, uygulama başlarken veya
+# "go run main.go migrate up" komutuyla persistence/migration altındaki migration'larla kurulur.
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"testing"
+	"time"
+)
+
+func Test_ShouldResolveEffectiveDiscountFromActiveCampaigns(t *testing.T) {
+	setup()
+	storeCampaign, _ := campaignService.Add(ctx, runningCampaign("Mağaza indirimi", "20", 0, nil, []int64{1}))
+	productCampaign, _ := campaignService.Add(ctx, runningCampaign("Ütü haftası", "35", 1, []int64{2}, nil))
+	t.Run("ShouldResolveEffectiveDiscountFromActiveCampaigns", func(t *testing.T) {
+		airFryer, err := productService.GetById(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, money.MustParse("20"), airFryer.Discount)
+		assert.Equal(t, &storeCampaign.Id, airFryer.CampaignId)
+
+		page, err := productService.GetProducts(ctx, domain.ProductQuery{})
+		assert.Nil(t, err)
+		assert.Equal(t, money.MustParse("35"), page.Products[1].Discount)
+		assert.Equal(t, &productCampaign.Id, page.Products[1].CampaignId)
+	})
+}
+
+func Test_WhenCampaignsHaveSamePriority_ShouldApplyHigherDiscount(t *testing.T) {
+	setup()
+	campaignService.Add(ctx, runningCampaign("Mağaza indirimi", "15", 0, nil, []int64{1}))
+	campaignService.Add(ctx, runningCampaign("Fritöz günleri", "25", 0, []int64{1}, nil))
+	t.Run("WhenCampaignsHaveSamePriority_ShouldApplyHigherDiscount", func(t *testing.T) {
+		airFryer, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("25"), airFryer.Discount)
+	})
+}
+
+func Test_WhenCampaignIsNotActive_ShouldKeepProductDiscount(t *testing.T) {
+	setup()
+	staticDiscount := money.MustParse("10")
+	productService.Patch(ctx, 1, model.ProductPatch{Discount: &staticDiscount}, domain.AnyVersion)
+	upcoming := runningCampaign("Yılbaşı", "50", 0, []int64{1}, nil)
+	upcoming.StartsAt = time.Now().Add(24 * time.Hour)
+	upcoming.EndsAt = time.Now().Add(48 * time.Hour)
+	campaignService.Add(ctx, upcoming)
+	t.Run("WhenCampaignIsNotActive_ShouldKeepProductDiscount", func(t *testing.T) {
+		airFryer, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("10"), airFryer.Discount)
+		assert.Nil(t, airFryer.CampaignId)
+
+		activeCampaigns, err := campaignService.GetActiveCampaigns(ctx)
+		assert.Nil(t, err)
+		assert.Empty(t, activeCampaigns)
+	})
+}
+
+func Test_WhenCampaignDiscountIsHigherThan70_ShouldReturnValidationError(t *testing.T) {
+	setup()
+	t.Run("WhenCampaignDiscountIsHigherThan70_ShouldReturnValidationError", func(t *testing.T) {
+		_, err := campaignService.Add(ctx, runningCampaign("Depo boşaltma", "90", 0, []int64{2}, nil))
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "discount", Message: "Discount can not be greater than 70"},
+		}, validationErr.FieldErrors)
+
+		_, err = campaignService.Add(ctx, runningCampaign("Depo boşaltma", "70", 0, []int64{2}, nil))
+		assert.Nil(t, err)
+		iron, _ := productService.GetById(ctx, 2)
+		assert.Equal(t, money.MustParse("70"), iron.Discount)
+	})
+}
+
+func Test_WhenCampaignIsInvalid_ShouldReturnAllFieldErrors(t *testing.T) {
+	setup()
+	t.Run("WhenCampaignIsInvalid_ShouldReturnAllFieldErrors", func(t *testing.T) {
+		invalid := runningCampaign(" ", "0", 0, []int64{9}, []int64{2})
+		invalid.EndsAt = invalid.StartsAt
+		_, err := campaignService.Add(ctx, invalid)
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "name", Message: "Name is required"},
+			{Field: "discount", Message: "Discount must be greater than 0"},
+			{Field: "endsAt", Message: "EndsAt must be after startsAt"},
+			{Field: "productIds", Message: "Product with id 9 does not exist"},
+		}, validationErr.FieldErrors)
+
+		_, err = campaignService.Add(ctx, runningCampaign("Hedefsiz", "10", 0, nil, nil))
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "productIds", Message: "At least one product or store is required"},
+		}, validationErr.FieldErrors)
+	})
+}
+
+func Test_ShouldUpdateAndDeleteCampaign(t *testing.T) {
+	setup()
+	campaign, _ := campaignService.Add(ctx, runningCampaign("Mağaza indirimi", "20", 0, nil, []int64{1}))
+	t.Run("ShouldUpdateAndDeleteCampaign", func(t *testing.T) {
+		err := campaignService.Update(ctx, campaign.Id, model.CampaignUpdate(runningCampaign("Ütü haftası", "30", 2, []int64{2, 2}, nil)))
+		assert.Nil(t, err)
+		updated, _ := campaignService.GetById(ctx, campaign.Id)
+		assert.Equal(t, "Ütü haftası", updated.Name)
+		assert.Equal(t, []int64{2}, updated.ProductIds)
+		assert.Empty(t, updated.StoreIds)
+
+		airFryer, _ := productService.GetById(ctx, 1)
+		assert.Nil(t, airFryer.CampaignId)
+
+		assert.Nil(t, campaignService.DeleteById(ctx, campaign.Id))
+		assert.ErrorIs(t, campaignService.DeleteById(ctx, campaign.Id), domain.ErrNotFound)
+		assert.ErrorIs(t, campaignService.Update(ctx, campaign.Id, model.CampaignUpdate{}), domain.ErrNotFound)
+	})
+}
+
+// runningCampaign, bir saat önce başlamış ve bir saat sonra bitecek bir kampanya modeli oluşturur.
+func runningCampaign(name string, discount string, priority int, productIds []int64, storeIds []int64) model.CampaignCreate {
+	return model.CampaignCreate{
+		Name:       name,
+		Discount:   money.MustParse(discount),
+		StartsAt:   time.Now().Add(-time.Hour),
+		EndsAt:     time.Now().Add(time.Hour),
+		Priority:   priority,
+		ProductIds: productIds,
+		StoreIds:   storeIds,
+	}
+}
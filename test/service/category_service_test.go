@@ -0,0 +1,101 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/postgresql"
+	"product-app/domain"
+	"product-app/service/model"
+	"testing"
+)
+
+func Test_ShouldGetCategoriesAsTree(t *testing.T) {
+	setup()
+	t.Run("ShouldGetCategoriesAsTree", func(t *testing.T) {
+		categoryTree, err := categoryService.GetCategoryTree(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(categoryTree))
+		assert.Equal(t, "Küçük Ev Aletleri", categoryTree[0].Category.Name)
+		assert.Equal(t, []string{"Fritözler", "Ütüler"}, categoryNodeNames(categoryTree[0].Children))
+		assert.Equal(t, []string{"Buharlı Ütüler"}, categoryNodeNames(categoryTree[0].Children[1].Children))
+		assert.Empty(t, categoryTree[0].Children[0].Children)
+	})
+}
+
+func Test_ShouldAddCategoryUnderParent(t *testing.T) {
+	setup()
+	t.Run("ShouldAddCategoryUnderParent", func(t *testing.T) {
+		category, err := categoryService.Add(ctx, model.CategoryCreate{Name: " Kettle ", ParentId: categoryId(1)})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.Category{Id: 5, Name: "Kettle", ParentId: categoryId(1)}, category)
+
+		_, err = categoryService.Add(ctx, model.CategoryCreate{Name: "ütüler", ParentId: categoryId(1)})
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+}
+
+func Test_WhenParentCategoryDoesNotExist_ShouldNotAddCategory(t *testing.T) {
+	setup()
+	t.Run("WhenParentCategoryDoesNotExist_ShouldNotAddCategory", func(t *testing.T) {
+		_, err := categoryService.Add(ctx, model.CategoryCreate{Name: "Kettle", ParentId: categoryId(9)})
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "parentId", Message: "Parent category with id 9 does not exist"},
+		}, validationErr.FieldErrors)
+	})
+}
+
+func Test_WhenCategoryIsMovedUnderItsSubcategory_ShouldNotUpdateCategory(t *testing.T) {
+	setup()
+	t.Run("WhenCategoryIsMovedUnderItsSubcategory_ShouldNotUpdateCategory", func(t *testing.T) {
+		err := categoryService.Update(ctx, 2, model.CategoryUpdate{Name: "Ütüler", ParentId: categoryId(4)})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Equal(t, "Category can not be moved under itself or one of its subcategories", err.Error())
+
+		err = categoryService.Update(ctx, 4, model.CategoryUpdate{Name: "Buharlı Ütüler", ParentId: categoryId(1)})
+		assert.Nil(t, err)
+		movedCategory, _ := categoryService.GetById(ctx, 4)
+		assert.Equal(t, categoryId(1), movedCategory.ParentId)
+		assert.Equal(t, 2, unitOfWork.Transactions)
+		assert.Equal(t, postgresql.Serializable, unitOfWork.LastOptions.IsolationLevel)
+	})
+}
+
+func Test_ShouldSetCategoriesOfProduct(t *testing.T) {
+	setup()
+	t.Run("ShouldSetCategoriesOfProduct", func(t *testing.T) {
+		err := categoryService.SetCategoriesOfProduct(ctx, 2, []int64{4, 2, 4})
+		assert.Nil(t, err)
+		categories, _ := categoryService.GetCategoriesOfProduct(ctx, 2)
+		assert.Equal(t, []string{"Buharlı Ütüler", "Ütüler"}, categoryNames(categories))
+
+		err = categoryService.DeleteById(ctx, 4)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		err = categoryService.SetCategoriesOfProduct(ctx, 2, []int64{9})
+		assert.Equal(t, "Category with id 9 does not exist", err.Error())
+
+		err = categoryService.SetCategoriesOfProduct(ctx, 5, []int64{1})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func categoryId(id int64) *int64 {
+	return &id
+}
+
+func categoryNodeNames(nodes []domain.CategoryNode) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Category.Name)
+	}
+	return names
+}
+
+func categoryNames(categories []domain.Category) []string {
+	var names []string
+	for _, category := range categories {
+		names = append(names, category.Name)
+	}
+	return names
+}
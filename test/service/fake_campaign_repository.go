@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"product-app/domain"
+	"product-app/persistence"
+	"sort"
+	"time"
+)
+
+// FakeCampaignRepository, kampanyaları bellekte tutan test repository'sidir.
+type FakeCampaignRepository struct {
+	campaigns []domain.Campaign
+	nextId    int64
+}
+
+func NewFakeCampaignRepository(initialCampaigns []domain.Campaign) persistence.ICampaignRepository {
+	nextId := int64(1)
+	for _, campaign := range initialCampaigns {
+		if campaign.Id >= nextId {
+			nextId = campaign.Id + 1
+		}
+	}
+	return &FakeCampaignRepository{
+		campaigns: append([]domain.Campaign{}, initialCampaigns...),
+		nextId:    nextId,
+	}
+}
+
+func (fakeRepository *FakeCampaignRepository) GetAllCampaigns(ctx context.Context) ([]domain.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	campaigns := append([]domain.Campaign{}, fakeRepository.campaigns...)
+	sort.SliceStable(campaigns, func(i, j int) bool {
+		return campaigns[i].StartsAt.Before(campaigns[j].StartsAt)
+	})
+	return campaigns, nil
+}
+
+func (fakeRepository *FakeCampaignRepository) GetActiveCampaigns(ctx context.Context, at time.Time) ([]domain.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var campaigns = []domain.Campaign{}
+	for _, campaign := range fakeRepository.campaigns {
+		if campaign.IsActiveAt(at) {
+			campaigns = append(campaigns, campaign)
+		}
+	}
+	return campaigns, nil
+}
+
+func (fakeRepository *FakeCampaignRepository) GetById(ctx context.Context, campaignId int64) (domain.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Campaign{}, err
+	}
+	for _, campaign := range fakeRepository.campaigns {
+		if campaign.Id == campaignId {
+			return campaign, nil
+		}
+	}
+	return domain.Campaign{}, domain.NewNotFoundError("Kampanya bulunamadı")
+}
+
+func (fakeRepository *FakeCampaignRepository) AddCampaign(ctx context.Context, campaign domain.Campaign) (domain.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Campaign{}, err
+	}
+	campaign.Id = fakeRepository.nextId
+	fakeRepository.nextId++
+	fakeRepository.campaigns = append(fakeRepository.campaigns, campaign)
+	return campaign, nil
+}
+
+func (fakeRepository *FakeCampaignRepository) UpdateCampaign(ctx context.Context, campaign domain.Campaign) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for index, existing := range fakeRepository.campaigns {
+		if existing.Id == campaign.Id {
+			fakeRepository.campaigns[index] = campaign
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Kampanya bulunamadı")
+}
+
+func (fakeRepository *FakeCampaignRepository) DeleteById(ctx context.Context, campaignId int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for index, campaign := range fakeRepository.campaigns {
+		if campaign.Id == campaignId {
+			fakeRepository.campaigns = append(fakeRepository.campaigns[:index], fakeRepository.campaigns[index+1:]...)
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Kampanya bulunamadı")
+}
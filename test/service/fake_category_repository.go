@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"product-app/common/text"
+	"product-app/domain"
+	"product-app/persistence"
+	"sort"
+	"strings"
+)
+
+// FakeCategoryRepository, kategorileri ve ürün-kategori bağlantılarını bellekte tutan test repository'sidir.
+type FakeCategoryRepository struct {
+	categories        []domain.Category
+	productCategories map[int64][]int64
+}
+
+func NewFakeCategoryRepository(initialCategories []domain.Category) persistence.ICategoryRepository {
+	return &FakeCategoryRepository{
+		categories:        initialCategories,
+		productCategories: map[int64][]int64{},
+	}
+}
+
+func (fakeRepository *FakeCategoryRepository) GetAllCategories(ctx context.Context) ([]domain.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	categories := append([]domain.Category{}, fakeRepository.categories...)
+	sort.SliceStable(categories, func(i, j int) bool {
+		return strings.Compare(categories[i].Name, categories[j].Name) < 0
+	})
+	return categories, nil
+}
+
+func (fakeRepository *FakeCategoryRepository) GetById(ctx context.Context, categoryId int64) (domain.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Category{}, err
+	}
+	for _, category := range fakeRepository.categories {
+		if category.Id == categoryId {
+			return category, nil
+		}
+	}
+	return domain.Category{}, domain.NewNotFoundError("Kategori bulunamadı")
+}
+
+func (fakeRepository *FakeCategoryRepository) AddCategory(ctx context.Context, category domain.Category) (domain.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Category{}, err
+	}
+	if fakeRepository.nameTaken(category) {
+		return domain.Category{}, domain.NewConflictError("Kategori zaten mevcut", nil)
+	}
+	category.Id = int64(len(fakeRepository.categories)) + 1
+	fakeRepository.categories = append(fakeRepository.categories, category)
+	return category, nil
+}
+
+func (fakeRepository *FakeCategoryRepository) UpdateCategory(ctx context.Context, category domain.Category) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if fakeRepository.nameTaken(category) {
+		return domain.NewConflictError("Kategori zaten mevcut", nil)
+	}
+	for i, existing := range fakeRepository.categories {
+		if existing.Id == category.Id {
+			fakeRepository.categories[i] = category
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Kategori bulunamadı")
+}
+
+func (fakeRepository *FakeCategoryRepository) DeleteById(ctx context.Context, categoryId int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, category := range fakeRepository.categories {
+		if category.ParentId != nil && *category.ParentId == categoryId {
+			return domain.NewConflictError("Kategorinin alt kategorileri var", nil)
+		}
+	}
+	for _, categoryIds := range fakeRepository.productCategories {
+		for _, linkedId := range categoryIds {
+			if linkedId == categoryId {
+				return domain.NewConflictError("Kategoriye bağlı ürünler var", nil)
+			}
+		}
+	}
+	for index, category := range fakeRepository.categories {
+		if category.Id == categoryId {
+			fakeRepository.categories = append(fakeRepository.categories[:index], fakeRepository.categories[index+1:]...)
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Kategori bulunamadı")
+}
+
+func (fakeRepository *FakeCategoryRepository) GetCategoriesOfProduct(ctx context.Context, productId int64) ([]domain.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	categories := []domain.Category{}
+	for _, categoryId := range fakeRepository.productCategories[productId] {
+		category, _ := fakeRepository.GetById(ctx, categoryId)
+		categories = append(categories, category)
+	}
+	sort.SliceStable(categories, func(i, j int) bool {
+		return strings.Compare(categories[i].Name, categories[j].Name) < 0
+	})
+	return categories, nil
+}
+
+func (fakeRepository *FakeCategoryRepository) SetCategoriesOfProduct(ctx context.Context, productId int64, categoryIds []int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	fakeRepository.productCategories[productId] = categoryIds
+	return nil
+}
+
+// nameTaken, aynı üst kategoride aynı adda başka bir kategori olup olmadığını döner.
+func (fakeRepository *FakeCategoryRepository) nameTaken(category domain.Category) bool {
+	for _, existing := range fakeRepository.categories {
+		if existing.Id != category.Id && sameParent(existing.ParentId, category.ParentId) &&
+			text.Normalize(existing.Name) == text.Normalize(category.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameParent(left *int64, right *int64) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+	return *left == *right
+}
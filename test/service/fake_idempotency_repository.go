@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"product-app/domain"
+	"product-app/persistence"
+	"time"
+)
+
+// fakeIdempotencyKey, bellekte tutulan bir idempotency anahtarı ve son kullanma zamanıdır.
+type fakeIdempotencyKey struct {
+	record      domain.IdempotencyRecord
+	expiresAt   time.Time
+	lockedUntil time.Time
+}
+
+// FakeIdempotencyRepository, idempotency anahtarlarını bellekte tutan test repository'sidir.
+type FakeIdempotencyRepository struct {
+	keys map[string]*fakeIdempotencyKey
+}
+
+func NewFakeIdempotencyRepository() persistence.IIdempotencyRepository {
+	return &FakeIdempotencyRepository{
+		keys: map[string]*fakeIdempotencyKey{},
+	}
+}
+
+func (fakeRepository *FakeIdempotencyRepository) Reserve(ctx context.Context, key string, requestHash string, ttl time.Duration, lease time.Duration) (domain.IdempotencyRecord, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	// Anahtar yoksa, süresi dolmuşsa veya yanıtsız kilidi dolmuşsa yeni istek için ayrılır, aksi halde mevcut kayıt döner
+	existing, ok := fakeRepository.keys[key]
+	if ok && time.Now().Before(existing.expiresAt) && (existing.record.Response != nil || time.Now().Before(existing.lockedUntil)) {
+		return existing.record, false, nil
+	}
+	record := domain.IdempotencyRecord{Key: key, RequestHash: requestHash}
+	fakeRepository.keys[key] = &fakeIdempotencyKey{record: record, expiresAt: time.Now().Add(ttl), lockedUntil: time.Now().Add(lease)}
+	return record, true, nil
+}
+
+func (fakeRepository *FakeIdempotencyRepository) SaveResponse(ctx context.Context, key string, response domain.IdempotentResponse) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if existing, ok := fakeRepository.keys[key]; ok {
+		existing.record.Response = &response
+	}
+	return nil
+}
+
+func (fakeRepository *FakeIdempotencyRepository) Release(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Yalnızca yanıtı kaydedilmemiş anahtarlar silinir
+	if existing, ok := fakeRepository.keys[key]; ok && existing.record.Response == nil {
+		delete(fakeRepository.keys, key)
+	}
+	return nil
+}
+
+func (fakeRepository *FakeIdempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for key, existing := range fakeRepository.keys {
+		if !time.Now().Before(existing.expiresAt) {
+			delete(fakeRepository.keys, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
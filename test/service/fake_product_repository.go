@@ -1,13 +1,22 @@
 package service
 
 import (
-	"errors"
+	"context"
+	"product-app/common/money"
+	"product-app/common/text"
 	"product-app/domain"
 	"product-app/persistence"
+	"sort"
+	"strings"
+	"time"
 )
 
+// FakeProductRepository, ürünleri bellekte tutan test repository'sidir.
+// Gerçek repository gibi, iptal edilmiş veya süresi dolmuş bağlamlarda işlem yapmadan hata döner.
 type FakeProductRepository struct {
-	products []domain.Product
+	products        []domain.Product
+	deletedProducts []domain.Product // Çöp kutusundaki ürünler.
+	priceHistory    []domain.PriceChange
 }
 
 func NewFakeProductRepository(initialProducts []domain.Product) persistence.IProductRepository {
@@ -16,62 +25,389 @@ func NewFakeProductRepository(initialProducts []domain.Product) persistence.IPro
 	}
 }
 
-func (fakeRepository *FakeProductRepository) GetAllProducts() []domain.Product {
-	return fakeRepository.products
+func (fakeRepository *FakeProductRepository) GetAllProducts(ctx context.Context) ([]domain.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fakeRepository.products, nil
 }
 
-func (fakeRepository *FakeProductRepository) GetAllProductsByStore(storeName string) []domain.Product {
+func (fakeRepository *FakeProductRepository) GetAllProductsByStore(ctx context.Context, storeId int64) ([]domain.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	// Belirtilen mağazaya ait ürünleri döndüren fonksiyon
 	var filteredProducts []domain.Product
 	for _, product := range fakeRepository.products {
-		if product.Store == storeName {
+		if product.StoreId == storeId {
 			filteredProducts = append(filteredProducts, product)
 		}
 	}
-	return filteredProducts
+	return filteredProducts, nil
 }
 
-func (fakeRepository *FakeProductRepository) AddProduct(product domain.Product) error {
-	// Yeni bir ürünü ürün listesine ekler
+func (fakeRepository *FakeProductRepository) AddProduct(ctx context.Context, product domain.Product) (domain.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Product{}, err
+	}
+	// Yeni bir ürünü ürün listesine ekler ve ID'si atanmış hâlini döner
 	fakeRepository.products = append(fakeRepository.products, domain.Product{
-		Id:       int64(len(fakeRepository.products)) + 1,
+		Id:       int64(len(fakeRepository.products)+len(fakeRepository.deletedProducts)) + 1,
 		Name:     product.Name,
 		Price:    product.Price,
 		Discount: product.Discount,
+		StoreId:  product.StoreId,
 		Store:    product.Store,
+		Version:  1,
 	})
-	return nil
+	return fakeRepository.products[len(fakeRepository.products)-1], nil
 }
 
-func (fakeRepository *FakeProductRepository) GetById(productId int64) (domain.Product, error) {
+func (fakeRepository *FakeProductRepository) GetById(ctx context.Context, productId int64) (domain.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Product{}, err
+	}
 	// Belirtilen ID'ye sahip ürünü döndürür
 	for _, product := range fakeRepository.products {
 		if product.Id == productId {
 			return product, nil
 		}
 	}
-	return domain.Product{}, errors.New("Ürün bulunamadı")
+	return domain.Product{}, domain.NewNotFoundError("Ürün bulunamadı")
 }
 
-func (fakeRepository *FakeProductRepository) DeleteById(productId int64) error {
-	// Belirtilen ID'ye sahip ürünü siler
+func (fakeRepository *FakeProductRepository) DeleteById(ctx context.Context, productId int64, version int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Belirtilen ID'ye sahip ürünü, sürüm verilmişse yalnızca o sürümdeyse çöp kutusuna taşır
 	for index, product := range fakeRepository.products {
 		if product.Id == productId {
+			if version != domain.AnyVersion && product.Version != version {
+				return domain.NewPreconditionFailedError("Ürün beklenen sürümde değil")
+			}
+			deletedAt := time.Now()
+			product.DeletedAt = &deletedAt
+			product.Version++
+			fakeRepository.deletedProducts = append(fakeRepository.deletedProducts, product)
 			// Ürünü listeden kaldır
 			fakeRepository.products = append(fakeRepository.products[:index], fakeRepository.products[index+1:]...)
 			return nil
 		}
 	}
-	return errors.New("Ürün bulunamadı")
+	return domain.NewNotFoundError("Ürün bulunamadı")
 }
 
-func (fakeRepository *FakeProductRepository) UpdatePrice(productId int64, newPrice float32) error {
-	// Belirtilen ID'ye sahip ürünün fiyatını günceller
+func (fakeRepository *FakeProductRepository) GetDeletedProducts(ctx context.Context) ([]domain.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Çöp kutusundaki ürünleri en son silinenden başlayarak döndürür
+	deletedProducts := []domain.Product{}
+	for i := len(fakeRepository.deletedProducts) - 1; i >= 0; i-- {
+		deletedProducts = append(deletedProducts, fakeRepository.deletedProducts[i])
+	}
+	return deletedProducts, nil
+}
+
+func (fakeRepository *FakeProductRepository) RestoreById(ctx context.Context, productId int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Çöp kutusundaki ürünü silinme işaretini kaldırarak ürün listesine geri taşır
+	for index, product := range fakeRepository.deletedProducts {
+		if product.Id == productId {
+			product.DeletedAt = nil
+			product.Version++
+			fakeRepository.products = append(fakeRepository.products, product)
+			fakeRepository.deletedProducts = append(fakeRepository.deletedProducts[:index], fakeRepository.deletedProducts[index+1:]...)
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Ürün çöp kutusunda bulunamadı")
+}
+
+func (fakeRepository *FakeProductRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	// Verilen zamandan önce silinmiş ürünleri çöp kutusundan kalıcı olarak kaldırır
+	var keptProducts []domain.Product
+	for _, product := range fakeRepository.deletedProducts {
+		if !product.DeletedAt.Before(deletedBefore) {
+			keptProducts = append(keptProducts, product)
+		}
+	}
+	purged := int64(len(fakeRepository.deletedProducts) - len(keptProducts))
+	fakeRepository.deletedProducts = keptProducts
+	return purged, nil
+}
+
+func (fakeRepository *FakeProductRepository) UpdatePrice(ctx context.Context, productId int64, newPrice money.Decimal, version int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Belirtilen ID'ye sahip ürünün fiyatını, sürüm verilmişse yalnızca o sürümdeyse günceller
 	for i, product := range fakeRepository.products {
 		if product.Id == productId {
-			fakeRepository.products[i].Price = newPrice
+			if version != domain.AnyVersion && product.Version != version {
+				return domain.NewPreconditionFailedError("Ürün beklenen sürümde değil")
+			}
+			fakeRepository.products[i].Price.Amount = newPrice
+			fakeRepository.products[i].Version++
+			fakeRepository.recordPriceChange(ctx, productId, product.Price, fakeRepository.products[i].Price)
 			return nil
 		}
 	}
-	return errors.New("Ürün bulunamadı")
+	return domain.NewNotFoundError("Ürün bulunamadı")
+}
+
+func (fakeRepository *FakeProductRepository) UpdateProduct(ctx context.Context, product domain.Product) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// Belirtilen ID'ye sahip ürünün tüm alanlarını, sürüm verilmişse yalnızca o sürümdeyse değiştirir
+	for i, existing := range fakeRepository.products {
+		if existing.Id == product.Id {
+			if product.Version != domain.AnyVersion && existing.Version != product.Version {
+				return domain.NewPreconditionFailedError("Ürün beklenen sürümde değil")
+			}
+			product.Version = existing.Version + 1
+			fakeRepository.products[i] = product
+			fakeRepository.recordPriceChange(ctx, product.Id, existing.Price, product.Price)
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Ürün bulunamadı")
+}
+
+func (fakeRepository *FakeProductRepository) FindProducts(ctx context.Context, query domain.ProductQuery) (domain.ProductPage, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.ProductPage{}, err
+	}
+	// Filtreleri uygular, ürünleri sıralar ve imleç/offset/limit ile sayfayı keser
+	var filteredProducts []domain.Product
+	for _, product := range fakeRepository.products {
+		if query.MinPrice != nil && product.Price.Amount.Cmp(*query.MinPrice) < 0 {
+			continue
+		}
+		if query.MaxPrice != nil && product.Price.Amount.Cmp(*query.MaxPrice) > 0 {
+			continue
+		}
+		if query.MinDiscount != nil && product.Discount.Cmp(*query.MinDiscount) < 0 {
+			continue
+		}
+		if query.StoreId != 0 && product.StoreId != query.StoreId {
+			continue
+		}
+		filteredProducts = append(filteredProducts, product)
+	}
+	sort.SliceStable(filteredProducts, func(i, j int) bool {
+		return compareProducts(filteredProducts[i], filteredProducts[j], query.Sort) < 0
+	})
+
+	page := domain.ProductPage{TotalCount: int64(len(filteredProducts))}
+	if len(query.Cursor) > 0 {
+		cursorProduct, err := domain.DecodeProductCursor(query.Cursor, query.Sort)
+		if err != nil {
+			return domain.ProductPage{}, err
+		}
+		start := len(filteredProducts)
+		for i, product := range filteredProducts {
+			if compareProducts(product, cursorProduct, query.Sort) > 0 {
+				start = i
+				break
+			}
+		}
+		filteredProducts = filteredProducts[start:]
+	}
+	if query.Offset >= len(filteredProducts) {
+		page.Products = []domain.Product{}
+		return page, nil
+	}
+	filteredProducts = filteredProducts[query.Offset:]
+	if len(filteredProducts) > query.Limit {
+		filteredProducts = filteredProducts[:query.Limit]
+		page.NextCursor = domain.EncodeProductCursor(filteredProducts[query.Limit-1], query.Sort)
+	}
+	page.Products = filteredProducts
+	return page, nil
+}
+
+// compareProducts, iki ürünü verilen sıralama kriterlerine göre karşılaştırır
+func compareProducts(left domain.Product, right domain.Product, sortFields []domain.SortField) int {
+	for _, sortField := range sortFields {
+		result := 0
+		switch sortField.Field {
+		case domain.SortFieldName:
+			result = strings.Compare(left.Name, right.Name)
+		case domain.SortFieldStore:
+			result = strings.Compare(left.Store, right.Store)
+		case domain.SortFieldPrice:
+			result = left.Price.Amount.Cmp(right.Price.Amount)
+		case domain.SortFieldDiscount:
+			result = left.Discount.Cmp(right.Discount)
+		case domain.SortFieldId:
+			result = compareNumbers(left.Id, right.Id)
+		}
+		if sortField.Descending {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func compareNumbers(left int64, right int64) int {
+	if left < right {
+		return -1
+	}
+	if left > right {
+		return 1
+	}
+	return 0
+}
+
+func (fakeRepository *FakeProductRepository) Search(ctx context.Context, searchText string, limit int) ([]domain.Product, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Her arama kelimesinin ürün adındaki bir kelimenin öneki olduğu ürünleri döndürür,
+	// tam eşleşen kelime sayısı fazla olan ürünler öne alınır
+	queryTokens := text.Tokenize(searchText)
+	var matchedProducts []domain.Product
+	ranks := map[int64]int{}
+	for _, product := range fakeRepository.products {
+		nameTokens := text.Tokenize(product.Name)
+		matched, rank := true, 0
+		for _, queryToken := range queryTokens {
+			tokenMatched := false
+			for _, nameToken := range nameTokens {
+				if strings.HasPrefix(nameToken, queryToken) {
+					tokenMatched = true
+					if nameToken == queryToken {
+						rank++
+					}
+				}
+			}
+			matched = matched && tokenMatched
+		}
+		if matched {
+			matchedProducts = append(matchedProducts, product)
+			ranks[product.Id] = rank
+		}
+	}
+	sort.SliceStable(matchedProducts, func(i, j int) bool {
+		return ranks[matchedProducts[i].Id] > ranks[matchedProducts[j].Id]
+	})
+	if len(matchedProducts) > limit {
+		matchedProducts = matchedProducts[:limit]
+	}
+	return matchedProducts, nil
+}
+
+func (fakeRepository *FakeProductRepository) GetPriceHistory(ctx context.Context, productId int64, query domain.PriceHistoryQuery) ([]domain.PriceChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Tarih aralığına uyan değişiklikleri en yeniden eskiye doğru döndürür
+	priceChanges := []domain.PriceChange{}
+	for i := len(fakeRepository.priceHistory) - 1; i >= 0; i-- {
+		priceChange := fakeRepository.priceHistory[i]
+		if priceChange.ProductId != productId ||
+			(query.From != nil && priceChange.ChangedAt.Before(*query.From)) ||
+			(query.To != nil && !priceChange.ChangedAt.Before(*query.To)) {
+			continue
+		}
+		priceChanges = append(priceChanges, priceChange)
+	}
+	return priceChanges, nil
+}
+
+// recordPriceChange, fiyat değiştiyse değişikliği bağlamdaki aktörle birlikte geçmişe ekler
+func (fakeRepository *FakeProductRepository) recordPriceChange(ctx context.Context, productId int64, oldPrice money.Money, newPrice money.Money) {
+	if oldPrice.Amount.Cmp(newPrice.Amount) == 0 && oldPrice.Currency == newPrice.Currency {
+		return
+	}
+	fakeRepository.priceHistory = append(fakeRepository.priceHistory, domain.PriceChange{
+		Id:        int64(len(fakeRepository.priceHistory)) + 1,
+		ProductId: productId,
+		OldPrice:  oldPrice,
+		NewPrice:  newPrice,
+		ChangedAt: time.Now(),
+		Actor:     domain.ActorFromContext(ctx),
+	})
+}
+
+func (fakeRepository *FakeProductRepository) ImportProducts(ctx context.Context, products []domain.Product) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	for _, product := range products {
+		if _, err := fakeRepository.AddProduct(ctx, product); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(products)), nil
+}
+
+func (fakeRepository *FakeProductRepository) ExecuteBatch(ctx context.Context, operations []domain.ProductOperation, atomic bool) ([]domain.ProductOperationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	// Atomik modda geri alabilmek için ürünlerin ve fiyat geçmişinin kopyası tutulur
+	products := append([]domain.Product{}, fakeRepository.products...)
+	deletedProducts := append([]domain.Product{}, fakeRepository.deletedProducts...)
+	priceHistory := append([]domain.PriceChange{}, fakeRepository.priceHistory...)
+	results := make([]domain.ProductOperationResult, len(operations))
+	for index, operation := range operations {
+		productId := operation.ProductId
+		var err error
+		switch operation.Type {
+		case domain.BatchOperationCreate:
+			var created domain.Product
+			created, err = fakeRepository.AddProduct(ctx, operation.Product)
+			productId = created.Id
+		case domain.BatchOperationUpdatePrice:
+			err = fakeRepository.UpdatePrice(ctx, operation.ProductId, operation.NewPrice, domain.AnyVersion)
+		case domain.BatchOperationDelete:
+			err = fakeRepository.DeleteById(ctx, operation.ProductId, domain.AnyVersion)
+		}
+		if err == nil {
+			results[index] = domain.ProductOperationResult{Status: domain.BatchStatusSucceeded, ProductId: productId}
+			continue
+		}
+		results[index] = domain.ProductOperationResult{Status: domain.BatchStatusFailed, ProductId: productId, Err: err}
+		if atomic {
+			fakeRepository.products = products
+			fakeRepository.deletedProducts = deletedProducts
+			fakeRepository.priceHistory = priceHistory
+			for other := range results {
+				if other != index {
+					results[other] = domain.ProductOperationResult{Status: domain.BatchStatusRolledBack, ProductId: operations[other].ProductId}
+				}
+			}
+			return results, nil
+		}
+	}
+	return results, nil
+}
+
+func (fakeRepository *FakeProductRepository) ExportProducts(ctx context.Context, query domain.ProductQuery, handle func(product domain.Product) error) error {
+	// Sayfalama alanları yok sayılarak filtrelere uyan tüm ürünler sırayla verilir
+	query.Limit = len(fakeRepository.products)
+	query.Offset = 0
+	query.Cursor = ""
+	page, err := fakeRepository.FindProducts(ctx, query)
+	if err != nil {
+		return err
+	}
+	for _, product := range page.Products {
+		if err := handle(product); err != nil {
+			return err
+		}
+	}
+	return nil
 }
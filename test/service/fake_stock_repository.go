@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"product-app/domain"
+	"product-app/persistence"
+	"sync"
+)
+
+// FakeStockRepository, stokları bellekte tutan test repository'sidir.
+// Gerçek repository'deki satır kilidinin yerine tek bir mutex kullanır.
+type FakeStockRepository struct {
+	mutex  sync.Mutex
+	stocks []domain.Stock
+}
+
+func NewFakeStockRepository(initialStocks []domain.Stock) persistence.IStockRepository {
+	return &FakeStockRepository{
+		stocks: initialStocks,
+	}
+}
+
+func (fakeRepository *FakeStockRepository) GetStocksOfProduct(ctx context.Context, productId int64) ([]domain.Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stocks := []domain.Stock{}
+	for _, stock := range fakeRepository.stocks {
+		if stock.ProductId == productId {
+			stocks = append(stocks, stock)
+		}
+	}
+	return stocks, nil
+}
+
+func (fakeRepository *FakeStockRepository) GetLowStocks(ctx context.Context) ([]domain.Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stocks := []domain.Stock{}
+	for _, stock := range fakeRepository.stocks {
+		if stock.IsLow() {
+			stocks = append(stocks, stock)
+		}
+	}
+	return stocks, nil
+}
+
+func (fakeRepository *FakeStockRepository) ModifyStock(ctx context.Context, productId int64, storeId int64, modify func(stock *domain.Stock) error) (domain.Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Stock{}, err
+	}
+	fakeRepository.mutex.Lock()
+	defer fakeRepository.mutex.Unlock()
+	for i, existing := range fakeRepository.stocks {
+		if existing.ProductId == productId && existing.StoreId == storeId {
+			// Değişiklik bir kopya üzerinde yapılır, hata olursa kayıt değişmez.
+			stock := existing
+			if err := modify(&stock); err != nil {
+				return domain.Stock{}, err
+			}
+			fakeRepository.stocks[i] = stock
+			return stock, nil
+		}
+	}
+	stock := domain.Stock{ProductId: productId, StoreId: storeId}
+	if err := modify(&stock); err != nil {
+		return domain.Stock{}, err
+	}
+	fakeRepository.stocks = append(fakeRepository.stocks, stock)
+	return stock, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"product-app/common/text"
+	"product-app/domain"
+	"product-app/persistence"
+	"sort"
+	"strings"
+)
+
+// FakeStoreRepository, mağazaları bellekte tutan test repository'sidir.
+// Gerçek tablodaki benzersiz indeks gibi, adları harf büyüklüğü gözetmeden karşılaştırır.
+type FakeStoreRepository struct {
+	stores   []domain.Store
+	products []domain.Product // Mağazaya bağlı ürün kontrolü için kullanılan ürünler.
+}
+
+func NewFakeStoreRepository(initialStores []domain.Store, products []domain.Product) persistence.IStoreRepository {
+	return &FakeStoreRepository{
+		stores:   initialStores,
+		products: products,
+	}
+}
+
+func (fakeRepository *FakeStoreRepository) GetAllStores(ctx context.Context) ([]domain.Store, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stores := append([]domain.Store{}, fakeRepository.stores...)
+	sort.SliceStable(stores, func(i, j int) bool {
+		return strings.Compare(stores[i].Name, stores[j].Name) < 0
+	})
+	return stores, nil
+}
+
+func (fakeRepository *FakeStoreRepository) GetById(ctx context.Context, storeId int64) (domain.Store, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Store{}, err
+	}
+	for _, store := range fakeRepository.stores {
+		if store.Id == storeId {
+			return store, nil
+		}
+	}
+	return domain.Store{}, domain.NewNotFoundError("Mağaza bulunamadı")
+}
+
+func (fakeRepository *FakeStoreRepository) AddStore(ctx context.Context, store domain.Store) (domain.Store, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Store{}, err
+	}
+	if fakeRepository.nameTaken(store.Name, 0) {
+		return domain.Store{}, domain.NewConflictError("Mağaza zaten mevcut", nil)
+	}
+	store.Id = int64(len(fakeRepository.stores)) + 1
+	fakeRepository.stores = append(fakeRepository.stores, store)
+	return store, nil
+}
+
+func (fakeRepository *FakeStoreRepository) UpdateStore(ctx context.Context, store domain.Store) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if fakeRepository.nameTaken(store.Name, store.Id) {
+		return domain.NewConflictError("Mağaza zaten mevcut", nil)
+	}
+	for i, existing := range fakeRepository.stores {
+		if existing.Id == store.Id {
+			fakeRepository.stores[i] = store
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Mağaza bulunamadı")
+}
+
+func (fakeRepository *FakeStoreRepository) DeleteById(ctx context.Context, storeId int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, product := range fakeRepository.products {
+		if product.StoreId == storeId {
+			return domain.NewConflictError("Mağazaya bağlı ürünler var", nil)
+		}
+	}
+	for index, store := range fakeRepository.stores {
+		if store.Id == storeId {
+			fakeRepository.stores = append(fakeRepository.stores[:index], fakeRepository.stores[index+1:]...)
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("Mağaza bulunamadı")
+}
+
+// nameTaken, verilen adın başka bir mağazada harf büyüklüğü gözetilmeden kullanılıp kullanılmadığını döner.
+func (fakeRepository *FakeStoreRepository) nameTaken(name string, exceptId int64) bool {
+	for _, store := range fakeRepository.stores {
+		if store.Id != exceptId && text.Normalize(store.Name) == text.Normalize(name) {
+			return true
+		}
+	}
+	return false
+}
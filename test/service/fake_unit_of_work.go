@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"product-app/domain"
+	"product-app/persistence"
+)
+
+// FakeUnitOfWork, verilen bellek içi repository'lerle çalışan test unit of work'üdür.
+// fn hata dönerse ürün repository'sindeki değişiklikler geri alınır.
+type FakeUnitOfWork struct {
+	repositories persistence.Repositories
+	Transactions int                   // Açılan transaction sayısı.
+	LastOptions  persistence.TxOptions // Son açılan transaction'ın ayarları.
+}
+
+func NewFakeUnitOfWork(repositories persistence.Repositories) *FakeUnitOfWork {
+	return &FakeUnitOfWork{
+		repositories: repositories,
+	}
+}
+
+func (fakeUnitOfWork *FakeUnitOfWork) WithTx(ctx context.Context, fn func(repositories persistence.Repositories) error) error {
+	return fakeUnitOfWork.WithTxOptions(ctx, persistence.TxOptions{}, fn)
+}
+
+func (fakeUnitOfWork *FakeUnitOfWork) WithTxOptions(ctx context.Context, options persistence.TxOptions, fn func(repositories persistence.Repositories) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	fakeUnitOfWork.Transactions++
+	fakeUnitOfWork.LastOptions = options
+	// Geri alabilmek için ürünlerin ve fiyat geçmişinin kopyası tutulur
+	productRepository, _ := fakeUnitOfWork.repositories.Products.(*FakeProductRepository)
+	var products, deletedProducts []domain.Product
+	var priceHistory []domain.PriceChange
+	if productRepository != nil {
+		products = append([]domain.Product{}, productRepository.products...)
+		deletedProducts = append([]domain.Product{}, productRepository.deletedProducts...)
+		priceHistory = append([]domain.PriceChange{}, productRepository.priceHistory...)
+	}
+	err := fn(fakeUnitOfWork.repositories)
+	if err != nil && productRepository != nil {
+		productRepository.products = products
+		productRepository.deletedProducts = deletedProducts
+		productRepository.priceHistory = priceHistory
+	}
+	return err
+}
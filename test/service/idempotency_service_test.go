@@ -0,0 +1,74 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"product-app/domain"
+	"product-app/service"
+	"testing"
+	"time"
+)
+
+func Test_WhenKeyIsRepeated_ShouldReturnSavedResponse(t *testing.T) {
+	idempotencyService := service.NewIdempotencyService(NewFakeIdempotencyRepository(), time.Hour, time.Minute)
+	t.Run("WhenKeyIsRepeated_ShouldReturnSavedResponse", func(t *testing.T) {
+		savedResponse, err := idempotencyService.Begin(ctx, "key-1", "hash-1")
+		assert.Nil(t, err)
+		assert.Nil(t, savedResponse)
+
+		// İlk istek tamamlanmadan gelen tekrar çakışma hatası alır
+		_, err = idempotencyService.Begin(ctx, "key-1", "hash-1")
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		response := domain.IdempotentResponse{StatusCode: http.StatusCreated, Header: map[string][]string{"Location": {"/api/v1/products/3"}}}
+		assert.Nil(t, idempotencyService.Complete(ctx, "key-1", response))
+		savedResponse, err = idempotencyService.Begin(ctx, "key-1", "hash-1")
+		assert.Nil(t, err)
+		assert.Equal(t, &response, savedResponse)
+
+		_, err = idempotencyService.Begin(ctx, "key-1", "hash-2")
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.EqualError(t, err, "Idempotency-Key was already used with a different request")
+	})
+}
+
+func Test_WhenRequestIsAbandonedOrKeyExpired_ShouldAllowRetry(t *testing.T) {
+	idempotencyService := service.NewIdempotencyService(NewFakeIdempotencyRepository(), time.Millisecond, time.Minute)
+	t.Run("WhenRequestIsAbandonedOrKeyExpired_ShouldAllowRetry", func(t *testing.T) {
+		idempotencyService.Begin(ctx, "key-1", "hash-1")
+		assert.Nil(t, idempotencyService.Abandon(ctx, "key-1"))
+		savedResponse, err := idempotencyService.Begin(ctx, "key-1", "hash-2")
+		assert.Nil(t, err)
+		assert.Nil(t, savedResponse)
+
+		assert.Nil(t, idempotencyService.Complete(ctx, "key-1", domain.IdempotentResponse{StatusCode: http.StatusCreated}))
+		time.Sleep(2 * time.Millisecond)
+		savedResponse, err = idempotencyService.Begin(ctx, "key-1", "hash-3")
+		assert.Nil(t, err)
+		assert.Nil(t, savedResponse)
+
+		time.Sleep(2 * time.Millisecond)
+		purged, err := idempotencyService.PurgeExpired(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), purged)
+	})
+}
+
+func Test_WhenLeaseExpiresWithoutResponse_ShouldAllowRetry(t *testing.T) {
+	idempotencyService := service.NewIdempotencyService(NewFakeIdempotencyRepository(), time.Hour, time.Millisecond)
+	t.Run("WhenLeaseExpiresWithoutResponse_ShouldAllowRetry", func(t *testing.T) {
+		idempotencyService.Begin(ctx, "key-1", "hash-1")
+		time.Sleep(2 * time.Millisecond)
+		// İlk isteği işleyen süreç yanıtı kaydetmeden sonlanmış gibi anahtar yeniden ayrılır
+		savedResponse, err := idempotencyService.Begin(ctx, "key-1", "hash-1")
+		assert.Nil(t, err)
+		assert.Nil(t, savedResponse)
+
+		response := domain.IdempotentResponse{StatusCode: http.StatusCreated}
+		assert.Nil(t, idempotencyService.Complete(ctx, "key-1", response))
+		time.Sleep(2 * time.Millisecond)
+		savedResponse, err = idempotencyService.Begin(ctx, "key-1", "hash-1")
+		assert.Nil(t, err)
+		assert.Equal(t, &response, savedResponse)
+	})
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"testing"
+)
+
+func Test_WhenBatchIsBestEffort_ShouldApplyValidOperationsAndReportFailures(t *testing.T) {
+	setup()
+	batch := model.ProductBatch{
+		Mode: domain.BatchModeBestEffort,
+		Operations: []model.ProductBatchOperation{
+			{Type: domain.BatchOperationCreate, Product: model.ProductCreate{Name: "Kettle", Price: money.MustParse("750"), StoreId: 2}},
+			{Type: domain.BatchOperationUpdatePrice, ProductId: 1, NewPrice: money.MustParse("1100")},
+			{Type: domain.BatchOperationDelete, ProductId: 99},
+			{Type: domain.BatchOperationUpdatePrice, ProductId: 2, NewPrice: money.MustParse("-5")},
+			{Type: domain.BatchOperationCreate, Product: model.ProductCreate{Name: "Blender", Price: money.MustParse("900"), StoreId: 9}},
+			{Type: "rename", ProductId: 2},
+			{Type: domain.BatchOperationDelete, ProductId: 2},
+		},
+	}
+	t.Run("WhenBatchIsBestEffort_ShouldApplyValidOperationsAndReportFailures", func(t *testing.T) {
+		batchResult, err := productService.ExecuteBatch(ctx, batch)
+		assert.Nil(t, err)
+		assert.Equal(t, domain.BatchModeBestEffort, batchResult.Mode)
+
+		var statuses []string
+		for _, result := range batchResult.Results {
+			statuses = append(statuses, result.Status)
+		}
+		assert.Equal(t, []string{
+			domain.BatchStatusSucceeded, domain.BatchStatusSucceeded, domain.BatchStatusFailed, domain.BatchStatusFailed,
+			domain.BatchStatusFailed, domain.BatchStatusFailed, domain.BatchStatusSucceeded,
+		}, statuses)
+		assert.Equal(t, int64(3), batchResult.Results[0].ProductId)
+		assert.ErrorIs(t, batchResult.Results[2].Err, domain.ErrNotFound)
+		assert.EqualError(t, batchResult.Results[3].Err, "Price must be greater than 0")
+		assert.EqualError(t, batchResult.Results[4].Err, "Store with id 9 does not exist")
+		assert.EqualError(t, batchResult.Results[5].Err, "Op must be one of create, updatePrice, delete")
+
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+		updated, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("1100"), updated.Price.Amount)
+		created, _ := productService.GetById(ctx, 3)
+		assert.Equal(t, "Dekorasyon Sarayı", created.Store)
+	})
+}
+
+func Test_WhenAtomicBatchHasFailedOperation_ShouldRollBackAllOperations(t *testing.T) {
+	setup()
+	operations := []model.ProductBatchOperation{
+		{Type: domain.BatchOperationUpdatePrice, ProductId: 1, NewPrice: money.MustParse("1100")},
+		{Type: domain.BatchOperationDelete, ProductId: 2},
+		{Type: domain.BatchOperationDelete, ProductId: 99},
+		{Type: domain.BatchOperationCreate, Product: model.ProductCreate{Name: "Kettle", Price: money.MustParse("750"), StoreId: 2}},
+	}
+	t.Run("WhenAtomicBatchHasFailedOperation_ShouldRollBackAllOperations", func(t *testing.T) {
+		batchResult, err := productService.ExecuteBatch(ctx, model.ProductBatch{Operations: operations})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.BatchModeAtomic, batchResult.Mode)
+		assert.Equal(t, 1, batchResult.Count(domain.BatchStatusFailed))
+		assert.Equal(t, 3, batchResult.Count(domain.BatchStatusRolledBack))
+		assert.ErrorIs(t, batchResult.Results[2].Err, domain.ErrNotFound)
+
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+		unchanged, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, money.MustParse("1000"), unchanged.Price.Amount)
+
+		// Doğrulanamayan bir işlem varsa hiçbir işlem repository'ye gönderilmez
+		operations[2] = model.ProductBatchOperation{Type: domain.BatchOperationUpdatePrice, ProductId: 1, NewPrice: money.MustParse("1.999")}
+		batchResult, err = productService.ExecuteBatch(ctx, model.ProductBatch{Mode: domain.BatchModeAtomic, Operations: operations})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.BatchStatusFailed, batchResult.Results[2].Status)
+		assert.ErrorIs(t, batchResult.Results[2].Err, domain.ErrValidation)
+		assert.Equal(t, 3, batchResult.Count(domain.BatchStatusRolledBack))
+		actualProducts, _ = productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+	})
+}
+
+func Test_WhenBatchIsInvalid_ShouldReturnValidationError(t *testing.T) {
+	setup()
+	t.Run("WhenBatchIsInvalid_ShouldReturnValidationError", func(t *testing.T) {
+		_, err := productService.ExecuteBatch(ctx, model.ProductBatch{})
+		assert.EqualError(t, err, "Batch contains no operations")
+
+		_, err = productService.ExecuteBatch(ctx, model.ProductBatch{
+			Mode:       "partial",
+			Operations: []model.ProductBatchOperation{{Type: domain.BatchOperationDelete, ProductId: 1}},
+		})
+		assert.EqualError(t, err, "Mode must be one of atomic, bestEffort")
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+	})
+}
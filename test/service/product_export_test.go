@@ -0,0 +1,46 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"testing"
+)
+
+func Test_ShouldExportAllMatchingProductsWithResolvedDiscounts(t *testing.T) {
+	setup()
+	productService.Add(ctx, model.ProductCreate{Name: "Lambader", Price: money.MustParse("2000"), StoreId: 2})
+	campaign, _ := campaignService.Add(ctx, runningCampaign("Ütü haftası", "30", 0, []int64{2}, nil))
+	t.Run("ShouldExportAllMatchingProductsWithResolvedDiscounts", func(t *testing.T) {
+		var exported []domain.Product
+		err := productService.Export(ctx, domain.ProductQuery{StoreId: 1, Limit: 1, Offset: 5, Sort: []domain.SortField{{Field: domain.SortFieldPrice, Descending: true}}},
+			func(product domain.Product) error {
+				exported = append(exported, product)
+				return nil
+			})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Ütü", "AirFryer"}, productNames(exported))
+		assert.Equal(t, money.MustParse("30"), exported[0].Discount)
+		assert.Equal(t, &campaign.Id, exported[0].CampaignId)
+	})
+}
+
+func Test_WhenExportQueryIsInvalid_ShouldNotExportAnything(t *testing.T) {
+	setup()
+	t.Run("WhenExportQueryIsInvalid_ShouldNotExportAnything", func(t *testing.T) {
+		called := false
+		err := productService.Export(ctx, domain.ProductQuery{Sort: []domain.SortField{{Field: "color"}}}, func(product domain.Product) error {
+			called = true
+			return nil
+		})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.False(t, called)
+
+		err = productService.Export(ctx, domain.ProductQuery{Currency: money.EUR}, func(product domain.Product) error {
+			assert.Equal(t, money.EUR, product.Price.Currency)
+			return nil
+		})
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/common/money"
+	"product-app/domain"
+	"product-app/service/model"
+	"testing"
+)
+
+func Test_WhenAllImportRowsAreValid_ShouldImportProducts(t *testing.T) {
+	setup()
+	rows := []model.ProductImportRow{
+		{Line: 2, Product: model.ProductCreate{Name: "Kettle", Price: money.MustParse("750"), StoreId: 2}},
+		{Line: 3, Product: model.ProductCreate{Name: "Tost Makinesi", Price: money.MustParse("1200"), Discount: money.MustParse("10"), StoreId: 3}},
+	}
+	t.Run("WhenAllImportRowsAreValid_ShouldImportProducts", func(t *testing.T) {
+		report, err := productService.Import(ctx, rows, true)
+		assert.Nil(t, err)
+		assert.Equal(t, domain.ProductImportReport{DryRun: true, TotalRows: 2, RowErrors: []domain.ImportRowError{}}, report)
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+
+		report, err = productService.Import(ctx, rows, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, report.ImportedRows)
+		imported, _ := productService.GetById(ctx, 4)
+		assert.Equal(t, "Tost Makinesi", imported.Name)
+		assert.Equal(t, "Mutfak Dünyası", imported.Store)
+		assert.Equal(t, money.TRY, imported.Price.Currency)
+	})
+}
+
+func Test_WhenAnyImportRowIsInvalid_ShouldReportRowsAndImportNothing(t *testing.T) {
+	setup()
+	rows := []model.ProductImportRow{
+		{Line: 2, Product: model.ProductCreate{Name: "Kettle", Price: money.MustParse("750"), StoreId: 2}},
+		{Line: 3, Product: model.ProductCreate{Name: "", Price: money.MustParse("100"), Discount: money.MustParse("75"), StoreId: 9}},
+		{
+			Line:        4,
+			Product:     model.ProductCreate{Name: "Blender", StoreId: 1},
+			ParseErrors: []domain.FieldError{{Field: "price", Message: "Price must be a decimal number"}},
+		},
+	}
+	t.Run("WhenAnyImportRowIsInvalid_ShouldReportRowsAndImportNothing", func(t *testing.T) {
+		report, err := productService.Import(ctx, rows, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, report.ImportedRows)
+		assert.Equal(t, []domain.ImportRowError{
+			{Line: 3, FieldErrors: []domain.FieldError{
+				{Field: "name", Message: "Name is required"},
+				{Field: "discount", Message: "Discount can not be greater than 70"},
+				{Field: "storeId", Message: "Store with id 9 does not exist"},
+			}},
+			{Line: 4, FieldErrors: []domain.FieldError{
+				{Field: "price", Message: "Price must be a decimal number"},
+			}},
+		}, report.RowErrors)
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+
+		_, err = productService.Import(ctx, []model.ProductImportRow{}, false)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
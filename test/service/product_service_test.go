@@ -1,74 +1,649 @@
 package service
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"os"
+	"product-app/common/money"
 	"product-app/domain"
+	"product-app/persistence"
 	"product-app/service"
 	"product-app/service/model"
 	"testing"
+	"time"
 )
 
 var productService service.IProductService
+var storeService service.IStoreService
+var categoryService service.ICategoryService
+var stockService service.IStockService
+var campaignService service.ICampaignService
+var unitOfWork *FakeUnitOfWork
+var ctx = context.Background()
 
 func TestMain(m *testing.M) {
+	exitCode := m.Run()
+	os.Exit(exitCode)
+}
+
+// setup, her testin önceki testlerden etkilenmemesi için servisi başlangıç verileriyle yeniden oluşturur.
+func setup() {
 	initialProducts := []domain.Product{
 		{
-			Id:    1,
-			Name:  "AirFryer",
-			Price: 1000.0,
-			Store: "ABC TECH",
+			Id:      1,
+			Name:    "AirFryer",
+			Price:   price("1000"),
+			StoreId: 1,
+			Store:   "ABC TECH",
+			Version: 1,
 		},
 		{
-			Id:    2,
-			Name:  "Ütü",
-			Price: 4000.0,
-			Store: "ABC TECH",
+			Id:      2,
+			Name:    "Ütü",
+			Price:   price("4000"),
+			StoreId: 1,
+			Store:   "ABC TECH",
+			Version: 1,
 		},
 	}
+	initialStores := []domain.Store{
+		{Id: 1, Name: "ABC TECH"},
+		{Id: 2, Name: "Dekorasyon Sarayı"},
+		{Id: 3, Name: "Mutfak Dünyası"},
+	}
+	initialCategories := []domain.Category{
+		{Id: 1, Name: "Küçük Ev Aletleri"},
+		{Id: 2, Name: "Ütüler", ParentId: categoryId(1)},
+		{Id: 3, Name: "Fritözler", ParentId: categoryId(1)},
+		{Id: 4, Name: "Buharlı Ütüler", ParentId: categoryId(2)},
+	}
 	fakeProductRepository := NewFakeProductRepository(initialProducts)
-	productService = service.NewProductService(fakeProductRepository)
-	exitCode := m.Run()
-	os.Exit(exitCode)
+	fakeStoreRepository := NewFakeStoreRepository(initialStores, initialProducts)
+	fakeCategoryRepository := NewFakeCategoryRepository(initialCategories)
+	fakeStockRepository := NewFakeStockRepository([]domain.Stock{
+		{ProductId: 1, StoreId: 1, Quantity: 10, Reserved: 2, LowStockThreshold: 3},
+		{ProductId: 2, StoreId: 1, Quantity: 4, Reserved: 1, LowStockThreshold: 5},
+	})
+	fakeCampaignRepository := NewFakeCampaignRepository([]domain.Campaign{})
+	rateProvider, err := persistence.NewFileRateProvider("testdata/exchange_rates.yaml")
+	if err != nil {
+		panic(err)
+	}
+	unitOfWork = NewFakeUnitOfWork(persistence.Repositories{
+		Products:   fakeProductRepository,
+		Stores:     fakeStoreRepository,
+		Categories: fakeCategoryRepository,
+		Stocks:     fakeStockRepository,
+		Campaigns:  fakeCampaignRepository,
+	})
+	productService = service.NewProductService(fakeProductRepository, fakeStoreRepository, rateProvider, fakeCampaignRepository, unitOfWork)
+	storeService = service.NewStoreService(fakeStoreRepository)
+	categoryService = service.NewCategoryService(fakeCategoryRepository, fakeProductRepository, unitOfWork)
+	stockService = service.NewStockService(fakeStockRepository, fakeProductRepository, fakeStoreRepository)
+	campaignService = service.NewCampaignService(fakeCampaignRepository, fakeProductRepository, fakeStoreRepository)
 }
 
 func Test_ShouldGetAllProducts(t *testing.T) {
+	setup()
 	t.Run("ShouldGetAllProducts", func(t *testing.T) {
-		actualProducts := productService.GetAllProducts()
+		actualProducts, _ := productService.GetAllProducts(ctx)
 		assert.Equal(t, 2, len(actualProducts))
 	})
 }
 
 func Test_WhenNoValidationErrorOccurred_ShouldAddProduct(t *testing.T) {
+	setup()
 	t.Run("WhenNoValidationErrorOccurred_ShouldAddProduct", func(t *testing.T) {
-		productService.Add(model.ProductCreate{
+		addedProduct, err := productService.Add(ctx, model.ProductCreate{
 			Name:     "Ütü",
-			Price:    2000.0,
-			Discount: 50,
-			Store:    "ABC TECH",
+			Price:    money.MustParse("2000"),
+			Discount: money.MustParse("50"),
+			StoreId:  1,
 		})
-		actualProducts := productService.GetAllProducts()
-		assert.Equal(t, 3, len(actualProducts))
-		assert.Equal(t, domain.Product{
+		assert.Nil(t, err)
+		expectedProduct := domain.Product{
 			Id:       3,
 			Name:     "Ütü",
-			Price:    2000.0,
-			Discount: 50,
+			Price:    price("2000"),
+			Discount: money.MustParse("50"),
+			StoreId:  1,
 			Store:    "ABC TECH",
-		}, actualProducts[len(actualProducts)-1])
+			Version:  1,
+			Pricing:  priceBreakdown("2000", "1000", "1000"),
+		}
+		assert.Equal(t, expectedProduct, addedProduct)
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 3, len(actualProducts))
+		assert.Equal(t, expectedProduct, actualProducts[len(actualProducts)-1])
 	})
 }
 
 func Test_WhenDiscountIsHigherThan70_ShouldNotAddProduct(t *testing.T) {
+	setup()
 	t.Run("WhenDiscountIsHigherThan70_ShouldNotAddProduct", func(t *testing.T) {
-		err := productService.Add(model.ProductCreate{
+		_, err := productService.Add(ctx, model.ProductCreate{
 			Name:     "Ütü",
-			Price:    2000.0,
-			Discount: 75,
-			Store:    "ABC TECH",
+			Price:    money.MustParse("2000"),
+			Discount: money.MustParse("75"),
+			StoreId:  1,
 		})
-		actualProducts := productService.GetAllProducts()
+		actualProducts, _ := productService.GetAllProducts(ctx)
 		assert.Equal(t, 2, len(actualProducts))
 		assert.Equal(t, "Discount can not be greater than 70", err.Error())
 	})
 }
+
+func Test_WhenNoValidationErrorOccurred_ShouldUpdateProduct(t *testing.T) {
+	setup()
+	t.Run("WhenNoValidationErrorOccurred_ShouldUpdateProduct", func(t *testing.T) {
+		err := productService.Update(ctx, 1, model.ProductUpdate{
+			Name:     "AirFryer XL",
+			Price:    money.MustParse("1500"),
+			Discount: money.MustParse("20"),
+			StoreId:  3,
+		}, domain.AnyVersion)
+		actualProduct, _ := productService.GetById(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, domain.Product{
+			Id:       1,
+			Name:     "AirFryer XL",
+			Price:    price("1500"),
+			Discount: money.MustParse("20"),
+			StoreId:  3,
+			Store:    "Mutfak Dünyası",
+			Version:  2,
+			Pricing:  priceBreakdown("1500", "300", "1200"),
+		}, actualProduct)
+	})
+}
+
+func Test_WhenStoreDoesNotExist_ShouldNotAddProduct(t *testing.T) {
+	setup()
+	t.Run("WhenStoreDoesNotExist_ShouldNotAddProduct", func(t *testing.T) {
+		_, err := productService.Add(ctx, model.ProductCreate{
+			Name:    "Kettle",
+			Price:   money.MustParse("1000"),
+			StoreId: 9,
+		})
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "storeId", Message: "Store with id 9 does not exist"},
+		}, validationErr.FieldErrors)
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+	})
+}
+
+func Test_WhenDiscountIsHigherThan70_ShouldNotUpdateProduct(t *testing.T) {
+	setup()
+	t.Run("WhenDiscountIsHigherThan70_ShouldNotUpdateProduct", func(t *testing.T) {
+		err := productService.Update(ctx, 1, model.ProductUpdate{
+			Name:     "AirFryer",
+			Price:    money.MustParse("1000"),
+			Discount: money.MustParse("75"),
+			StoreId:  1,
+		}, domain.AnyVersion)
+		actualProduct, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, "Discount can not be greater than 70", err.Error())
+		assert.True(t, actualProduct.Discount.IsZero())
+	})
+}
+
+func Test_ShouldPatchOnlyGivenFields(t *testing.T) {
+	setup()
+	t.Run("ShouldPatchOnlyGivenFields", func(t *testing.T) {
+		newPrice := money.MustParse("900")
+		err := productService.Patch(ctx, 1, model.ProductPatch{
+			Price: &newPrice,
+		}, domain.AnyVersion)
+		actualProduct, _ := productService.GetById(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, domain.Product{
+			Id:      1,
+			Name:    "AirFryer",
+			Price:   price("900"),
+			StoreId: 1,
+			Store:   "ABC TECH",
+			Version: 2,
+			Pricing: priceBreakdown("900", "0", "900"),
+		}, actualProduct)
+	})
+}
+
+func Test_WhenPatchedDiscountIsHigherThan70_ShouldNotPatchProduct(t *testing.T) {
+	setup()
+	t.Run("WhenPatchedDiscountIsHigherThan70_ShouldNotPatchProduct", func(t *testing.T) {
+		newDiscount := money.MustParse("80")
+		err := productService.Patch(ctx, 2, model.ProductPatch{
+			Discount: &newDiscount,
+		}, domain.AnyVersion)
+		actualProduct, _ := productService.GetById(ctx, 2)
+		assert.Equal(t, "Discount can not be greater than 70", err.Error())
+		assert.True(t, actualProduct.Discount.IsZero())
+	})
+}
+
+func Test_WhenProductDoesNotExist_ShouldNotPatchProduct(t *testing.T) {
+	setup()
+	t.Run("WhenProductDoesNotExist_ShouldNotPatchProduct", func(t *testing.T) {
+		newName := "Kettle"
+		err := productService.Patch(ctx, 5, model.ProductPatch{
+			Name: &newName,
+		}, domain.AnyVersion)
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_WhenStoreOfUpdateDoesNotExist_ShouldRollBackUnitOfWork(t *testing.T) {
+	setup()
+	t.Run("WhenStoreOfUpdateDoesNotExist_ShouldRollBackUnitOfWork", func(t *testing.T) {
+		err := productService.Update(ctx, 1, model.ProductUpdate{
+			Name:    "AirFryer XL",
+			Price:   money.MustParse("1500"),
+			StoreId: 9,
+		}, domain.AnyVersion)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Equal(t, 1, unitOfWork.Transactions)
+
+		newName := "AirFryer XL"
+		assert.Nil(t, productService.Patch(ctx, 1, model.ProductPatch{Name: &newName}, domain.AnyVersion))
+		assert.Equal(t, 2, unitOfWork.Transactions)
+		actualProduct, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, "AirFryer XL", actualProduct.Name)
+		assert.Equal(t, int64(1), actualProduct.StoreId)
+	})
+}
+
+func Test_ShouldGetProductsSortedAndPaginatedWithCursor(t *testing.T) {
+	setup()
+	productService.Add(ctx, model.ProductCreate{Name: "Kettle", Price: money.MustParse("1000"), StoreId: 1})
+	t.Run("ShouldGetProductsSortedAndPaginatedWithCursor", func(t *testing.T) {
+		sort := []domain.SortField{{Field: "price", Descending: true}, {Field: "name"}}
+		firstPage, err := productService.GetProducts(ctx, domain.ProductQuery{Limit: 2, Sort: sort})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), firstPage.TotalCount)
+		assert.Equal(t, []string{"Ütü", "AirFryer"}, productNames(firstPage.Products))
+		assert.NotEmpty(t, firstPage.NextCursor)
+
+		secondPage, err := productService.GetProducts(ctx, domain.ProductQuery{Limit: 2, Sort: sort, Cursor: firstPage.NextCursor})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Kettle"}, productNames(secondPage.Products))
+		assert.Empty(t, secondPage.NextCursor)
+	})
+}
+
+func Test_ShouldGetProductsOfStore(t *testing.T) {
+	setup()
+	productService.Add(ctx, model.ProductCreate{Name: "Tencere", Price: money.MustParse("750"), StoreId: 3})
+	t.Run("ShouldGetProductsOfStore", func(t *testing.T) {
+		page, err := productService.GetAllProductsByStore(ctx, 3, domain.ProductQuery{})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), page.TotalCount)
+		assert.Equal(t, []string{"Tencere"}, productNames(page.Products))
+		assert.Equal(t, "Mutfak Dünyası", page.Products[0].Store)
+
+		_, err = productService.GetAllProductsByStore(ctx, 9, domain.ProductQuery{})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func Test_ShouldFilterProductsByPriceRange(t *testing.T) {
+	setup()
+	t.Run("ShouldFilterProductsByPriceRange", func(t *testing.T) {
+		minPrice := money.MustParse("2000")
+		page, err := productService.GetProducts(ctx, domain.ProductQuery{MinPrice: &minPrice})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), page.TotalCount)
+		assert.Equal(t, []string{"Ütü"}, productNames(page.Products))
+	})
+}
+
+func Test_WhenSortFieldIsUnsupported_ShouldNotGetProducts(t *testing.T) {
+	setup()
+	t.Run("WhenSortFieldIsUnsupported_ShouldNotGetProducts", func(t *testing.T) {
+		_, err := productService.GetProducts(ctx, domain.ProductQuery{Sort: []domain.SortField{{Field: "color"}}})
+		assert.Equal(t, "Unsupported sort field: color", err.Error())
+	})
+}
+
+func Test_WhenCursorDoesNotMatchSort_ShouldNotGetProducts(t *testing.T) {
+	setup()
+	t.Run("WhenCursorDoesNotMatchSort_ShouldNotGetProducts", func(t *testing.T) {
+		firstPage, _ := productService.GetProducts(ctx, domain.ProductQuery{Limit: 1, Sort: []domain.SortField{{Field: "price"}}})
+		_, err := productService.GetProducts(ctx, domain.ProductQuery{Limit: 1, Sort: []domain.SortField{{Field: "name"}}, Cursor: firstPage.NextCursor})
+		assert.Equal(t, "Cursor does not match the requested sort", err.Error())
+	})
+}
+
+// price, testlerde kullanılan varsayılan para birimindeki fiyatı oluşturur.
+func price(amount string) money.Money {
+	return money.New(money.MustParse(amount), money.DefaultCurrency)
+}
+
+func priceBreakdown(listPrice string, discountAmount string, finalPrice string) domain.PriceBreakdown {
+	return domain.PriceBreakdown{Price: price(listPrice), DiscountAmount: price(discountAmount), FinalPrice: price(finalPrice)}
+}
+
+func productNames(products []domain.Product) []string {
+	var names []string
+	for _, product := range products {
+		names = append(names, product.Name)
+	}
+	return names
+}
+
+func Test_ShouldSearchProductsWithTurkishNormalization(t *testing.T) {
+	setup()
+	productService.Add(ctx, model.ProductCreate{Name: "Çamaşır Makinesi", Price: money.MustParse("10000"), StoreId: 1})
+	productService.Add(ctx, model.ProductCreate{Name: "IŞIKLI AYNA", Price: money.MustParse("500"), StoreId: 2})
+	t.Run("ShouldSearchProductsWithTurkishNormalization", func(t *testing.T) {
+		washingMachines, err := productService.Search(ctx, "ÇAMAŞIR makine", 0)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Çamaşır Makinesi"}, productNames(washingMachines))
+
+		irons, _ := productService.Search(ctx, "ütü", 0)
+		assert.Equal(t, []string{"Ütü"}, productNames(irons))
+
+		mirrors, _ := productService.Search(ctx, "ışıklı", 0)
+		assert.Equal(t, []string{"IŞIKLI AYNA"}, productNames(mirrors))
+	})
+}
+
+func Test_WhenSearchTextIsEmpty_ShouldNotSearchProducts(t *testing.T) {
+	setup()
+	t.Run("WhenSearchTextIsEmpty_ShouldNotSearchProducts", func(t *testing.T) {
+		_, err := productService.Search(ctx, "  ", 0)
+		assert.Equal(t, "Search text is required", err.Error())
+	})
+}
+
+func Test_WhenContextIsCancelled_ShouldNotAddProduct(t *testing.T) {
+	setup()
+	t.Run("WhenContextIsCancelled_ShouldNotAddProduct", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := productService.Add(cancelledCtx, model.ProductCreate{
+			Name:    "Kettle",
+			Price:   money.MustParse("1000"),
+			StoreId: 1,
+		})
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 2, len(actualProducts))
+	})
+}
+
+func Test_WhenContextIsCancelled_ShouldNotGetProducts(t *testing.T) {
+	setup()
+	t.Run("WhenContextIsCancelled_ShouldNotGetProducts", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := productService.GetProducts(cancelledCtx, domain.ProductQuery{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func Test_WhenProductDoesNotExist_ShouldReturnNotFoundError(t *testing.T) {
+	setup()
+	t.Run("WhenProductDoesNotExist_ShouldReturnNotFoundError", func(t *testing.T) {
+		_, err := productService.GetById(ctx, 5)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func Test_WhenDiscountIsHigherThan70_ShouldReturnValidationError(t *testing.T) {
+	setup()
+	t.Run("WhenDiscountIsHigherThan70_ShouldReturnValidationError", func(t *testing.T) {
+		_, err := productService.Add(ctx, model.ProductCreate{
+			Name:     "Ütü",
+			Price:    money.MustParse("2000"),
+			Discount: money.MustParse("75"),
+			StoreId:  1,
+		})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
+
+func Test_WhenSeveralFieldsAreInvalid_ShouldReturnAllFieldErrors(t *testing.T) {
+	setup()
+	t.Run("WhenSeveralFieldsAreInvalid_ShouldReturnAllFieldErrors", func(t *testing.T) {
+		_, err := productService.Add(ctx, model.ProductCreate{
+			Name:     " ",
+			Price:    money.MustParse("-10"),
+			Discount: money.MustParse("-5"),
+		})
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "name", Message: "Name is required"},
+			{Field: "price", Message: "Price must be greater than 0"},
+			{Field: "discount", Message: "Discount can not be negative"},
+			{Field: "storeId", Message: "StoreId is required"},
+		}, validationErr.FieldErrors)
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 2, len(actualProducts))
+	})
+}
+
+func Test_WhenPriceHasMoreThanTwoDecimalPlaces_ShouldNotAddProduct(t *testing.T) {
+	setup()
+	t.Run("WhenPriceHasMoreThanTwoDecimalPlaces_ShouldNotAddProduct", func(t *testing.T) {
+		_, err := productService.Add(ctx, model.ProductCreate{
+			Name:    "Kettle",
+			Price:   money.MustParse("19.999"),
+			StoreId: 1,
+		})
+		assert.Equal(t, "Price can have at most 2 decimal places", err.Error())
+	})
+}
+
+func Test_WhenPriceDoesNotFitPriceColumn_ShouldNotSaveProduct(t *testing.T) {
+	setup()
+	t.Run("WhenPriceDoesNotFitPriceColumn_ShouldNotSaveProduct", func(t *testing.T) {
+		_, err := productService.Add(ctx, model.ProductCreate{
+			Name:    "Kettle",
+			Price:   money.MustParse("1000000000000"),
+			StoreId: 1,
+		})
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "price", Message: "Price can have at most 12 integer digits"},
+		}, validationErr.FieldErrors)
+
+		err = productService.UpdatePrice(ctx, 1, money.MustParse("1000000000000"), domain.AnyVersion)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Nil(t, productService.UpdatePrice(ctx, 1, money.MustParse("999999999999.99"), domain.AnyVersion))
+	})
+}
+
+func Test_WhenNewPriceIsNotPositive_ShouldNotUpdatePrice(t *testing.T) {
+	setup()
+	t.Run("WhenNewPriceIsNotPositive_ShouldNotUpdatePrice", func(t *testing.T) {
+		err := productService.UpdatePrice(ctx, 1, money.Decimal{}, domain.AnyVersion)
+		actualProduct, _ := productService.GetById(ctx, 1)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Equal(t, price("1000"), actualProduct.Price)
+	})
+}
+
+func Test_ShouldGetProductWithPriceConvertedToRequestedCurrency(t *testing.T) {
+	setup()
+	t.Run("ShouldGetProductWithPriceConvertedToRequestedCurrency", func(t *testing.T) {
+		actualProduct, err := productService.GetByIdInCurrency(ctx, 1, money.EUR)
+		assert.Nil(t, err)
+		assert.Equal(t, "28.37 EUR", actualProduct.Price.String())
+
+		storedProduct, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, price("1000"), storedProduct.Price)
+	})
+}
+
+func Test_ShouldListProductsInRequestedCurrency(t *testing.T) {
+	setup()
+	productService.Add(ctx, model.ProductCreate{Name: "Kettle", Price: money.MustParse("100"), Currency: money.EUR, StoreId: 1})
+	t.Run("ShouldListProductsInRequestedCurrency", func(t *testing.T) {
+		page, err := productService.GetProducts(ctx, domain.ProductQuery{Currency: money.TRY})
+		assert.Nil(t, err)
+		var prices []string
+		for _, product := range page.Products {
+			prices = append(prices, product.Price.String())
+		}
+		assert.Equal(t, []string{"1000.00 TRY", "4000.00 TRY", "3525.00 TRY"}, prices)
+	})
+}
+
+func Test_WhenExchangeRateIsMissing_ShouldReturnValidationError(t *testing.T) {
+	setup()
+	productService.Add(ctx, model.ProductCreate{Name: "Kettle", Price: money.MustParse("100"), Currency: money.EUR, StoreId: 1})
+	t.Run("WhenExchangeRateIsMissing_ShouldReturnValidationError", func(t *testing.T) {
+		_, err := productService.GetByIdInCurrency(ctx, 3, money.USD)
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		assert.Equal(t, "Exchange rate from EUR to USD is not available", err.Error())
+	})
+}
+
+func Test_WhenCurrencyIsUnsupported_ShouldNotAddProduct(t *testing.T) {
+	setup()
+	t.Run("WhenCurrencyIsUnsupported_ShouldNotAddProduct", func(t *testing.T) {
+		_, err := productService.Add(ctx, model.ProductCreate{
+			Name:     "Kettle",
+			Price:    money.MustParse("100"),
+			Currency: "GBP",
+			StoreId:  1,
+		})
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, "Currency must be one of TRY, EUR, USD", err.Error())
+		assert.Equal(t, 2, len(actualProducts))
+	})
+}
+
+func Test_ShouldCalculatePriceBreakdownWithConsistentRounding(t *testing.T) {
+	t.Run("ShouldCalculatePriceBreakdownWithConsistentRounding", func(t *testing.T) {
+		priceBreakdown := service.CalculatePrice(domain.Product{
+			Price:    money.New(money.MustParse("0.99"), money.EUR),
+			Discount: money.MustParse("50"),
+		})
+		assert.Equal(t, "0.99 EUR", priceBreakdown.Price.String())
+		assert.Equal(t, "0.50 EUR", priceBreakdown.DiscountAmount.String())
+		assert.Equal(t, "0.49 EUR", priceBreakdown.FinalPrice.String())
+
+		withoutDiscount := service.CalculatePrice(domain.Product{Price: price("1000")})
+		assert.True(t, withoutDiscount.DiscountAmount.Amount.IsZero())
+		assert.Equal(t, price("1000"), withoutDiscount.FinalPrice)
+	})
+}
+
+func Test_ShouldRecordPriceChangesWithActor(t *testing.T) {
+	setup()
+	t.Run("ShouldRecordPriceChangesWithActor", func(t *testing.T) {
+		actorCtx := domain.WithActor(ctx, "ayse.yilmaz")
+		productService.UpdatePrice(actorCtx, 1, money.MustParse("1200"), domain.AnyVersion)
+		newCurrency := money.EUR
+		productService.Patch(ctx, 1, model.ProductPatch{Currency: &newCurrency}, domain.AnyVersion)
+		productService.UpdatePrice(actorCtx, 1, money.MustParse("1200"), domain.AnyVersion)
+
+		priceHistory, err := productService.GetPriceHistory(ctx, 1, domain.PriceHistoryQuery{})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(priceHistory))
+		assert.Equal(t, "1200.00 TRY", priceHistory[0].OldPrice.String())
+		assert.Equal(t, "1200.00 EUR", priceHistory[0].NewPrice.String())
+		assert.Equal(t, domain.AnonymousActor, priceHistory[0].Actor)
+		assert.Equal(t, "1000.00 TRY", priceHistory[1].OldPrice.String())
+		assert.Equal(t, "ayse.yilmaz", priceHistory[1].Actor)
+
+		future := time.Now().Add(time.Hour)
+		laterHistory, _ := productService.GetPriceHistory(ctx, 1, domain.PriceHistoryQuery{From: &future})
+		assert.Empty(t, laterHistory)
+	})
+}
+
+func Test_WhenPriceHistoryRangeIsInvalid_ShouldReturnValidationError(t *testing.T) {
+	setup()
+	t.Run("WhenPriceHistoryRangeIsInvalid_ShouldReturnValidationError", func(t *testing.T) {
+		from := time.Now()
+		to := from.Add(-time.Hour)
+		_, err := productService.GetPriceHistory(ctx, 1, domain.PriceHistoryQuery{From: &from, To: &to})
+		assert.Equal(t, "From must be before to", err.Error())
+
+		_, err = productService.GetPriceHistory(ctx, 5, domain.PriceHistoryQuery{})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func Test_WhenVersionDoesNotMatch_ShouldNotChangeProduct(t *testing.T) {
+	setup()
+	t.Run("WhenVersionDoesNotMatch_ShouldNotChangeProduct", func(t *testing.T) {
+		assert.Nil(t, productService.UpdatePrice(ctx, 1, money.MustParse("1200"), 1))
+		updated, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, int64(2), updated.Version)
+
+		// İlk sürümü okumuş olan ikinci istemcinin değişiklikleri reddedilir
+		err := productService.UpdatePrice(ctx, 1, money.MustParse("1300"), 1)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+		err = productService.Update(ctx, 1, model.ProductUpdate{Name: "AirFryer XL", Price: money.MustParse("1500"), StoreId: 1}, 1)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+		newName := "AirFryer XL"
+		err = productService.Patch(ctx, 1, model.ProductPatch{Name: &newName}, 1)
+		assert.ErrorIs(t, err, domain.ErrPreconditionFailed)
+		assert.ErrorIs(t, productService.DeleteById(ctx, 1, 1), domain.ErrPreconditionFailed)
+
+		unchanged, _ := productService.GetById(ctx, 1)
+		assert.Equal(t, "AirFryer", unchanged.Name)
+		assert.Equal(t, price("1200"), unchanged.Price)
+
+		assert.Nil(t, productService.Patch(ctx, 1, model.ProductPatch{Name: &newName}, 2))
+		assert.Nil(t, productService.DeleteById(ctx, 1, 3))
+		_, err = productService.GetById(ctx, 1)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}
+
+func Test_WhenProductIsDeleted_ShouldMoveItToTrashUntilRestored(t *testing.T) {
+	setup()
+	t.Run("WhenProductIsDeleted_ShouldMoveItToTrashUntilRestored", func(t *testing.T) {
+		assert.Nil(t, productService.DeleteById(ctx, 2, 1))
+
+		_, err := productService.GetById(ctx, 2)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		actualProducts, _ := productService.GetAllProducts(ctx)
+		assert.Equal(t, 1, len(actualProducts))
+
+		trash, _ := productService.GetTrash(ctx)
+		assert.Equal(t, 1, len(trash))
+		assert.Equal(t, int64(2), trash[0].Id)
+		assert.NotNil(t, trash[0].DeletedAt)
+
+		assert.Nil(t, productService.Restore(ctx, 2))
+		assert.ErrorIs(t, productService.Restore(ctx, 2), domain.ErrNotFound)
+		restored, err := productService.GetById(ctx, 2)
+		assert.Nil(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Equal(t, int64(3), restored.Version)
+		trash, _ = productService.GetTrash(ctx)
+		assert.Equal(t, 0, len(trash))
+	})
+}
+
+func Test_ShouldPurgeOnlyProductsDeletedBeforeRetention(t *testing.T) {
+	setup()
+	t.Run("ShouldPurgeOnlyProductsDeletedBeforeRetention", func(t *testing.T) {
+		assert.Nil(t, productService.DeleteById(ctx, 1, domain.AnyVersion))
+
+		purged, err := productService.PurgeTrash(ctx, time.Hour)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), purged)
+
+		time.Sleep(time.Millisecond)
+		purged, err = productService.PurgeTrash(ctx, time.Nanosecond)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), purged)
+		trash, _ := productService.GetTrash(ctx)
+		assert.Equal(t, 0, len(trash))
+		assert.ErrorIs(t, productService.Restore(ctx, 1), domain.ErrNotFound)
+
+		_, err = productService.PurgeTrash(ctx, 0)
+		assert.EqualError(t, err, "Retention must be greater than 0")
+	})
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"product-app/service/model"
+	"sync"
+	"testing"
+)
+
+func Test_ShouldReserveAndReleaseStock(t *testing.T) {
+	setup()
+	t.Run("ShouldReserveAndReleaseStock", func(t *testing.T) {
+		stock, err := stockService.Reserve(ctx, 1, model.StockChange{StoreId: 1, Quantity: 5})
+		assert.Nil(t, err)
+		assert.Equal(t, 7, stock.Reserved)
+		assert.Equal(t, 3, stock.Available())
+
+		stock, err = stockService.Release(ctx, 1, model.StockChange{StoreId: 1, Quantity: 7})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, stock.Reserved)
+		assert.Equal(t, 10, stock.Available())
+	})
+}
+
+func Test_WhenStockIsInsufficient_ShouldNotReserveStock(t *testing.T) {
+	setup()
+	t.Run("WhenStockIsInsufficient_ShouldNotReserveStock", func(t *testing.T) {
+		_, err := stockService.Reserve(ctx, 1, model.StockChange{StoreId: 1, Quantity: 9})
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.Equal(t, "Insufficient stock: 8 available, 9 requested", err.Error())
+
+		stocks, _ := stockService.GetStocksOfProduct(ctx, 1)
+		assert.Equal(t, 2, stocks[0].Reserved)
+	})
+}
+
+func Test_WhenReservedConcurrently_ShouldNotOversell(t *testing.T) {
+	setup()
+	t.Run("WhenReservedConcurrently_ShouldNotOversell", func(t *testing.T) {
+		var waitGroup sync.WaitGroup
+		var mutex sync.Mutex
+		reservedCount := 0
+		for i := 0; i < 20; i++ {
+			waitGroup.Add(1)
+			go func() {
+				defer waitGroup.Done()
+				if _, err := stockService.Reserve(ctx, 1, model.StockChange{StoreId: 1, Quantity: 1}); err == nil {
+					mutex.Lock()
+					reservedCount++
+					mutex.Unlock()
+				}
+			}()
+		}
+		waitGroup.Wait()
+		assert.Equal(t, 8, reservedCount)
+	})
+}
+
+func Test_ShouldAdjustStockAndCreateMissingStoreStock(t *testing.T) {
+	setup()
+	t.Run("ShouldAdjustStockAndCreateMissingStoreStock", func(t *testing.T) {
+		threshold := 2
+		stock, err := stockService.Adjust(ctx, 1, model.StockAdjustment{StoreId: 3, Delta: 6, LowStockThreshold: &threshold})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.Stock{ProductId: 1, StoreId: 3, Quantity: 6, LowStockThreshold: 2}, stock)
+
+		_, err = stockService.Adjust(ctx, 1, model.StockAdjustment{StoreId: 1, Delta: -9})
+		assert.Equal(t, "Stock can not drop below the reserved quantity 2", err.Error())
+
+		_, err = stockService.Adjust(ctx, 1, model.StockAdjustment{StoreId: 9, Delta: 1})
+		assert.Equal(t, "Store with id 9 does not exist", err.Error())
+	})
+}
+
+func Test_ShouldGetLowStocks(t *testing.T) {
+	setup()
+	t.Run("ShouldGetLowStocks", func(t *testing.T) {
+		lowStocks, err := stockService.GetLowStocks(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(lowStocks))
+		assert.Equal(t, int64(2), lowStocks[0].ProductId)
+	})
+}
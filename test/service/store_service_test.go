@@ -0,0 +1,58 @@
+package service
+
+import (
+	"github.com/stretchr/testify/assert"
+	"product-app/domain"
+	"product-app/service/model"
+	"testing"
+)
+
+func Test_ShouldAddStoreWithTrimmedName(t *testing.T) {
+	setup()
+	t.Run("ShouldAddStoreWithTrimmedName", func(t *testing.T) {
+		store, err := storeService.Add(ctx, model.StoreCreate{Name: "  Bahçe Market "})
+		assert.Nil(t, err)
+		assert.Equal(t, domain.Store{Id: 4, Name: "Bahçe Market"}, store)
+
+		actualStore, _ := storeService.GetById(ctx, 4)
+		assert.Equal(t, store, actualStore)
+	})
+}
+
+func Test_WhenStoreNameDiffersOnlyInCase_ShouldReturnConflictError(t *testing.T) {
+	setup()
+	t.Run("WhenStoreNameDiffersOnlyInCase_ShouldReturnConflictError", func(t *testing.T) {
+		_, err := storeService.Add(ctx, model.StoreCreate{Name: "ABC Tech"})
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		err = storeService.Update(ctx, 2, model.StoreUpdate{Name: "abc tech"})
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		stores, _ := storeService.GetAllStores(ctx)
+		assert.Equal(t, 3, len(stores))
+	})
+}
+
+func Test_WhenStoreNameIsEmpty_ShouldReturnValidationError(t *testing.T) {
+	setup()
+	t.Run("WhenStoreNameIsEmpty_ShouldReturnValidationError", func(t *testing.T) {
+		_, err := storeService.Add(ctx, model.StoreCreate{Name: " "})
+		var validationErr *domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []domain.FieldError{
+			{Field: "name", Message: "Name is required"},
+		}, validationErr.FieldErrors)
+	})
+}
+
+func Test_WhenStoreHasProducts_ShouldNotDeleteStore(t *testing.T) {
+	setup()
+	t.Run("WhenStoreHasProducts_ShouldNotDeleteStore", func(t *testing.T) {
+		err := storeService.DeleteById(ctx, 1)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+
+		assert.Nil(t, storeService.DeleteById(ctx, 2))
+		_, err = storeService.GetById(ctx, 2)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+}